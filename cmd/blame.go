@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/mandelsoft/spiff/flow"
+	"github.com/mandelsoft/spiff/legacy/candiedyaml"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+var blame bool
+var blameReport string
+
+// loadSource parses a source file not given as template or stub,
+// for example a file read by the read function.
+func loadSource(source string) []yaml.Node {
+	if !fileExists(source) {
+		return nil
+	}
+	data, err := ioutil.ReadFile(source)
+	if err != nil {
+		return nil
+	}
+	docs, err := yaml.ParseMulti(source, data)
+	if err != nil {
+		return nil
+	}
+	return docs
+}
+
+// blameComments provides the provenance of leaf values as comment.
+func blameComments(sources *flow.SourceIndex) yaml.CommentFunction {
+	return func(path []string, node yaml.Node) string {
+		if node == nil {
+			return ""
+		}
+		return flow.DetermineProvenance(path, node, sources).String()
+	}
+}
+
+// provenanceReport describes the provenance of all leaf values of a
+// document as list of report entries.
+func provenanceReport(doc int, root yaml.Node, sources *flow.SourceIndex) []yaml.Node {
+	var entries []yaml.Node
+	for _, p := range flow.ProvenanceOfLeafs(root, sources) {
+		entry := map[string]yaml.Node{}
+		keys := []string{}
+		add := func(key string, value interface{}) {
+			keys = append(keys, key)
+			entry[key] = yaml.NewNode(value, "<blame>")
+		}
+		if doc > 0 {
+			add("document", int64(doc))
+		}
+		add("path", strings.Join(p.Path, "."))
+		add("source", p.Origin.Source)
+		if p.Origin.Position.IsValid() {
+			add("line", int64(p.Origin.Position.Line))
+			add("column", int64(p.Origin.Position.Column))
+		}
+		if p.SourcePath != nil {
+			add("sourcePath", strings.Join(p.SourcePath, "."))
+		}
+		if p.Origin.Expression != "" {
+			add("expression", p.Origin.Expression)
+		}
+		if len(p.Flags) > 0 {
+			flags := []yaml.Node{}
+			for _, f := range p.Flags {
+				flags = append(flags, yaml.NewNode(f, "<blame>"))
+			}
+			add("flags", flags)
+		}
+		entries = append(entries, yaml.OrderedNode(yaml.NewNode(entry, "<blame>"), keys))
+	}
+	return entries
+}
+
+// writeProvenanceReport writes the report in JSON format for files
+// with suffix .json and in YAML format otherwise.
+func writeProvenanceReport(file string, entries []yaml.Node) error {
+	var data []byte
+	var err error
+	report := yaml.NewNode(entries, "<blame>")
+	if strings.HasSuffix(file, ".json") {
+		data, err = yaml.ToJSON(report)
+	} else {
+		data, err = candiedyaml.Marshal(report)
+	}
+	if err != nil {
+		return fmt.Errorf("error marshalling provenance report: %s", err)
+	}
+	return ioutil.WriteFile(file, data, 0664)
+}
@@ -39,6 +39,7 @@ func init() {
 	convertCmd.Flags().StringVar(&outputPath, "path", "", "output is taken from given path")
 	convertCmd.Flags().BoolVar(&split, "split", false, "if the output is alist it will be split into separate documents")
 	convertCmd.Flags().StringArrayVar(&selection, "select", []string{}, "filter dedicated output fields")
+	convertCmd.Flags().BoolVar(&sortKeys, "sort-keys", false, "sort map keys instead of preserving the document order")
 }
 
 func convert(stdin bool, templateFilePath string, json, split bool, subpath string, selection []string) {
@@ -49,7 +50,7 @@ func convert(stdin bool, templateFilePath string, json, split bool, subpath stri
 		templateFile, err = ioutil.ReadAll(os.Stdin)
 		stdin = true
 	} else {
-		templateFile, err = ReadFile(templateFilePath)
+		templateFile, err = ReadFile(nil, templateFilePath)
 	}
 
 	if err != nil {
@@ -94,6 +95,9 @@ func convert(stdin bool, templateFilePath string, json, split bool, subpath stri
 				}
 				flowed = yaml.NewNode(new, "")
 			}
+			if sortKeys {
+				flowed = yaml.SortKeys(flowed)
+			}
 			if split {
 				if list, ok := flowed.Value().([]yaml.Node); ok {
 					for _, d := range list {
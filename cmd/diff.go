@@ -1,10 +1,13 @@
 package cmd
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"os"
 	"path"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -15,6 +18,12 @@ import (
 )
 
 var separator string
+var diffFormat string
+var noColor bool
+var diffKeys []string
+var diffIgnore []string
+var numericEquivalence bool
+var stringEquivalence bool
 
 // diffCmd represents the diff command
 var diffCmd = &cobra.Command{
@@ -26,7 +35,25 @@ Here streams with multiple documents are supported, also. To indicate
 no difference the number of documents in both streams must be identical
 and each document in the first stream must have no difference compared
 to the document with the same index in the second stream. Found differences
-are shown for each document separately.`,
+are shown for each document separately.
+
+The output format can be selected with --format:
+  text      human readable description of the differences (default)
+  json      structured description of the differences
+  jsonpatch JSON Patch (RFC 6902) transforming a into b
+  unified   unified diff of the normalized documents
+
+List entries are identified by their field name. With --key the fields
+used to identify the entries of lists can be configured per path pattern
+(<pattern>=<field>{,<field>}). Path patterns are dot separated path steps
+with glob characters; the step ** matches any number of path steps.
+Fields may be nested paths (metadata.name). With --ignore differences
+below matching paths are omitted.
+
+The command exits with code 1 if differences are found, and with code 2
+for errors. The unified format compares the normalized documents as text,
+therefore it cannot be combined with --key, --ignore or the equivalence
+options.`,
 	Args: func(cmd *cobra.Command, args []string) error {
 		if len(args) != 2 {
 			return errors.New("requires two args")
@@ -34,7 +61,21 @@ are shown for each document separately.`,
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
-		diff(args[0], args[1], separator)
+		opts := &compare.Options{
+			Ignore:             diffIgnore,
+			NumericEquivalence: numericEquivalence,
+			StringEquivalence:  stringEquivalence,
+		}
+		for _, k := range diffKeys {
+			sel, err := compare.ParseKeySelector(k)
+			if err != nil {
+				diffFatalln(err)
+			}
+			opts.Keys = append(opts.Keys, sel)
+		}
+		if diff(args[0], args[1], separator, diffFormat, !noColor, opts) {
+			os.Exit(1)
+		}
 	},
 }
 
@@ -42,46 +83,101 @@ func init() {
 	rootCmd.AddCommand(diffCmd)
 
 	diffCmd.Flags().StringVar(&separator, "separator", "", "Separator to print between diffs")
+	diffCmd.Flags().StringVar(&diffFormat, "format", "text", "output format (text, json, jsonpatch or unified)")
+	diffCmd.Flags().BoolVar(&noColor, "no-color", false, "omit color codes in text output")
+	diffCmd.Flags().StringArrayVar(&diffKeys, "key", nil, "key fields for lists matching a path pattern (<pattern>=<field>{,<field>})")
+	diffCmd.Flags().StringArrayVar(&diffIgnore, "ignore", nil, "path pattern to ignore")
+	diffCmd.Flags().BoolVar(&numericEquivalence, "numeric-equivalence", false, "treat integer and float values describing the same number as equal")
+	diffCmd.Flags().BoolVar(&stringEquivalence, "string-equivalence", false, "treat strings as equal to scalar values with the same string representation")
 }
 
-func diff(aFilePath, bFilePath string, separator string) {
-	aFile, err := ReadFile(aFilePath)
+// DIFF_ERROR is the exit code used for errors. Like for diff(1) the
+// exit code 1 indicates found differences.
+const DIFF_ERROR = 2
+
+func diffFatalln(v ...interface{}) {
+	log.Println(v...)
+	os.Exit(DIFF_ERROR)
+}
+
+// diff compares two document streams and reports whether there are
+// differences.
+func diff(aFilePath, bFilePath string, separator string, format string, color bool, opts *compare.Options) bool {
+	switch format {
+	case "text", "json", "jsonpatch", "unified":
+	default:
+		diffFatalln(fmt.Sprintf("invalid diff format %q (use text, json, jsonpatch or unified)", format))
+	}
+	if format == "unified" && (len(opts.Keys) > 0 || len(opts.Ignore) > 0 || opts.NumericEquivalence || opts.StringEquivalence) {
+		diffFatalln("the unified format cannot be combined with --key, --ignore or equivalence options")
+	}
+
+	aFile, err := ReadFile(nil, aFilePath)
 	if err != nil {
-		log.Fatalln(fmt.Sprintf("error reading a [%s]:", path.Clean(aFilePath)), err)
+		diffFatalln(fmt.Sprintf("error reading a [%s]:", path.Clean(aFilePath)), err)
 	}
 
 	aYAMLs, err := yaml.ParseMulti(aFilePath, aFile)
 	if err != nil {
-		log.Fatalln(fmt.Sprintf("error parsing a [%s]:", path.Clean(aFilePath)), err)
+		diffFatalln(fmt.Sprintf("error parsing a [%s]:", path.Clean(aFilePath)), err)
 	}
 
-	bFile, err := ReadFile(bFilePath)
+	bFile, err := ReadFile(nil, bFilePath)
 	if err != nil {
-		log.Fatalln(fmt.Sprintf("error reading b [%s]:", path.Clean(bFilePath)), err)
+		diffFatalln(fmt.Sprintf("error reading b [%s]:", path.Clean(bFilePath)), err)
 	}
 
 	bYAMLs, err := yaml.ParseMulti(bFilePath, bFile)
 	if err != nil {
-		log.Fatalln(fmt.Sprintf("error parsing b [%s]:", path.Clean(bFilePath)), err)
+		diffFatalln(fmt.Sprintf("error parsing b [%s]:", path.Clean(bFilePath)), err)
+	}
+
+	if format == "unified" {
+		return diffUnified(aFilePath, bFilePath, aYAMLs, bYAMLs)
 	}
 
 	if len(aYAMLs) != len(bYAMLs) {
-		fmt.Printf("Different number of documents (%d != %d)\n", len(aYAMLs), len(bYAMLs))
-		return
+		msg := fmt.Sprintf("Different number of documents (%d != %d)", len(aYAMLs), len(bYAMLs))
+		switch format {
+		case "text":
+			fmt.Println(msg)
+		case "json":
+			printJSON(map[string]interface{}{"different": true, "message": msg})
+		default:
+			diffFatalln(msg)
+		}
+		return true
 	}
 
 	ddiffs := make([][]compare.Diff, len(aYAMLs))
 	found := false
 	for no, aYAML := range aYAMLs {
 		bYAML := bYAMLs[no]
-		ddiffs[no] = compare.Compare(aYAML, bYAML)
+		ddiffs[no] = compare.CompareWithOptions(aYAML, bYAML, opts)
+		sort.SliceStable(ddiffs[no], func(i, j int) bool {
+			return strings.Join(ddiffs[no][i].Path, ".") < strings.Join(ddiffs[no][j].Path, ".")
+		})
 		if len(ddiffs[no]) != 0 {
 			found = true
 		}
 	}
+
+	switch format {
+	case "json":
+		diffJSON(ddiffs, found)
+		return found
+	case "jsonpatch":
+		diffJSONPatch(aYAMLs, bYAMLs, opts)
+		return found
+	}
+
 	if !found {
 		fmt.Println("no differences!")
-		return
+		return false
+	}
+	red, green, reset := "\x1b[31m", "\x1b[32m", "\x1b[0m"
+	if !color {
+		red, green, reset = "", "", ""
 	}
 	for no := range aYAMLs {
 		if len(ddiffs[no]) == 0 {
@@ -103,7 +199,7 @@ func diff(aFilePath, bFilePath string, separator string) {
 						panic(err)
 					}
 
-					fmt.Printf("  %s has:\n    \x1b[31m%s\x1b[0m\n", aFilePath, strings.Replace(string(ayaml), "\n", "\n    ", -1))
+					fmt.Printf("  %s has:\n    %s%s%s\n", yaml.SourceLocation(diff.A), red, strings.Replace(string(ayaml), "\n", "\n    ", -1), reset)
 				}
 
 				if diff.B != nil {
@@ -112,11 +208,114 @@ func diff(aFilePath, bFilePath string, separator string) {
 						panic(err)
 					}
 
-					fmt.Printf("  %s has:\n    \x1b[32m%s\x1b[0m\n", bFilePath, strings.Replace(string(byaml), "\n", "\n    ", -1))
+					fmt.Printf("  %s has:\n    %s%s%s\n", yaml.SourceLocation(diff.B), green, strings.Replace(string(byaml), "\n", "\n    ", -1), reset)
 				}
 
 				fmt.Print(separator)
 			}
 		}
 	}
+	return true
+}
+
+type jsonDifference struct {
+	Path           string          `json:"path"`
+	PathComponents []string        `json:"pathComponents"`
+	A              json.RawMessage `json:"a,omitempty"`
+	B              json.RawMessage `json:"b,omitempty"`
+	ASource        string          `json:"aSource,omitempty"`
+	BSource        string          `json:"bSource,omitempty"`
+}
+
+type jsonDocumentDiff struct {
+	Document    int              `json:"document"`
+	Differences []jsonDifference `json:"differences"`
+}
+
+func diffJSON(ddiffs [][]compare.Diff, found bool) {
+	docs := []jsonDocumentDiff{}
+	for no, diffs := range ddiffs {
+		doc := jsonDocumentDiff{Document: no + 1, Differences: []jsonDifference{}}
+		for _, d := range diffs {
+			e := jsonDifference{
+				Path:           strings.Join(d.Path, "."),
+				PathComponents: d.Path,
+			}
+			if e.PathComponents == nil {
+				e.PathComponents = []string{}
+			}
+			if d.A != nil {
+				e.A = toJSON(d.A)
+				e.ASource = yaml.SourceLocation(d.A)
+			}
+			if d.B != nil {
+				e.B = toJSON(d.B)
+				e.BSource = yaml.SourceLocation(d.B)
+			}
+			doc.Differences = append(doc.Differences, e)
+		}
+		docs = append(docs, doc)
+	}
+	printJSON(map[string]interface{}{"different": found, "documents": docs})
+}
+
+func diffJSONPatch(aYAMLs, bYAMLs []yaml.Node, opts *compare.Options) {
+	patches := [][]compare.PatchOperation{}
+	for no := range aYAMLs {
+		patch, err := compare.JSONPatchWithOptions(aYAMLs[no], bYAMLs[no], opts)
+		if err != nil {
+			diffFatalln(fmt.Sprintf("error creating patch for document %d:", no+1), err)
+		}
+		patches = append(patches, patch)
+	}
+	if len(patches) == 1 {
+		printJSON(patches[0])
+	} else {
+		printJSON(patches)
+	}
+}
+
+func diffUnified(aFilePath, bFilePath string, aYAMLs, bYAMLs []yaml.Node) bool {
+	found := false
+	for no := 0; no < len(aYAMLs) || no < len(bYAMLs); no++ {
+		aName, bName := aFilePath, bFilePath
+		if len(aYAMLs) > 1 || len(bYAMLs) > 1 {
+			aName = fmt.Sprintf("%s (document %d)", aFilePath, no+1)
+			bName = fmt.Sprintf("%s (document %d)", bFilePath, no+1)
+		}
+		d := compare.Unified(aName, bName, marshalDocument(aYAMLs, no), marshalDocument(bYAMLs, no), 3)
+		if d != "" {
+			found = true
+			fmt.Print(d)
+		}
+	}
+	return found
+}
+
+func marshalDocument(docs []yaml.Node, no int) string {
+	if no >= len(docs) {
+		return ""
+	}
+	data, err := candiedyaml.Marshal(docs[no])
+	if err != nil {
+		diffFatalln(fmt.Sprintf("error marshalling document %d:", no+1), err)
+	}
+	return string(data)
+}
+
+func toJSON(node yaml.Node) json.RawMessage {
+	data, err := yaml.ToJSON(node)
+	if err != nil {
+		diffFatalln("error converting to json:", err)
+	}
+	return data
+}
+
+func printJSON(v interface{}) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		diffFatalln("error writing json:", err)
+	}
 }
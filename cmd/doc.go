@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"path"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mandelsoft/spiff/flow"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+var docFormat string
+
+// docCmd represents the doc command
+var docCmd = &cobra.Command{
+	Use:   "doc template.yml",
+	Short: "Describe the parameters of a template",
+	Long: `Render a reference of the parameters declared by the __params
+section of a template. For every parameter its name, type, default value,
+description and validation conditions are shown, either as Markdown table
+or as json document.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("requires exactly one arg")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		doc(args[0], docFormat)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(docCmd)
+
+	docCmd.Flags().StringVar(&docFormat, "format", "markdown", "output format (markdown or json)")
+}
+
+// parameterDoc is the json representation of a template parameter.
+type parameterDoc struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Type        string            `json:"type,omitempty"`
+	Required    bool              `json:"required"`
+	Default     json.RawMessage   `json:"default,omitempty"`
+	Validate    []json.RawMessage `json:"validate,omitempty"`
+}
+
+// doc prints the parameter reference of a template.
+func doc(templateFilePath string, format string) {
+	if format != "markdown" && format != "json" {
+		log.Fatalf("invalid format %q (use markdown or json)\n", format)
+	}
+	data, err := ReadFile(nil, templateFilePath)
+	if err != nil {
+		log.Fatalln(fmt.Sprintf("error reading template [%s]:", path.Clean(templateFilePath)), err)
+	}
+	templateYAMLs, err := yaml.ParseMulti(templateFilePath, data)
+	if err != nil {
+		log.Fatalln(fmt.Sprintf("error parsing template [%s]:", path.Clean(templateFilePath)), err)
+	}
+
+	docs := []parameterDoc{}
+	for no, templateYAML := range templateYAMLs {
+		params, err := flow.Parameters(templateYAML)
+		if err != nil {
+			log.Fatalln(fmt.Sprintf("invalid parameter declarations (document %d):", no+1), err)
+		}
+		for _, p := range params {
+			d := parameterDoc{
+				Name:        p.Name,
+				Description: p.Description,
+				Type:        p.Type,
+				Required:    p.Required,
+			}
+			if p.Default != nil {
+				d.Default = toJSON(p.Default)
+			}
+			for _, c := range p.Validate {
+				d.Validate = append(d.Validate, toJSON(c))
+			}
+			docs = append(docs, d)
+		}
+	}
+
+	if format == "json" {
+		printJSON(docs)
+		return
+	}
+
+	fmt.Printf("# Parameters of %s\n\n", path.Base(templateFilePath))
+	if len(docs) == 0 {
+		fmt.Println("The template does not declare any parameters.")
+		return
+	}
+	fmt.Println("| Name | Type | Required | Default | Description | Validation |")
+	fmt.Println("|------|------|----------|---------|-------------|------------|")
+	for _, d := range docs {
+		required := "no"
+		if d.Required {
+			required = "yes"
+		}
+		var conditions []string
+		for _, c := range d.Validate {
+			conditions = append(conditions, markdownCode(c))
+		}
+		fmt.Printf("| `%s` | %s | %s | %s | %s | %s |\n", d.Name, d.Type, required,
+			markdownCode(d.Default), markdownText(d.Description), strings.Join(conditions, "<br>"))
+	}
+}
+
+// markdownCode formats a json value as code for a Markdown table cell.
+func markdownCode(data json.RawMessage) string {
+	if data == nil {
+		return ""
+	}
+	return "`" + markdownText(string(data)) + "`"
+}
+
+// markdownText escapes a text for a Markdown table cell.
+func markdownText(s string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(s)
+}
@@ -49,7 +49,7 @@ func encrypt(decrypt bool, args []string) {
 	if filePath == "-" {
 		file, err = ioutil.ReadAll(os.Stdin)
 	} else {
-		file, err = ReadFile(filePath)
+		file, err = ReadFile(nil, filePath)
 	}
 
 	if err != nil {
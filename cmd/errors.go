@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/mandelsoft/spiff/dynaml"
+	"github.com/mandelsoft/spiff/flow"
+)
+
+const (
+	ERROR_FORMAT_TEXT = "text"
+	ERROR_FORMAT_JSON = "json"
+)
+
+var errorFormat string
+
+// errorReport is the json representation of a processing error.
+type errorReport struct {
+	Message    string                      `json:"message"`
+	Error      string                      `json:"error,omitempty"`
+	Parameters flow.ParameterErrors        `json:"parameters,omitempty"`
+	Unresolved []dynaml.UnresolvedNodeInfo `json:"unresolved,omitempty"`
+}
+
+func checkErrorFormat() {
+	switch errorFormat {
+	case ERROR_FORMAT_TEXT, ERROR_FORMAT_JSON:
+	default:
+		log.Fatalf("invalid error format %q (use %s or %s)\n", errorFormat, ERROR_FORMAT_TEXT, ERROR_FORMAT_JSON)
+	}
+}
+
+// processingError describes a failed processing. It is reported
+// according to the selected error format.
+type processingError struct {
+	msg    string
+	err    error
+	legend string
+}
+
+func newProcessingError(msg string, err error, legend string) error {
+	return &processingError{msg, err, legend}
+}
+
+func (e *processingError) Error() string {
+	if e.err == nil {
+		return e.msg
+	}
+	return e.msg + " " + e.err.Error()
+}
+
+func (e *processingError) Unwrap() error {
+	return e.err
+}
+
+// exitOnError reports a processing error and terminates the program.
+func exitOnError(err error) {
+	if err == nil {
+		return
+	}
+	reportError(err)
+	os.Exit(1)
+}
+
+// reportError reports a processing error according to the error format.
+func reportError(err error) {
+	var p *processingError
+	if errors.As(err, &p) {
+		report(p.msg, p.err, p.legend)
+	} else {
+		report("error:", err, "")
+	}
+}
+
+// fatal reports an error and terminates the program.
+func fatal(msg string, err error, legend string) {
+	report(msg, err, legend)
+	os.Exit(1)
+}
+
+// report reports an error. The json error format provides the structured
+// description of unresolved nodes. Violations of template parameters are
+// reported separately, without the legend describing the processing error.
+func report(msg string, err error, legend string) {
+	var params flow.ParameterErrors
+	var invalid flow.InvalidParameters
+	switch {
+	case errors.As(err, &invalid):
+		params, err = invalid.Parameters, invalid.Err
+	case errors.As(err, &params):
+		err = nil
+	}
+	if errorFormat != ERROR_FORMAT_JSON {
+		switch {
+		case params != nil:
+			log.Println(msg, params)
+		case err == nil:
+			log.Println(msg)
+		}
+		if err != nil {
+			if legend != "" {
+				log.Println(msg, err, legend)
+			} else {
+				log.Println(msg, err)
+			}
+		}
+		return
+	}
+	report := errorReport{Message: strings.TrimSuffix(msg, ":"), Parameters: params}
+	var unresolved dynaml.UnresolvedNodes
+	if errors.As(err, &unresolved) {
+		report.Unresolved = unresolved.Info()
+	} else if err != nil {
+		report.Error = err.Error()
+	}
+	encoder := json.NewEncoder(os.Stderr)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if encoder.Encode(report) != nil {
+		log.Println(msg, err)
+	}
+}
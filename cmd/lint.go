@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mandelsoft/spiff/compile"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+var lintBindings string
+var lintValues []string
+var lintInterpolation bool
+
+// lintCmd represents the lint command
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Statically check templates and stubs",
+	Long: `Check a template and its stubs without evaluating them.
+Reported are dynaml parse errors, calls of unknown functions, wrong
+argument counts for builtin functions, unknown controls, references to
+names defined nowhere in the given documents and stub fields not used
+by the template. All findings are reported with their source locations.
+The command exits with a non-zero exit code if there are any findings.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("requires at least one arg")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		if lint(args[0], args[1:]) > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(lintCmd)
+
+	lintCmd.Flags().BoolVar(&lintInterpolation, "interpolation", false, "enable interpolation alpha feature")
+	lintCmd.Flags().StringVar(&lintBindings, "bindings", "", "yaml file with additional bindings to use")
+	lintCmd.Flags().StringArrayVarP(&lintValues, "define", "D", nil, "key/value bindings")
+}
+
+// lint checks the given files and prints all findings. It returns the
+// number of findings.
+func lint(templateFilePath string, stubFilePaths []string) int {
+	var findings compile.CompileErrors
+
+	read := func(file string, multi bool) []yaml.Node {
+		data, err := ReadFile(nil, file)
+		if err != nil {
+			findings = append(findings, compile.CompileError{Path: []string{path.Clean(file)}, Message: err})
+			return nil
+		}
+		var docs []yaml.Node
+		if multi {
+			docs, err = yaml.ParseMulti(file, data)
+		} else {
+			var doc yaml.Node
+			doc, err = yaml.Parse(file, data)
+			docs = []yaml.Node{doc}
+		}
+		if err != nil {
+			findings = append(findings, compile.CompileError{Path: []string{path.Clean(file)}, Message: err})
+			return nil
+		}
+		return docs
+	}
+
+	opts := compile.LintOptions{Interpolation: lintInterpolation}
+	if lintBindings != "" {
+		for _, b := range read(lintBindings, false) {
+			if m, ok := b.Value().(map[string]yaml.Node); ok {
+				opts.Names = append(opts.Names, yaml.GetSortedKeys(m)...)
+			}
+		}
+	}
+	values, err := createValuesFromArgs(lintValues)
+	if err != nil {
+		findings = append(findings, compile.CompileError{Path: []string{"define"}, Message: err})
+	}
+	defined := map[string]yaml.Node{}
+	for k, v := range values {
+		if err := addValue(defined, k, yaml.NewNode(v, "<values>")); err != nil {
+			findings = append(findings, compile.CompileError{Path: []string{"define"}, Message: err})
+		}
+	}
+	opts.Names = append(opts.Names, yaml.GetSortedKeys(defined)...)
+
+	templates := read(templateFilePath, true)
+	var stubs []yaml.Node
+	for _, file := range stubFilePaths {
+		stubs = append(stubs, read(file, false)...)
+	}
+
+	findings = append(findings, compile.Lint(templates, stubs, opts)...)
+	for _, f := range findings {
+		fmt.Println(f.Error())
+	}
+	return len(findings)
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mandelsoft/spiff/debug"
+	"github.com/mandelsoft/spiff/dynaml"
+	"github.com/mandelsoft/spiff/lsp"
+)
+
+var lspOptions lsp.Options
+
+// lspCmd represents the lsp command
+var lspCmd = &cobra.Command{
+	Use:   "lsp [stub.yml ...]",
+	Short: "Run a language server for dynaml in yaml documents",
+	Long: `Run a language server implementing the Language Server Protocol on
+stdin/stdout. It provides diagnostics for the static checks of the lint
+command, completion of function names and document paths, hover showing
+the values of the last merge and go-to-definition for references.
+The given stub files are used for checking and merging opened documents.
+Operating system commands are not executed by merges of the language server.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		lspOptions.Stubs = args
+		dynaml.DisableStdin("used by the language server")
+		if err := lsp.NewServer(lspOptions).Serve(os.Stdin, os.Stdout); err != nil {
+			log.Fatalln("language server failed:", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(lspCmd)
+
+	lspCmd.Flags().BoolVar(&lspOptions.Lint.Interpolation, "interpolation", false, "enable interpolation alpha feature")
+	lspCmd.Flags().BoolVar(&debug.DebugFlag, "debug", false, "Print state info")
+}
@@ -1,14 +1,16 @@
 package cmd
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
-	"log"
 	"os"
 	"path"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -32,6 +34,24 @@ var processingOptions flow.Options
 var state string
 var bindings string
 var values []string
+var sortKeys bool
+var policyFile string
+var cacheDir string
+var offline bool
+var lockFile string
+var httpTimeout time.Duration
+var deterministic bool
+var dryRun bool
+var profile bool
+var profileReport string
+var seed string
+var watch bool
+var watchDiff bool
+var watchInterval time.Duration
+var warningsAsErrors bool
+
+// output is the writer used for the processing result.
+var output io.Writer = os.Stdout
 
 // mergeCmd represents the merge command
 var mergeCmd = &cobra.Command{
@@ -46,11 +66,25 @@ var mergeCmd = &cobra.Command{
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
+		checkErrorFormat()
 		vals, err := createValuesFromArgs(values)
 		if err != nil {
-			log.Fatalf("%s\n", err)
+			fatal("error in value definitions (-D):", err, "")
 		}
-		merge(false, args[0], processingOptions, asJSON, split, outputPath, selection, state, bindings, vals, nil, args[1:])
+		if watch || watchDiff {
+			watchMerge(args, func(inputs inputFiles) error {
+				defstate, err := createState()
+				if err != nil {
+					return err
+				}
+				defer func() { inputs.add(defstate.CachedFiles()...) }()
+				return merge(defstate, false, args[0], processingOptions, asJSON, split, outputPath, selection, state, bindings, vals, nil, args[1:])
+			})
+			return
+		}
+		defstate, err := createState()
+		exitOnError(err)
+		exitOnError(merge(defstate, false, args[0], processingOptions, asJSON, split, outputPath, selection, state, bindings, vals, nil, args[1:]))
 	},
 }
 
@@ -75,6 +109,24 @@ func init() {
 	mergeCmd.Flags().StringArrayVar(&tagdefs, "tag", []string{}, "tag files (tag:path)")
 	mergeCmd.Flags().StringArrayVar(&featureFlags, "features", []string{}, "set feature flags")
 	mergeCmd.Flags().StringVar(&expr, "evaluate", "", "evaluation expression")
+	mergeCmd.Flags().BoolVar(&sortKeys, "sort-keys", false, "sort map keys instead of preserving the template order")
+	mergeCmd.Flags().BoolVar(&blame, "blame", false, "annotate output values with their origin as comments")
+	mergeCmd.Flags().StringVar(&errorFormat, "error-format", ERROR_FORMAT_TEXT, "format of processing errors (text or json)")
+	mergeCmd.Flags().StringVar(&policyFile, "policy", "", "policy file restricting the access to executables, files, URLs and environment variables")
+	mergeCmd.Flags().StringVar(&cacheDir, "cache-dir", "", "directory used to cache content read from http(s) URLs")
+	mergeCmd.Flags().BoolVar(&offline, "offline", false, "serve http(s) content from the cache, only")
+	mergeCmd.Flags().StringVar(&lockFile, "lock-file", "", "lock file recording the digests of content read from http(s) URLs")
+	mergeCmd.Flags().DurationVar(&httpTimeout, "http-timeout", flow.DefaultFetchTimeout, "timeout for http(s) requests")
+	mergeCmd.Flags().BoolVar(&deterministic, "deterministic", false, "derive random values, keys, salts and temp file names from a seed")
+	mergeCmd.Flags().StringVar(&seed, "seed", "", "seed for the deterministic mode (implies --deterministic)")
+	mergeCmd.Flags().BoolVar(&profile, "profile", false, "print the effort spent for the processing (flow iterations, node evaluations, function calls)")
+	mergeCmd.Flags().StringVar(&profileReport, "profile-report", "", "write the profile of the processing to a json file")
+	mergeCmd.Flags().BoolVar(&dryRun, "dry-run", false, "record file modifications and command executions instead of performing them")
+	mergeCmd.Flags().BoolVar(&watch, "watch", false, "render again whenever an input file changes")
+	mergeCmd.Flags().BoolVar(&watchDiff, "watch-diff", false, "watch mode printing the differences to the previous rendering (implies --watch)")
+	mergeCmd.Flags().DurationVar(&watchInterval, "watch-interval", time.Second, "polling interval for the watch mode")
+	mergeCmd.Flags().BoolVar(&warningsAsErrors, "warnings-as-errors", false, "fail the processing if warnings are reported")
+	mergeCmd.Flags().StringVar(&blameReport, "blame-report", "", "write the origin of all output values to a report file (yaml or json)")
 }
 
 func createValuesFromArgs(values []string) (map[string]string, error) {
@@ -85,10 +137,10 @@ func createValuesFromArgs(values []string) (map[string]string, error) {
 	for _, s := range values {
 		parts := strings.Split(s, "=")
 		if len(parts) != 2 {
-			return nil, fmt.Errorf("invalid value definition %q\n", s)
+			return nil, fmt.Errorf("invalid value definition %q", s)
 		}
 		if parts[0] == "" {
-			return nil, fmt.Errorf("empty key in value definition %q\n", s)
+			return nil, fmt.Errorf("empty key in value definition %q", s)
 		}
 		result[parts[0]] = parts[1]
 	}
@@ -103,52 +155,93 @@ func fileExists(filename string) bool {
 	return !info.IsDir()
 }
 
-func readYAML(filename string, desc string, required bool) yaml.Node {
+func readYAML(state *flow.State, filename string, desc string, required bool) (yaml.Node, error) {
 	if filename != "" {
-		if fileExists(filename) {
-			data, err := ioutil.ReadFile(filename)
+		if dynaml.LookupResolver(nil, filename) != nil || fileExists(filename) {
+			data, err := ReadFile(state, filename)
 			if required && err != nil {
-				log.Fatalln(fmt.Sprintf("error reading %s [%s]:", desc, path.Clean(filename)), err)
+				return nil, newProcessingError(fmt.Sprintf("error reading %s [%s]:", desc, path.Clean(filename)), err, "")
 			}
 			doc, err := yaml.Parse(filename, data)
 			if err != nil {
-				log.Fatalln(fmt.Sprintf("error parsing %s [%s]:", desc, path.Clean(filename)), err)
+				return nil, newProcessingError(fmt.Sprintf("error parsing %s [%s]:", desc, path.Clean(filename)), err, "")
 			}
-			return doc
+			return doc, nil
 		}
 	}
-	return nil
+	return nil, nil
 }
 
-func merge(stdin bool, templateFilePath string, opts flow.Options, json, split bool,
-	subpath string, selection []string, stateFilePath, bindingFilePath string, values map[string]string, stubs []yaml.Node, stubFilePaths []string) {
-	var templateFile []byte
-	var err error
+// createState creates the processing state configured by the command line.
+func createState() (*flow.State, error) {
+	features := features.Features()
+	for _, list := range featureFlags {
+		for _, f := range strings.Split(list, ",") {
+			if err := features.Set(strings.TrimSpace(f), true); err != nil {
+				return nil, newProcessingError("invalid feature flags:", err, "")
+			}
+		}
+	}
+	if interpolation {
+		features.SetInterpolation(true)
+	}
+	policy, err := loadPolicy(policyFile)
+	if err != nil {
+		return nil, err
+	}
+	fetcher, err := createFetcher()
+	if err != nil {
+		return nil, err
+	}
+	var profiler *dynaml.Profiler
+	if profile || profileReport != "" {
+		profiler = dynaml.NewProfiler()
+	}
+	state := flow.NewDefaultState().SetFeatures(features).SetPolicy(policy).SetFetcher(fetcher).SetWarnings(&dynaml.Warnings{})
+	if deterministic || seed != "" {
+		state.SetDeterministic(seed)
+	}
+	state.SetProfiler(profiler)
+	if dryRun {
+		state.SetDryRun(nil)
+	}
+	return state, nil
+}
 
+func merge(defstate *flow.State, stdin bool, templateFilePath string, opts flow.Options, json, split bool,
+	subpath string, selection []string, stateFilePath, bindingFilePath string, values map[string]string, stubs []yaml.Node, stubFilePaths []string) error {
+	var err error
+	var templateFile []byte
 	if templateFilePath == "-" {
 		templateFile, err = ioutil.ReadAll(os.Stdin)
 		stdin = true
 	} else {
-		templateFile, err = ReadFile(templateFilePath)
+		templateFile, err = ReadFile(defstate, templateFilePath)
 	}
 
 	if err != nil {
-		log.Fatalln(fmt.Sprintf("error reading template [%s]:", path.Clean(templateFilePath)), err)
+		return newProcessingError(fmt.Sprintf("error reading template [%s]:", path.Clean(templateFilePath)), err, "")
 	}
 
 	templateYAMLs, err := yaml.ParseMulti(templateFilePath, templateFile)
 	if err != nil {
-		log.Fatalln(fmt.Sprintf("error parsing template [%s]:", path.Clean(templateFilePath)), err)
+		return newProcessingError(fmt.Sprintf("error parsing template [%s]:", path.Clean(templateFilePath)), err, "")
 	}
 
 	var stateYAML yaml.Node
 	if stateFilePath != "" {
 		if len(templateYAMLs) > 1 {
-			log.Fatalln(fmt.Sprintf("state handling not supported gor multi documents [%s]:", path.Clean(templateFilePath)), err)
+			return newProcessingError(fmt.Sprintf("state handling not supported for multi documents [%s]", path.Clean(templateFilePath)), nil, "")
 		}
-		stateYAML = readYAML(stateFilePath, "state file", false)
+		stateYAML, err = readYAML(defstate, stateFilePath, "state file", false)
+		if err != nil {
+			return err
+		}
+	}
+	bindingYAML, err := readYAML(defstate, bindingFilePath, "bindings file", true)
+	if err != nil {
+		return err
 	}
-	bindingYAML := readYAML(bindingFilePath, "bindings file", true)
 
 	if len(values) > 0 {
 		if bindingYAML == nil {
@@ -156,7 +249,7 @@ func merge(stdin bool, templateFilePath string, opts flow.Options, json, split b
 		}
 		m, ok := bindingYAML.Value().(map[string]yaml.Node)
 		if !ok {
-			log.Fatalf("binding %q must be a map\n", bindingFilePath)
+			return newProcessingError(fmt.Sprintf("binding %q must be a map", bindingFilePath), nil, "")
 		}
 		for k, v := range values {
 			i, err := strconv.ParseInt(v, 10, 64)
@@ -166,37 +259,17 @@ func merge(stdin bool, templateFilePath string, opts flow.Options, json, split b
 				err = addValue(m, k, yaml.NewNode(v, "<values>"))
 			}
 			if err != nil {
-				log.Fatalln(fmt.Sprintf("error in value definitions (-D): %s", err))
+				return newProcessingError("error in value definitions (-D):", err, "")
 			}
 		}
 
 	}
 
-	tags := []*dynaml.Tag{}
-
-	for _, tagDef := range tagdefs {
-		i := strings.Index(tagDef, ":")
-		if i <= 0 {
-			log.Fatalln(fmt.Sprintf("tag file must be preceeded by a tag (<tag>:<path>)"))
-		}
-		tagName := tagDef[:i]
-		err := dynaml.CheckTagName(tagName)
-		if err != nil {
-			log.Fatalln(fmt.Sprintf("invalid tag name [%s]:", path.Clean(tagName)), err)
-		}
-		tagFilePath := tagDef[i+1:]
-		tagFile, err := ReadFile(tagFilePath)
-		if err != nil {
-			log.Fatalln(fmt.Sprintf("error reading tag file [%s]:", path.Clean(tagFilePath)), err)
-		}
-
-		tagYAML, err := yaml.Parse(tagFilePath, tagFile)
-		if err != nil {
-			log.Fatalln(fmt.Sprintf("error parsing tag file [%s]:", path.Clean(tagFilePath)), err)
-		}
-
-		tags = append(tags, dynaml.NewTag(tagName, tagYAML, nil, dynaml.TAG_SCOPE_GLOBAL))
+	tags, err := createTags(defstate, tagdefs)
+	if err != nil {
+		return err
 	}
+	defstate.SetTags(tags...)
 
 	if stubs == nil {
 		stubs = []yaml.Node{}
@@ -208,63 +281,65 @@ func merge(stdin bool, templateFilePath string, opts flow.Options, json, split b
 		var err error
 		if stubFilePath == "-" {
 			if stdin {
-				log.Fatalln(fmt.Sprintf("stdin cannot be used twice"))
+				return newProcessingError("stdin cannot be used twice", nil, "")
 			}
 			stubFile, err = ioutil.ReadAll(os.Stdin)
 			stdin = true
 		} else {
-			stubFile, err = ReadFile(stubFilePath)
+			stubFile, err = ReadFile(defstate, stubFilePath)
 		}
 		if err != nil {
-			log.Fatalln(fmt.Sprintf("error reading stub [%s]:", path.Clean(stubFilePath)), err)
+			return newProcessingError(fmt.Sprintf("error reading stub [%s]:", path.Clean(stubFilePath)), err, "")
 		}
 
 		stubYAML, err := yaml.Parse(stubFilePath, stubFile)
 		if err != nil {
-			log.Fatalln(fmt.Sprintf("error parsing stub [%s]:", path.Clean(stubFilePath)), err)
+			return newProcessingError(fmt.Sprintf("error parsing stub [%s]:", path.Clean(stubFilePath)), err, "")
 		}
 
 		stubs = append(stubs, stubYAML)
 	}
 
+	if stdin {
+		dynaml.DisableStdin("already used as input document")
+	}
+
 	if stateYAML != nil {
 		stubs = append(stubs, stateYAML)
 	}
 
+	var sources *flow.SourceIndex
+	if blame || blameReport != "" {
+		if blame && json {
+			return newProcessingError("comment annotations (--blame) require yaml output, use --blame-report", nil, "")
+		}
+		// stubs are modified by their preparation
+		sources = flow.NewSourceIndex(loadSource, append(append(templateYAMLs[:0:0], templateYAMLs...), stubs...)...)
+		sources.Add(bindingYAML)
+	}
+	var report []yaml.Node
+
 	legend := "\nerror classification:\n" +
 		" *: error in local dynaml expression\n" +
-		" @: dependent of or involved in a cycle\n" +
+		" !: involved in a reference cycle\n" +
+		" @: dependent on unresolved nodes\n" +
 		" -: depending on a node with an error"
 
-	var binding dynaml.Binding
-	features := features.Features()
-	for _, list := range featureFlags {
-		for _, f := range strings.Split(list, ",") {
-			if err := features.Set(strings.TrimSpace(f), true); err != nil {
-				log.Fatalln(err.Error())
-			}
-		}
-	}
-	if interpolation {
-		features.SetInterpolation(true)
-	}
-	if bindingYAML != nil || features.Size() > 0 || len(tags) > 0 || len(templateYAMLs) > 1 {
-		defstate := flow.NewDefaultState().SetTags(tags...).SetFeatures(features)
-		binding = flow.NewEnvironment(
-			nil, "context", defstate)
-		if bindingYAML != nil {
-			values, ok := bindingYAML.Value().(map[string]yaml.Node)
-			if !ok {
-				log.Fatalln("bindings must be given as map")
-			}
-			binding = binding.WithLocalScope(values)
+	binding := flow.NewEnvironment(
+		nil, "context", defstate)
+	if bindingYAML != nil {
+		values, ok := bindingYAML.Value().(map[string]yaml.Node)
+		if !ok {
+			return newProcessingError("bindings must be given as map", nil, "")
 		}
-		features = binding.GetFeatures()
+		binding = binding.WithLocalScope(values)
 	}
+	features := binding.GetFeatures()
+	warnings := defstate.GetWarnings()
 
 	prepared, err := flow.PrepareStubs(binding, processingOptions.Partial, stubs...)
 	if !processingOptions.Partial && err != nil {
-		log.Fatalln("error generating manifest:", err, legend)
+		return newProcessingError("error generating manifest:", err, legend)
 	}
 
 	result := [][]byte{}
@@ -279,7 +354,7 @@ func merge(stdin bool, templateFilePath string, opts flow.Options, json, split b
 			count++
 			flowed, err := flow.Apply(binding, templateYAML, prepared, opts)
 			if !opts.Partial && err != nil {
-				log.Fatalln(fmt.Sprintf("error generating manifest%s:", doc), err, legend)
+				return newProcessingError(fmt.Sprintf("error generating manifest%s:", doc), err, legend)
 			}
 			if err != nil {
 				flowed = dynaml.ResetUnresolvedNodes(flowed)
@@ -291,7 +366,7 @@ func merge(stdin bool, templateFilePath string, opts flow.Options, json, split b
 				comps := dynaml.PathComponents(subpath, false)
 				node, ok := yaml.FindR(true, flowed, features, comps...)
 				if !ok {
-					log.Fatalln(fmt.Sprintf("path %q not found%s", subpath, doc))
+					return newProcessingError(fmt.Sprintf("path %q not found%s", subpath, doc), nil, "")
 				}
 				flowed = node
 			}
@@ -322,24 +397,24 @@ func merge(stdin bool, templateFilePath string, opts flow.Options, json, split b
 					if old {
 						os.Rename(stateFilePath+".bak", stateFilePath)
 					}
-					log.Fatalln(fmt.Sprintf("cannot write state file %q", stateFilePath))
+					return newProcessingError(fmt.Sprintf("cannot write state file %q:", stateFilePath), err, "")
 				}
 			}
 
 			if len(expr) > 0 {
 				e, err := dynaml.Parse(expr, []string{}, []string{})
 				if err != nil {
-					log.Fatalln(fmt.Sprintf("invalid expression %q: %s", expr, err))
+					return newProcessingError(fmt.Sprintf("invalid expression %q:", expr), err, "")
 				}
 				if m, ok := flowed.Value().(map[string]yaml.Node); ok {
 					binding := flow.NewNestedEnvironment(nil, "context", binding).WithLocalScope(m)
 					v, err := flow.Cascade(binding, yaml.NewNode(e, "<expr>"), flow.Options{})
 					if err != nil {
-						log.Fatalln(fmt.Sprintf("expression %q failed: %s", expr, err))
+						return newProcessingError(fmt.Sprintf("expression %q failed:", expr), err, "")
 					}
 					flowed = v
 				} else {
-					log.Fatalln("no map document")
+					return newProcessingError("no map document", nil, "")
 				}
 			}
 
@@ -349,7 +424,7 @@ func merge(stdin bool, templateFilePath string, opts flow.Options, json, split b
 					comps := dynaml.PathComponents(p, false)
 					node, ok := yaml.FindR(true, flowed, features, comps...)
 					if !ok {
-						log.Fatalln(fmt.Sprintf("path %q not found%s", subpath, doc))
+						return newProcessingError(fmt.Sprintf("path %q not found%s", subpath, doc), nil, "")
 					}
 					new[comps[len(comps)-1]] = node
 
@@ -357,16 +432,32 @@ func merge(stdin bool, templateFilePath string, opts flow.Options, json, split b
 				flowed = yaml.NewNode(new, "")
 			}
 
+			if sortKeys {
+				flowed = yaml.SortKeys(flowed)
+			}
+
+			if blameReport != "" {
+				n := 0
+				if len(templateYAMLs) > 1 {
+					n = no + 1
+				}
+				report = append(report, provenanceReport(n, flowed, sources)...)
+			}
+
 			if split {
 				if list, ok := flowed.Value().([]yaml.Node); ok {
 					for _, d := range list {
 						if json {
 							bytes, err = yaml.ToJSON(d)
 						} else {
-							bytes, err = candiedyaml.Marshal(d)
+							if blame {
+								bytes, err = yaml.MarshalWithComments(d, blameComments(sources))
+							} else {
+								bytes, err = candiedyaml.Marshal(d)
+							}
 						}
 						if err != nil {
-							log.Fatalln(fmt.Sprintf("error marshalling manifest%s:", doc), err)
+							return newProcessingError(fmt.Sprintf("error marshalling manifest%s:", doc), err, "")
 						}
 						result = append(result, bytes)
 					}
@@ -376,26 +467,88 @@ func merge(stdin bool, templateFilePath string, opts flow.Options, json, split b
 			if json {
 				bytes, err = yaml.ToJSON(flowed)
 			} else {
-				bytes, err = candiedyaml.Marshal(flowed)
+				if blame {
+					bytes, err = yaml.MarshalWithComments(flowed, blameComments(sources))
+				} else {
+					bytes, err = candiedyaml.Marshal(flowed)
+				}
 			}
 			if err != nil {
-				log.Fatalln(fmt.Sprintf("error marshalling manifest%s:", doc), err)
+				return newProcessingError(fmt.Sprintf("error marshalling manifest%s:", doc), err, "")
 			}
 		}
 		result = append(result, bytes)
 	}
 
+	if fetcher := defstate.GetFetcher(); fetcher != nil {
+		if err := fetcher.SaveLockFile(); err != nil {
+			return newProcessingError(fmt.Sprintf("cannot write lock file %q:", lockFile), err, "")
+		}
+	}
+
+	if blameReport != "" {
+		if err := writeProvenanceReport(blameReport, report); err != nil {
+			return newProcessingError(fmt.Sprintf("cannot write provenance report %q:", blameReport), err, "")
+		}
+	}
+
+	if warningsAsErrors && len(warnings.List()) > 0 {
+		printWarnings(warnings)
+		return newProcessingError(fmt.Sprintf("%d warning(s) found, treated as errors", len(warnings.List())), nil, "")
+	}
+
 	for _, bytes := range result {
 		if !json && (len(result) > 1 || len(bytes) == 0) {
-			fmt.Println("---")
+			fmt.Fprintln(output, "---")
 		}
 		if bytes != nil {
-			fmt.Print(string(bytes))
+			fmt.Fprint(output, string(bytes))
 			if json {
-				fmt.Println()
+				fmt.Fprintln(output)
 			}
 		}
 	}
+
+	if dryRun {
+		printJournal(binding.GetState().GetJournal())
+	}
+	if profiler := defstate.GetProfiler(); profiler != nil {
+		if err := writeProfile(profiler.Report()); err != nil {
+			return err
+		}
+	}
+	printWarnings(warnings)
+	return nil
+}
+
+// createTags reads the global tags given by tag definitions (<tag>:<path>).
+func createTags(state *flow.State, tagdefs []string) ([]*dynaml.Tag, error) {
+	tags := []*dynaml.Tag{}
+
+	for _, tagDef := range tagdefs {
+		i := strings.Index(tagDef, ":")
+		if i <= 0 {
+			return nil, newProcessingError("tag file must be preceeded by a tag (<tag>:<path>)", nil, "")
+		}
+		tagName := tagDef[:i]
+		err := dynaml.CheckTagName(tagName)
+		if err != nil {
+			return nil, newProcessingError(fmt.Sprintf("invalid tag name [%s]:", path.Clean(tagName)), err, "")
+		}
+		tagFilePath := tagDef[i+1:]
+		tagFile, err := ReadFile(state, tagFilePath)
+		if err != nil {
+			return nil, newProcessingError(fmt.Sprintf("error reading tag file [%s]:", path.Clean(tagFilePath)), err, "")
+		}
+
+		tagYAML, err := yaml.Parse(tagFilePath, tagFile)
+		if err != nil {
+			return nil, newProcessingError(fmt.Sprintf("error parsing tag file [%s]:", path.Clean(tagFilePath)), err, "")
+		}
+
+		tags = append(tags, dynaml.NewTag(tagName, tagYAML, nil, dynaml.TAG_SCOPE_GLOBAL))
+	}
+	return tags, nil
 }
 
 func addValue(m map[string]yaml.Node, name string, value yaml.Node) error {
@@ -437,3 +590,75 @@ func addValue(m map[string]yaml.Node, name string, value yaml.Node) error {
 	m[comps[len(comps)-1]] = value
 	return nil
 }
+
+// printJournal prints the side effects recorded in dry-run mode
+// to stderr.
+func printJournal(journal *dynaml.Journal) {
+	entries := journal.Entries()
+	if len(entries) == 0 {
+		fmt.Fprintln(os.Stderr, "dry-run: no side effects")
+		return
+	}
+	fmt.Fprintln(os.Stderr, "dry-run: side effects not performed:")
+	for _, e := range entries {
+		fmt.Fprintf(os.Stderr, "  %s\n", e)
+	}
+}
+
+// printWarnings prints the warnings of a processing to stderr.
+func printWarnings(warnings *dynaml.Warnings) {
+	for _, w := range warnings.List() {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", w)
+	}
+}
+
+// writeProfile prints the profile of a processing to stderr and/or
+// writes it to the profile report file.
+func writeProfile(report *dynaml.ProfileReport) error {
+	if profile {
+		fmt.Fprintln(os.Stderr, "profile:")
+		report.Write(os.Stderr, 20)
+	}
+	if profileReport != "" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err == nil {
+			err = ioutil.WriteFile(profileReport, append(data, '\n'), 0644)
+		}
+		if err != nil {
+			return newProcessingError(fmt.Sprintf("cannot write profile report %q:", profileReport), err, "")
+		}
+	}
+	return nil
+}
+
+func createFetcher() (*flow.Fetcher, error) {
+	if cacheDir == "" && !offline && lockFile == "" && httpTimeout == flow.DefaultFetchTimeout {
+		return nil, nil
+	}
+	if offline && cacheDir == "" {
+		return nil, newProcessingError("offline mode requires a cache directory (--cache-dir)", nil, "")
+	}
+	fetcher := flow.NewFetcher(cacheDir, offline)
+	fetcher.Timeout = httpTimeout
+	if lockFile != "" {
+		if err := fetcher.UseLockFile(lockFile); err != nil {
+			return nil, newProcessingError(fmt.Sprintf("cannot use lock file %q:", lockFile), err, "")
+		}
+	}
+	return fetcher, nil
+}
+
+func loadPolicy(policyFilePath string) (*dynaml.Policy, error) {
+	if policyFilePath == "" {
+		return nil, nil
+	}
+	data, err := ReadFile(nil, policyFilePath)
+	if err != nil {
+		return nil, newProcessingError(fmt.Sprintf("error reading policy [%s]:", path.Clean(policyFilePath)), err, "")
+	}
+	policy, err := dynaml.ParsePolicy(policyFilePath, data)
+	if err != nil {
+		return nil, newProcessingError(fmt.Sprintf("error parsing policy [%s]:", path.Clean(policyFilePath)), err, "")
+	}
+	return policy, nil
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mandelsoft/spiff/debug"
+	"github.com/mandelsoft/spiff/dynaml"
+	"github.com/mandelsoft/spiff/features"
+	"github.com/mandelsoft/spiff/repl"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+var replHistory string
+
+// replCmd represents the repl command
+var replCmd = &cobra.Command{
+	Use:   "repl template.yml [stub.yml ...]",
+	Short: "Evaluate dynaml expressions interactively on a merged template",
+	Long: `Merge a template with its stubs once and evaluate dynaml expressions
+entered interactively against the merged document. The context node
+used for evaluations can be selected by a path, additional stubs can
+be loaded and previous inputs can be repeated. Enter :help for a list
+of the available commands.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("requires at least one arg")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		runRepl(args[0], args[1:])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(replCmd)
+
+	history := ""
+	if home, err := os.UserHomeDir(); err == nil {
+		history = filepath.Join(home, ".spiff_history")
+	}
+	replCmd.Flags().BoolVar(&interpolation, "interpolation", interpolation, "enable interpolation alpha feature")
+	replCmd.Flags().BoolVar(&debug.DebugFlag, "debug", false, "Print state info")
+	replCmd.Flags().BoolVar(&processingOptions.Partial, "partial", false, "Allow partial evaluation only")
+	replCmd.Flags().StringVar(&bindings, "bindings", "", "yaml file with additional bindings to use")
+	replCmd.Flags().StringArrayVarP(&values, "define", "D", nil, "key/value bindings")
+	replCmd.Flags().StringArrayVar(&tagdefs, "tag", []string{}, "tag files (tag:path)")
+	replCmd.Flags().StringArrayVar(&featureFlags, "features", []string{}, "set feature flags")
+	replCmd.Flags().StringVar(&replHistory, "history", history, "file used to persist the input history (empty for none)")
+}
+
+func runRepl(templateFilePath string, stubFilePaths []string) {
+	tags, err := createTags(nil, tagdefs)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	opts := repl.Options{
+		Stubs:    stubFilePaths,
+		Tags:     tags,
+		Partial:  processingOptions.Partial,
+		History:  replHistory,
+		ReadFile: func(file string) ([]byte, error) { return ReadFile(nil, file) },
+		Features: features.Features(),
+	}
+	for _, list := range featureFlags {
+		for _, f := range strings.Split(list, ",") {
+			if err := opts.Features.Set(strings.TrimSpace(f), true); err != nil {
+				log.Fatalln(err.Error())
+			}
+		}
+	}
+	if interpolation {
+		opts.Features.SetInterpolation(true)
+	}
+
+	bindingYAML, err := readYAML(nil, bindings, "bindings file", true)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	vals, err := createValuesFromArgs(values)
+	if err != nil {
+		log.Fatalf("%s\n", err)
+	}
+	if bindingYAML != nil || len(vals) > 0 {
+		m := map[string]yaml.Node{}
+		if bindingYAML != nil {
+			b, ok := bindingYAML.Value().(map[string]yaml.Node)
+			if !ok {
+				log.Fatalln("bindings must be given as map")
+			}
+			m = b
+		}
+		for k, v := range vals {
+			i, err := strconv.ParseInt(v, 10, 64)
+			if err == nil {
+				err = addValue(m, k, yaml.NewNode(i, "<values>"))
+			} else {
+				err = addValue(m, k, yaml.NewNode(v, "<values>"))
+			}
+			if err != nil {
+				log.Fatalln(fmt.Sprintf("error in value definitions (-D): %s", err))
+			}
+		}
+		opts.Bindings = m
+	}
+
+	dynaml.DisableStdin("used by the interactive session")
+	session, err := repl.NewSession(templateFilePath, opts)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer session.Close()
+	fmt.Println("spiff repl, enter :help for the available commands")
+	if err := session.Run(os.Stdin, os.Stdout); err != nil {
+		log.Fatalln(err)
+	}
+}
@@ -2,14 +2,12 @@ package cmd
 
 import (
 	"fmt"
-	"io/ioutil"
-	"net/http"
 	"os"
-	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/mandelsoft/spiff/dynaml"
 	"github.com/mandelsoft/spiff/flow"
 )
 
@@ -40,20 +38,19 @@ func initConfig() {
 	viper.AutomaticEnv() // read in environment variables that match
 }
 
-func ReadFile(file string) ([]byte, error) {
-	if strings.HasPrefix(file, "http:") || strings.HasPrefix(file, "https:") {
-		response, err := http.Get(file)
-		if err != nil {
-			return nil, fmt.Errorf("error getting [%s]: %s", file, err)
-		} else {
-			if response.StatusCode != http.StatusOK {
-				defer response.Body.Close()
-				msg, _ := ioutil.ReadAll(response.Body)
-				return nil, fmt.Errorf("[status %d]: %s", response.StatusCode, msg)
-			}
-			return ioutil.ReadAll(response.Body)
-		}
-	} else {
-		return ioutil.ReadFile(file)
+// ReadFile reads a file or the content of a location with a scheme
+// supported by the registered content resolvers. The content is accessed
+// by the given processing state and therefore obeys its policy, fetcher
+// and content cache. Without a state a default state is used.
+func ReadFile(state *flow.State, file string) ([]byte, error) {
+	if state == nil {
+		state = flow.NewDefaultState()
 	}
+	if dynaml.LookupResolver(state.GetRegistry(), file) != nil {
+		return state.GetFileContent(file, true)
+	}
+	if err := state.GetPolicy().CheckRead(file); err != nil {
+		return nil, err
+	}
+	return state.FileSystem().ReadFile(file)
 }
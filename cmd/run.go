@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"os"
 	"path"
 
@@ -28,6 +27,7 @@ var processCmd = &cobra.Command{
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
+		checkErrorFormat()
 		run(args[0], args[1], processingOptions, asJSON, split, outputPath, selection, state, bindings, args[2:])
 	},
 }
@@ -43,31 +43,45 @@ func init() {
 	processCmd.Flags().StringArrayVar(&selection, "select", []string{}, "filter dedicated output fields")
 	processCmd.Flags().BoolVar(&processingOptions.PreserveEscapes, "preserve-escapes", false, "preserve escaping for escaped expressions and merges")
 	processCmd.Flags().BoolVar(&processingOptions.PreserveTemporary, "preserve-temporary", false, "preserve temporary fields")
+	processCmd.Flags().StringVar(&errorFormat, "error-format", ERROR_FORMAT_TEXT, "format of processing errors (text or json)")
+	processCmd.Flags().StringVar(&policyFile, "policy", "", "policy file restricting the access to executables, files, URLs and environment variables")
+	processCmd.Flags().StringVar(&cacheDir, "cache-dir", "", "directory used to cache content read from http(s) URLs")
+	processCmd.Flags().BoolVar(&offline, "offline", false, "serve http(s) content from the cache, only")
+	processCmd.Flags().StringVar(&lockFile, "lock-file", "", "lock file recording the digests of content read from http(s) URLs")
+	processCmd.Flags().DurationVar(&httpTimeout, "http-timeout", flow.DefaultFetchTimeout, "timeout for http(s) requests")
+	processCmd.Flags().BoolVar(&deterministic, "deterministic", false, "derive random values, keys, salts and temp file names from a seed")
+	processCmd.Flags().StringVar(&seed, "seed", "", "seed for the deterministic mode (implies --deterministic)")
+	processCmd.Flags().BoolVar(&dryRun, "dry-run", false, "record file modifications and command executions instead of performing them")
+	processCmd.Flags().BoolVar(&sortKeys, "sort-keys", false, "sort map keys instead of preserving the template order")
 }
 
 func run(documentFilePath, templateFilePath string, opts flow.Options, json, split bool,
 	subpath string, selection []string, stateFilePath, bindingFilePath string, stubFilePaths []string) {
-	var err error
 	var stdin = false
 	var documentFile []byte
 
+	defstate, err := createState()
+	exitOnError(err)
 	if documentFilePath == "-" {
 		documentFile, err = ioutil.ReadAll(os.Stdin)
 		stdin = true
 	} else {
-		documentFile, err = ReadFile(documentFilePath)
+		documentFile, err = ReadFile(defstate, documentFilePath)
+	}
+	if err != nil {
+		fatal(fmt.Sprintf("error reading document [%s]:", path.Clean(documentFilePath)), err, "")
 	}
 
 	documentYAML, err := yaml.Parse(documentFilePath, documentFile)
 	if err != nil {
-		log.Fatalln(fmt.Sprintf("error parsing template [%s]:", path.Clean(documentFilePath)), err)
+		fatal(fmt.Sprintf("error parsing document [%s]:", path.Clean(documentFilePath)), err, "")
 	}
 
 	documentYAML = yaml.NewNode(map[string]yaml.Node{"document": documentYAML}, "<"+documentFilePath+">")
 	stub := yaml.NewNode(map[string]yaml.Node{"document": yaml.NewNode("(( &temporary &inject (merge) ))", "<document>)")}, "<document>")
 	vals, err := createValuesFromArgs(values)
 	if err != nil {
-		log.Fatalf("%s\n", err)
+		fatal("error in value definitions (-D):", err, "")
 	}
-	merge(stdin, templateFilePath, opts, json, split, subpath, selection, stateFilePath, bindingFilePath, vals, []yaml.Node{stub, documentYAML}, stubFilePaths)
+	exitOnError(merge(defstate, stdin, templateFilePath, opts, json, split, subpath, selection, stateFilePath, bindingFilePath, vals, []yaml.Node{stub, documentYAML}, stubFilePaths))
 }
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mandelsoft/spiff/legacy/candiedyaml"
+	"github.com/mandelsoft/spiff/spifftest"
+)
+
+var testUpdate bool
+var testRun string
+
+// testCmd represents the test command
+var testCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Run declarative template tests",
+	Long: `Run the template tests described by test spec files.
+Directories are searched recursively for spec files with the suffix
+.test.yml or .test.yaml.
+
+A test processes a template with optional stubs, values, tags and features
+and checks the output against an expected output given inline (output) or
+by a golden file (expected), or checks that the processing fails with an
+error matching a regular expression (error).
+
+With --update the golden files are rewritten with the actual output.
+The command exits with code 1 if any test fails.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("requires at least one arg")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		if runTests(args) > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(testCmd)
+
+	testCmd.Flags().BoolVar(&testUpdate, "update", false, "rewrite expected files with the actual output")
+	testCmd.Flags().StringVar(&testRun, "run", "", "run only tests whose name matches the regular expression")
+}
+
+// runTests runs the tests of the given spec files and directories and
+// prints the results. It returns the number of failed tests.
+func runTests(args []string) int {
+	var filter *regexp.Regexp
+	if testRun != "" {
+		var err error
+		filter, err = regexp.Compile(testRun)
+		if err != nil {
+			log.Fatalln(fmt.Sprintf("invalid test filter %q: %s", testRun, err))
+		}
+	}
+
+	files, err := findTestSpecs(args)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	counts := map[spifftest.Status]int{}
+	for _, file := range files {
+		specs, err := spifftest.LoadSpecs(file)
+		if err != nil {
+			fmt.Printf("%-7s %s\n  %s\n", spifftest.FAILED, file, err)
+			counts[spifftest.FAILED]++
+			continue
+		}
+		for _, spec := range specs {
+			if filter != nil && !filter.MatchString(spec.Name) {
+				continue
+			}
+			result := spifftest.Run(spec, spifftest.Options{Update: testUpdate})
+			counts[result.Status]++
+			fmt.Printf("%-7s %s (%s)\n", result.Status, spec.Name, file)
+			if result.Status == spifftest.FAILED {
+				fmt.Printf("  %s\n", result.Message)
+				printTestDiffs(result)
+			}
+		}
+	}
+
+	total := counts[spifftest.PASSED] + counts[spifftest.FAILED] + counts[spifftest.UPDATED]
+	summary := fmt.Sprintf("%d tests, %d passed, %d failed", total, counts[spifftest.PASSED], counts[spifftest.FAILED])
+	if testUpdate {
+		summary += fmt.Sprintf(", %d updated", counts[spifftest.UPDATED])
+	}
+	fmt.Println(summary)
+	return counts[spifftest.FAILED]
+}
+
+func printTestDiffs(result *spifftest.Result) {
+	show := func(kind string, node interface{}) {
+		data, err := candiedyaml.Marshal(node)
+		if err != nil {
+			data = []byte(fmt.Sprintf("%v\n", node))
+		}
+		fmt.Printf("    %s:\n      %s\n", kind, strings.Replace(strings.TrimSuffix(string(data), "\n"), "\n", "\n      ", -1))
+	}
+	for _, d := range result.Diffs {
+		fmt.Printf("  Difference in %s\n", strings.Join(d.Path, "."))
+		if d.A != nil {
+			show("expected", d.A)
+		}
+		if d.B != nil {
+			show("found", d.B)
+		}
+	}
+}
+
+// findTestSpecs provides the spec files given by files or found
+// in directories.
+func findTestSpecs(args []string) ([]string, error) {
+	var files []string
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, arg)
+			continue
+		}
+		var found []string
+		err = filepath.Walk(arg, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() && (strings.HasSuffix(path, ".test.yml") || strings.HasSuffix(path, ".test.yaml")) {
+				found = append(found, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(found)
+		files = append(files, found...)
+	}
+	return files, nil
+}
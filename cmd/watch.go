@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mandelsoft/spiff/compare"
+	"github.com/mandelsoft/spiff/legacy/candiedyaml"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+// inputFiles is the set of input files of a rendering.
+type inputFiles map[string]bool
+
+func (f inputFiles) add(files ...string) {
+	for _, n := range files {
+		f[n] = true
+	}
+}
+
+// fileStamp describes the state of a watched file.
+type fileStamp struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+func stampOf(file string) fileStamp {
+	info, err := os.Stat(file)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{true, info.Size(), info.ModTime()}
+}
+
+// watchMerge renders the template given by the arguments again whenever
+// one of its input files changes. This includes the template, the stubs,
+// bindings, tag and policy files, and all files read by the processing.
+// Errors are reported, but do not terminate the watching.
+func watchMerge(args []string, render func(inputFiles) error) {
+	for _, f := range args {
+		if f == "-" {
+			fatal("watch mode not possible for stdin", nil, "")
+		}
+	}
+	var previous []byte
+	var files map[string]fileStamp
+	for {
+		var current []byte
+		var ok bool
+		current, ok, files = renderWatched(args, render, files)
+		if ok {
+			if watchDiff && previous != nil {
+				printWatchDiff(previous, current)
+			} else {
+				os.Stdout.Write(current)
+			}
+			previous = current
+		}
+		fmt.Fprintf(os.Stderr, "watching %d files for changes...\n", len(files))
+		changed := waitForChange(files)
+		fmt.Fprintf(os.Stderr, "%s changed, rendering again\n", strings.Join(changed, ", "))
+	}
+}
+
+// renderWatched executes a single rendering and provides its output, whether
+// it succeeded and the files to watch. A failed rendering might not reach
+// all inputs, therefore the files of the previous rendering are kept.
+func renderWatched(args []string, render func(inputFiles) error, last map[string]fileStamp) ([]byte, bool, map[string]fileStamp) {
+	inputs := inputFiles{}
+	inputs.add(args...)
+	for _, f := range []string{bindings, policyFile} {
+		if f != "" {
+			inputs.add(f)
+		}
+	}
+	for _, t := range tagdefs {
+		if i := strings.Index(t, ":"); i > 0 {
+			inputs.add(t[i+1:])
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	output = buf
+	err := render(inputs)
+	output = os.Stdout
+	if err != nil {
+		reportError(err)
+		for f := range last {
+			inputs.add(f)
+		}
+	}
+	files := map[string]fileStamp{}
+	for f := range inputs {
+		files[f] = stampOf(f)
+	}
+	if err != nil {
+		return nil, false, files
+	}
+	return buf.Bytes(), true, files
+}
+
+// waitForChange polls the given files until at least one of them
+// changes and provides the changed files.
+func waitForChange(files map[string]fileStamp) []string {
+	for {
+		time.Sleep(watchInterval)
+		var changed []string
+		for f, s := range files {
+			if stampOf(f) != s {
+				changed = append(changed, f)
+			}
+		}
+		if len(changed) > 0 {
+			sort.Strings(changed)
+			return changed
+		}
+	}
+}
+
+// printWatchDiff prints the differences of two renderings.
+func printWatchDiff(previous, current []byte) {
+	aYAMLs, aerr := yaml.ParseMulti("previous", previous)
+	bYAMLs, berr := yaml.ParseMulti("current", current)
+	if aerr != nil || berr != nil || len(aYAMLs) != len(bYAMLs) {
+		os.Stdout.Write(current)
+		return
+	}
+	found := false
+	for no := range aYAMLs {
+		diffs := compare.Compare(aYAMLs[no], bYAMLs[no])
+		sort.SliceStable(diffs, func(i, j int) bool {
+			return strings.Join(diffs[i].Path, ".") < strings.Join(diffs[j].Path, ".")
+		})
+		doc := ""
+		if len(aYAMLs) > 1 {
+			doc = fmt.Sprintf("document %d ", no+1)
+		}
+		for _, d := range diffs {
+			found = true
+			fmt.Printf("Difference in %s%s\n", doc, strings.Join(d.Path, "."))
+			if d.A != nil {
+				printWatchValue("previous", d.A)
+			}
+			if d.B != nil {
+				printWatchValue("current", d.B)
+			}
+		}
+	}
+	if !found {
+		fmt.Println("no differences!")
+	}
+}
+
+func printWatchValue(kind string, node yaml.Node) {
+	data, err := candiedyaml.Marshal(node)
+	if err != nil {
+		data = []byte(fmt.Sprintf("%v\n", node.Value()))
+	}
+	fmt.Printf("  %s:\n    %s\n", kind, strings.Replace(strings.TrimSuffix(string(data), "\n"), "\n", "\n    ", -1))
+}
@@ -15,23 +15,33 @@ type Diff struct {
 }
 
 func Compare(a, b yaml.Node) []Diff {
-	return compare(a, b, []string{})
+	return CompareWithOptions(a, b, nil)
 }
 
-func compare(a, b yaml.Node, path []string) []Diff {
+// CompareWithOptions compares two documents according to the
+// given options.
+func CompareWithOptions(a, b yaml.Node, opts *Options) []Diff {
+	return compare(a, b, []string{}, opts)
+}
+
+func compare(a, b yaml.Node, path []string, opts *Options) []Diff {
+	if opts.ignored(path) {
+		return []Diff{}
+	}
+
 	mismatch := Diff{A: a, B: b, Path: path}
 
 	switch av := a.Value().(type) {
 	case map[string]yaml.Node:
 		switch bv := b.Value().(type) {
 		case map[string]yaml.Node:
-			return compareMap(av, bv, path)
+			return compareMap(av, bv, path, opts)
 
 		case []yaml.Node:
 			toMap := listToMap(bv)
 
 			if toMap != nil {
-				return compareMap(av, toMap, path)
+				return compareMap(av, toMap, path, opts)
 			} else {
 				return []Diff{mismatch}
 			}
@@ -43,7 +53,7 @@ func compare(a, b yaml.Node, path []string) []Diff {
 	case []yaml.Node:
 		switch bv := b.Value().(type) {
 		case []yaml.Node:
-			return compareList(av, bv, path)
+			return compareList(av, bv, path, opts)
 		default:
 			return []Diff{mismatch}
 		}
@@ -56,7 +66,7 @@ func compare(a, b yaml.Node, path []string) []Diff {
 			return []Diff{mismatch}
 		}
 
-		if av != b.Value() {
+		if !opts.equal(av, b.Value()) {
 			return []Diff{Diff{A: a, B: b, Path: path}}
 		}
 	}
@@ -91,22 +101,22 @@ func listToMap(list []yaml.Node) map[string]yaml.Node {
 	return toMap
 }
 
-func compareMap(a, b map[string]yaml.Node, path []string) []Diff {
+func compareMap(a, b map[string]yaml.Node, path []string, opts *Options) []Diff {
 	diff := []Diff{}
 
 	for key, aval := range a {
 		bval, present := b[key]
 		if present {
-			diff = append(diff, compare(aval, bval, addPath(path, key))...)
+			diff = append(diff, compare(aval, bval, addPath(path, key), opts)...)
 		} else {
-			diff = append(diff, Diff{A: aval, B: nil, Path: addPath(path, key)})
+			diff = appendDiff(diff, Diff{A: aval, B: nil, Path: addPath(path, key)}, opts)
 		}
 	}
 
 	for key, bval := range b {
 		_, present := a[key]
 		if !present {
-			diff = append(diff, Diff{A: nil, B: bval, Path: addPath(path, key)})
+			diff = appendDiff(diff, Diff{A: nil, B: bval, Path: addPath(path, key)}, opts)
 			continue
 		}
 	}
@@ -114,29 +124,40 @@ func compareMap(a, b map[string]yaml.Node, path []string) []Diff {
 	return diff
 }
 
-func compareList(a, b []yaml.Node, path []string) []Diff {
+func compareList(a, b []yaml.Node, path []string, opts *Options) []Diff {
 	diff := []Diff{}
 
-	if len(path) == 1 && path[0] == "jobs" {
-		return compareJobs(a, b, path)
+	selector, explicit := opts.keySelector(path)
+	if !explicit && len(path) == 1 && path[0] == "jobs" {
+		return compareJobs(a, b, path, opts)
 	}
 
 	for index, aval := range a {
-		key, bval, found := findByNameOrIndex(aval, b, index)
+		key, bval, found := findByKeyOrIndex(selector, aval, b, index)
 
 		if !found {
-			diff = append(diff, Diff{A: aval, B: nil, Path: addPath(path, key)})
+			diff = appendDiff(diff, Diff{A: aval, B: nil, Path: addPath(path, key)}, opts)
 			continue
 		}
 
-		diff = append(diff, compare(aval, bval, addPath(path, key))...)
+		diff = append(diff, compare(aval, bval, addPath(path, key), opts)...)
 	}
 
 	for index, bval := range b {
 		key := fmt.Sprintf("[%d]", index)
 
+		if explicit {
+			// entries with a configured key are added by key
+			if name, ok := selector.key(bval); ok {
+				if _, _, found := findByKey(selector, name, a); !found {
+					diff = appendDiff(diff, Diff{A: nil, B: bval, Path: addPath(path, name)}, opts)
+				}
+				continue
+			}
+		}
+
 		if len(a) <= index {
-			diff = append(diff, Diff{A: nil, B: bval, Path: addPath(path, key)})
+			diff = appendDiff(diff, Diff{A: nil, B: bval, Path: addPath(path, key)}, opts)
 			continue
 		}
 	}
@@ -144,8 +165,15 @@ func compareList(a, b []yaml.Node, path []string) []Diff {
 	return diff
 }
 
-func compareJobs(ajobs, bjobs []yaml.Node, path []string) []Diff {
-	return compareMap(jobMap(ajobs), jobMap(bjobs), path)
+func appendDiff(diffs []Diff, diff Diff, opts *Options) []Diff {
+	if opts.ignored(diff.Path) {
+		return diffs
+	}
+	return append(diffs, diff)
+}
+
+func compareJobs(ajobs, bjobs []yaml.Node, path []string, opts *Options) []Diff {
+	return compareMap(jobMap(ajobs), jobMap(bjobs), path, opts)
 }
 
 func jobMap(jobs []yaml.Node) map[string]yaml.Node {
@@ -166,13 +194,13 @@ func jobMap(jobs []yaml.Node) map[string]yaml.Node {
 	return byName
 }
 
-func findByNameOrIndex(node yaml.Node, others []yaml.Node, index int) (string, yaml.Node, bool) {
-	name, ok := yaml.FindString(node, nil, "name")
+func findByKeyOrIndex(selector KeySelector, node yaml.Node, others []yaml.Node, index int) (string, yaml.Node, bool) {
+	name, ok := selector.key(node)
 	if !ok {
 		return findByIndex(others, index)
 	}
 
-	key, node, found := findByName(name, others)
+	key, node, found := findByKey(selector, name, others)
 	if !found {
 		return findByIndex(others, index)
 	}
@@ -180,9 +208,9 @@ func findByNameOrIndex(node yaml.Node, others []yaml.Node, index int) (string, y
 	return key, node, true
 }
 
-func findByName(name string, nodes []yaml.Node) (string, yaml.Node, bool) {
+func findByKey(selector KeySelector, name string, nodes []yaml.Node) (string, yaml.Node, bool) {
 	for _, node := range nodes {
-		otherName, ok := yaml.FindString(node, nil, "name")
+		otherName, ok := selector.key(node)
 		if !ok {
 			continue
 		}
@@ -3,6 +3,8 @@ package compare
 import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/spiff/yaml"
 )
 
 var _ = Describe("Diffing YAML", func() {
@@ -29,8 +31,8 @@ foo: 2
 			It("reports one difference with the key as the path", func() {
 				Expect(Compare(a, b)).To(Equal([]Diff{
 					{
-						A:    parseYAML("1"),
-						B:    parseYAML("2"),
+						A:    parseYAMLAt("1", 3, 6),
+						B:    parseYAMLAt("2", 3, 6),
 						Path: []string{"foo"},
 					},
 				}))
@@ -53,8 +55,8 @@ foo:
 			It("reports one difference for the nested difference, not the wholistic one", func() {
 				Expect(Compare(a, b)).To(Equal([]Diff{
 					{
-						A:    parseYAML("1"),
-						B:    parseYAML("2"),
+						A:    parseYAMLAt("1", 4, 8),
+						B:    parseYAMLAt("2", 4, 8),
 						Path: []string{"foo", "bar"},
 					},
 				}))
@@ -76,8 +78,8 @@ foo: 2
 			It("reports one difference with the different nodes", func() {
 				Expect(Compare(a, b)).To(Equal([]Diff{
 					{
-						A:    parseYAML("bar: 1"),
-						B:    parseYAML("2"),
+						A:    parseYAMLAt("bar: 1", 4, 3),
+						B:    parseYAMLAt("2", 3, 6),
 						Path: []string{"foo"},
 					},
 				}))
@@ -97,7 +99,7 @@ foo: 1
 			It("reports one difference", func() {
 				Expect(Compare(a, b)).To(Equal([]Diff{
 					{
-						A:    parseYAML("1"),
+						A:    parseYAMLAt("1", 3, 6),
 						B:    nil,
 						Path: []string{"foo"},
 					},
@@ -119,7 +121,7 @@ foo: 1
 				Expect(Compare(a, b)).To(Equal([]Diff{
 					Diff{
 						A:    nil,
-						B:    parseYAML("1"),
+						B:    parseYAMLAt("1", 3, 6),
 						Path: []string{"foo"},
 					},
 				}))
@@ -141,7 +143,7 @@ bar: 2
 
 				Expect(diff).To(ContainElement(
 					Diff{
-						A:    parseYAML("1"),
+						A:    parseYAMLAt("1", 3, 6),
 						B:    nil,
 						Path: []string{"foo"},
 					},
@@ -150,7 +152,7 @@ bar: 2
 				Expect(diff).To(ContainElement(
 					Diff{
 						A:    nil,
-						B:    parseYAML("2"),
+						B:    parseYAMLAt("2", 3, 6),
 						Path: []string{"bar"},
 					},
 				))
@@ -186,8 +188,8 @@ foo:
 				It("reports no differences", func() {
 					Expect(Compare(a, b)).To(Equal([]Diff{
 						{
-							A:    parseYAML("1"),
-							B:    parseYAML("2"),
+							A:    parseYAMLAt("1", 4, 9),
+							B:    parseYAMLAt("2", 4, 9),
 							Path: []string{"foo", "fizz"},
 						},
 					}))
@@ -211,8 +213,8 @@ foo:
 			It("reports one difference with the index in the path", func() {
 				Expect(Compare(a, b)).To(Equal([]Diff{
 					{
-						A:    parseYAML("1"),
-						B:    parseYAML("2"),
+						A:    parseYAMLAt("1", 3, 3),
+						B:    parseYAMLAt("2", 3, 3),
 						Path: []string{"[0]"},
 					},
 				}))
@@ -234,8 +236,11 @@ foo:
 			It("reports one difference with the index in the path", func() {
 				Expect(Compare(a, b)).To(Equal([]Diff{
 					{
-						A:    parseYAML("[hello, world]"),
-						B:    parseYAML("42"),
+						A: yaml.PositionedNode(node([]yaml.Node{
+							parseYAMLAt("hello", 3, 5),
+							parseYAMLAt("world", 4, 5),
+						}), yaml.Position{Line: 3, Column: 3}),
+						B:    parseYAMLAt("42", 3, 3),
 						Path: []string{"[0]"},
 					},
 				}))
@@ -268,16 +273,16 @@ jobs:
 
 				Expect(diff).To(ContainElement(
 					Diff{
-						A:    parseYAML("0"),
-						B:    parseYAML("1"),
+						A:    node(0),
+						B:    node(1),
 						Path: []string{"jobs", "a", "index"},
 					},
 				))
 
 				Expect(diff).To(ContainElement(
 					Diff{
-						A:    parseYAML("1"),
-						B:    parseYAML("0"),
+						A:    node(1),
+						B:    node(0),
 						Path: []string{"jobs", "b", "index"},
 					},
 				))
@@ -306,8 +311,12 @@ jobs:
 
 				Expect(diff).To(Equal([]Diff{
 					Diff{
-						A:    nil,
-						B:    parseYAML("name: b\nvalue: bar\nindex: 1\n"),
+						A: nil,
+						B: node(map[string]yaml.Node{
+							"name":  parseYAMLAt("b", 6, 9),
+							"value": parseYAMLAt("bar", 7, 10),
+							"index": node(1),
+						}),
 						Path: []string{"jobs", "b"},
 					},
 				}))
@@ -359,17 +368,17 @@ foo:
 			It("reports each difference", func() {
 				Expect(Compare(a, b)).To(Equal([]Diff{
 					Diff{
-						A:    parseYAML("2"),
+						A:    parseYAMLAt("2", 6, 5),
 						B:    nil,
 						Path: []string{"foo", "[0]", "baz", "[1]"},
 					},
 					Diff{
-						A:    parseYAML("3"),
+						A:    parseYAMLAt("3", 7, 5),
 						B:    nil,
 						Path: []string{"foo", "[0]", "baz", "[2]"},
 					},
 					Diff{
-						A:    parseYAML("4"),
+						A:    parseYAMLAt("4", 8, 5),
 						B:    nil,
 						Path: []string{"foo", "[0]", "baz", "[3]"},
 					},
@@ -393,7 +402,7 @@ foo:
 				Expect(Compare(a, b)).To(Equal([]Diff{
 					Diff{
 						A:    nil,
-						B:    parseYAML("2"),
+						B:    parseYAMLAt("2", 4, 3),
 						Path: []string{"[1]"},
 					},
 				}))
@@ -1,6 +1,7 @@
 package compare
 
 import (
+	"strings"
 	"testing"
 
 	. "github.com/onsi/ginkgo"
@@ -26,3 +27,13 @@ func parseYAMLFrom(source string, name string) yaml.Node {
 
 	return parsed
 }
+
+// parseYAMLAt parses a yaml snippet as if it would be located
+// at the given position in the source document.
+func parseYAMLAt(source string, line, column int) yaml.Node {
+	return parseYAML(strings.Repeat("\n", line-1) + strings.Repeat(" ", column-1) + source)
+}
+
+func node(value interface{}) yaml.Node {
+	return yaml.NewNode(value, "compare test")
+}
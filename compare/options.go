@@ -0,0 +1,212 @@
+package compare
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+// Options controls the comparison of documents.
+//
+// Paths are given as dot separated sequence of path steps, like they are
+// reported for a Diff. A pattern step may contain glob characters
+// (see path.Match). The step ** matches any number of path steps.
+type Options struct {
+	// Keys selects the fields used to identify the entries of lists.
+	// The first selector matching the path of a list is used. Lists
+	// without matching selector use the field name.
+	Keys []KeySelector
+	// Ignore is a list of path patterns excluded from the comparison.
+	Ignore []string
+	// NumericEquivalence treats integer and float values as equal if
+	// they describe the same number.
+	NumericEquivalence bool
+	// StringEquivalence treats strings as equal to other scalar values
+	// with the same string representation.
+	StringEquivalence bool
+}
+
+// KeySelector describes the key fields for lists matching a path pattern.
+// Fields are given as dot separated paths relative to the list entry.
+// Multiple fields describe a composite key, whose path step is the
+// sequence of field values separated by a slash. Slashes, dots and
+// backslashes in the values of a composite key are escaped by a backslash.
+type KeySelector struct {
+	Path   string
+	Fields []string
+}
+
+var defaultKey = KeySelector{Fields: []string{"name"}}
+
+// ParseKeySelector parses a key selector given as <path>=<field>{,<field>}.
+func ParseKeySelector(s string) (KeySelector, error) {
+	i := strings.LastIndex(s, "=")
+	if i < 0 {
+		return KeySelector{}, fmt.Errorf("invalid key selector %q: expected <path>=<field>{,<field>}", s)
+	}
+	sel := KeySelector{Path: s[:i]}
+	for _, f := range strings.Split(s[i+1:], ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			return KeySelector{}, fmt.Errorf("invalid key selector %q: empty field", s)
+		}
+		sel.Fields = append(sel.Fields, f)
+	}
+	if _, err := path.Match(sel.Path, ""); err != nil {
+		return KeySelector{}, fmt.Errorf("invalid key selector %q: %s", s, err)
+	}
+	return sel, nil
+}
+
+// keySelector provides the key selector for a list path and whether
+// it has explicitly been configured.
+func (o *Options) keySelector(p []string) (KeySelector, bool) {
+	if o != nil {
+		for _, k := range o.Keys {
+			if MatchPath(k.Path, p) {
+				return k, true
+			}
+		}
+	}
+	return defaultKey, false
+}
+
+func (o *Options) ignored(p []string) bool {
+	if o == nil {
+		return false
+	}
+	for _, i := range o.Ignore {
+		if MatchPath(i, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// key determines the key of a list entry. It fails if any key field is
+// missing or not a scalar value.
+func (k KeySelector) key(node yaml.Node) (string, bool) {
+	values := []string{}
+	for _, f := range k.Fields {
+		n, ok := yaml.Find(node, nil, strings.Split(f, ".")...)
+		if !ok || n == nil {
+			return "", false
+		}
+		switch v := n.Value().(type) {
+		case string:
+			values = append(values, v)
+		case int64, float64, bool:
+			values = append(values, fmt.Sprint(v))
+		default:
+			return "", false
+		}
+	}
+	return joinKeyValues(values), true
+}
+
+// joinKeyValues provides the path step for the values of the key fields.
+// Like yaml.JoinKeyValues, the values of composite keys are escaped, so
+// that the step is unique. Because paths are dot separated, dots are
+// escaped, too.
+func joinKeyValues(values []string) string {
+	if len(values) == 1 {
+		return values[0]
+	}
+	escaped := make([]string, len(values))
+	for i, v := range values {
+		escaped[i] = keyEscaper.Replace(v)
+	}
+	return strings.Join(escaped, "/")
+}
+
+var keyEscaper = strings.NewReplacer(`\`, `\\`, "/", `\/`, ".", `\.`)
+
+// equal compares two scalar values according to the configured
+// equivalences.
+func (o *Options) equal(a, b interface{}) bool {
+	if a == b {
+		return true
+	}
+	if o == nil {
+		return false
+	}
+	if o.NumericEquivalence {
+		af, aok := number(a)
+		bf, bok := number(b)
+		if aok && bok {
+			return af == bf
+		}
+	}
+	if o.StringEquivalence {
+		_, astr := a.(string)
+		_, bstr := b.(string)
+		if astr != bstr {
+			as, aok := scalarString(a)
+			bs, bok := scalarString(b)
+			if aok && bok {
+				if as == bs {
+					return true
+				}
+				if o.NumericEquivalence {
+					af, aerr := strconv.ParseFloat(as, 64)
+					bf, berr := strconv.ParseFloat(bs, 64)
+					return aerr == nil && berr == nil && af == bf
+				}
+			}
+		}
+	}
+	return false
+}
+
+func number(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+func scalarString(v interface{}) (string, bool) {
+	switch s := v.(type) {
+	case string:
+		return s, true
+	case int64, float64, bool:
+		return fmt.Sprint(s), true
+	}
+	return "", false
+}
+
+// MatchPath checks whether a path matches a dot separated path pattern.
+// The empty pattern matches the root path, only.
+func MatchPath(pattern string, p []string) bool {
+	if pattern == "" {
+		return len(p) == 0
+	}
+	return matchSteps(strings.Split(pattern, "."), p)
+}
+
+func matchSteps(pattern []string, p []string) bool {
+	if len(pattern) == 0 {
+		return len(p) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(p); i++ {
+			if matchSteps(pattern[1:], p[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(p) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], p[0]); !ok {
+		return false
+	}
+	return matchSteps(pattern[1:], p[1:])
+}
@@ -0,0 +1,180 @@
+package compare
+
+import (
+	"encoding/json"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Comparing with options", func() {
+	paths := func(diffs []Diff) []string {
+		result := []string{}
+		for _, d := range diffs {
+			result = append(result, strings.Join(d.Path, "."))
+		}
+		return result
+	}
+
+	Describe("path patterns", func() {
+		It("matches steps", func() {
+			Expect(MatchPath("a.b", []string{"a", "b"})).To(BeTrue())
+			Expect(MatchPath("a.*", []string{"a", "b"})).To(BeTrue())
+			Expect(MatchPath("a.*", []string{"a", "b", "c"})).To(BeFalse())
+			Expect(MatchPath("a.b*", []string{"a", "bar"})).To(BeTrue())
+		})
+
+		It("matches any number of steps with **", func() {
+			Expect(MatchPath("**.env", []string{"env"})).To(BeTrue())
+			Expect(MatchPath("**.env", []string{"spec", "containers", "nginx", "env"})).To(BeTrue())
+			Expect(MatchPath("a.**", []string{"a"})).To(BeTrue())
+			Expect(MatchPath("**.env", []string{"spec", "env", "x"})).To(BeFalse())
+		})
+
+		It("matches the root with the empty pattern", func() {
+			Expect(MatchPath("", []string{})).To(BeTrue())
+			Expect(MatchPath("", []string{"a"})).To(BeFalse())
+		})
+	})
+
+	Describe("key selectors", func() {
+		It("parses selectors", func() {
+			Expect(ParseKeySelector("items=kind,metadata.name")).To(Equal(KeySelector{Path: "items", Fields: []string{"kind", "metadata.name"}}))
+			_, err := ParseKeySelector("items")
+			Expect(err).To(HaveOccurred())
+			_, err = ParseKeySelector("items=")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("identifies list entries by the selected field", func() {
+			a := parseYAML(`
+ports:
+  - containerPort: 80
+    protocol: TCP
+  - containerPort: 443
+    protocol: TCP
+`)
+			b := parseYAML(`
+ports:
+  - containerPort: 443
+    protocol: UDP
+  - containerPort: 80
+    protocol: TCP
+`)
+			opts := &Options{Keys: []KeySelector{{Path: "**.ports", Fields: []string{"containerPort"}}}}
+			Expect(paths(CompareWithOptions(a, b, opts))).To(Equal([]string{"ports.443.protocol"}))
+		})
+
+		It("identifies list entries by composite keys", func() {
+			a := parseYAML(`
+items:
+  - kind: Service
+    metadata:
+      name: web
+    spec: 1
+  - kind: Deployment
+    metadata:
+      name: web
+    spec: 1
+`)
+			b := parseYAML(`
+items:
+  - kind: Deployment
+    metadata:
+      name: web
+    spec: 2
+  - kind: Service
+    metadata:
+      name: web
+    spec: 1
+  - kind: ConfigMap
+    metadata:
+      name: web
+`)
+			opts := &Options{Keys: []KeySelector{{Path: "items", Fields: []string{"kind", "metadata.name"}}}}
+			diffs := CompareWithOptions(a, b, opts)
+			Expect(paths(diffs)).To(ConsistOf("items.Deployment/web.spec", "items.ConfigMap/web"))
+
+			ops, err := JSONPatchWithOptions(a, b, opts)
+			Expect(err).NotTo(HaveOccurred())
+			data, err := json.Marshal(ops)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(MatchJSON(`[
+				{"op":"move","from":"/items/1","path":"/items/0"},
+				{"op":"replace","path":"/items/0/spec","value":2},
+				{"op":"add","path":"/items/2","value":{"kind":"ConfigMap","metadata":{"name":"web"}}}
+			]`))
+		})
+
+		It("escapes the values of composite keys", func() {
+			key := func(selector KeySelector, src string) string {
+				k, ok := selector.key(parseYAML(src))
+				Expect(ok).To(BeTrue())
+				return k
+			}
+			composite := KeySelector{Fields: []string{"kind", "name"}}
+			Expect(key(composite, "{ kind: a/b, name: c }")).To(Equal(`a\/b/c`))
+			Expect(key(composite, "{ kind: a, name: b/c }")).To(Equal(`a/b\/c`))
+			Expect(key(composite, `{ kind: 'a\', name: y.z }`)).To(Equal(`a\\/y\.z`))
+			Expect(key(KeySelector{Fields: []string{"name"}}, "{ name: a/b.c }")).To(Equal("a/b.c"))
+
+			a := parseYAML(`
+items:
+  - kind: x
+    name: y.z
+`)
+			b := parseYAML(`
+items:
+  - kind: x
+    name: y.z
+    spec: 1
+`)
+			opts := &Options{Keys: []KeySelector{{Path: "items", Fields: []string{"kind", "name"}}}}
+			Expect(paths(CompareWithOptions(a, b, opts))).To(Equal([]string{`items.x/y\.z.spec`}))
+		})
+	})
+
+	Describe("ignore paths", func() {
+		It("omits ignored subtrees", func() {
+			a := parseYAML(`
+metadata:
+  name: web
+  annotations:
+    revision: 1
+status: 1
+`)
+			b := parseYAML(`
+metadata:
+  name: app
+  annotations:
+    revision: 2
+`)
+			opts := &Options{Ignore: []string{"metadata.annotations", "status"}}
+			Expect(paths(CompareWithOptions(a, b, opts))).To(Equal([]string{"metadata.name"}))
+		})
+	})
+
+	Describe("equivalences", func() {
+		a := parseYAML(`
+int: 1
+str: "1"
+`)
+		b := parseYAML(`
+int: 1.0
+str: 1
+`)
+
+		It("reports differences by default", func() {
+			Expect(paths(Compare(a, b))).To(ConsistOf("int", "str"))
+		})
+
+		It("treats numbers as equivalent", func() {
+			Expect(paths(CompareWithOptions(a, b, &Options{NumericEquivalence: true}))).To(Equal([]string{"str"}))
+		})
+
+		It("treats strings as equivalent", func() {
+			Expect(paths(CompareWithOptions(a, b, &Options{StringEquivalence: true}))).To(Equal([]string{"int"}))
+		})
+	})
+})
@@ -0,0 +1,232 @@
+package compare
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+// PatchOperation is an operation of a JSON Patch (RFC 6902).
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	From  string          `json:"from,omitempty"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// JSONPatch creates a JSON Patch transforming document a into document b.
+func JSONPatch(a, b yaml.Node) ([]PatchOperation, error) {
+	return JSONPatchWithOptions(a, b, nil)
+}
+
+// JSONPatchWithOptions creates a JSON Patch transforming document a into
+// document b according to the given options. Both documents are walked
+// in parallel. Lists whose entries can all be identified by the key
+// selectors of the options are patched by key: entries missing in b are
+// removed, entries missing in a are added and the other entries are moved
+// to their position in b. Other lists are patched by index. Every
+// operation uses the indices resulting from the preceding operations.
+// Ignored paths are not patched.
+func JSONPatchWithOptions(a, b yaml.Node, opts *Options) ([]PatchOperation, error) {
+	p := &patcher{opts: opts, ops: []PatchOperation{}}
+	if err := p.patch(a, b, []string{}, ""); err != nil {
+		return nil, err
+	}
+	return p.ops, nil
+}
+
+type patcher struct {
+	opts *Options
+	ops  []PatchOperation
+}
+
+func (p *patcher) patch(a, b yaml.Node, path []string, ptr string) error {
+	if p.opts.ignored(path) {
+		return nil
+	}
+	switch av := value(a).(type) {
+	case map[string]yaml.Node:
+		if bv, ok := value(b).(map[string]yaml.Node); ok {
+			return p.patchMap(av, bv, path, ptr)
+		}
+	case []yaml.Node:
+		if bv, ok := value(b).([]yaml.Node); ok {
+			return p.patchList(av, bv, path, ptr)
+		}
+	default:
+		switch bv := value(b).(type) {
+		case map[string]yaml.Node, []yaml.Node:
+		default:
+			if p.opts.equal(av, bv) {
+				return nil
+			}
+		}
+	}
+	return p.add("replace", ptr, b)
+}
+
+func (p *patcher) patchMap(a, b map[string]yaml.Node, path []string, ptr string) error {
+	for _, key := range sortedKeys(a) {
+		if bval, ok := b[key]; ok {
+			if err := p.patch(a[key], bval, addPath(path, key), ptr+pointerStep(key)); err != nil {
+				return err
+			}
+		} else if !p.opts.ignored(addPath(path, key)) {
+			p.remove(ptr + pointerStep(key))
+		}
+	}
+	for _, key := range sortedKeys(b) {
+		if _, ok := a[key]; !ok && !p.opts.ignored(addPath(path, key)) {
+			if err := p.add("add", ptr+pointerStep(key), b[key]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *patcher) patchList(a, b []yaml.Node, path []string, ptr string) error {
+	selector, _ := p.opts.keySelector(path)
+	akeys, aok := listKeys(selector, a)
+	bkeys, bok := listKeys(selector, b)
+	if aok && bok {
+		return p.patchKeyedList(a, b, akeys, bkeys, path, ptr)
+	}
+
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		if err := p.patch(a[i], b[i], addPath(path, fmt.Sprintf("[%d]", i)), ptr+pointerIndex(i)); err != nil {
+			return err
+		}
+	}
+	for i := len(a) - 1; i >= n; i-- {
+		if !p.opts.ignored(addPath(path, fmt.Sprintf("[%d]", i))) {
+			p.remove(ptr + pointerIndex(i))
+		}
+	}
+	for i := n; i < len(b); i++ {
+		if !p.opts.ignored(addPath(path, fmt.Sprintf("[%d]", i))) {
+			if err := p.add("add", ptr+pointerIndex(i), b[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// patchKeyedList patches a list by the keys of its entries. The actual
+// order of the keys is tracked to determine the indices of the entries
+// for the subsequent operations.
+func (p *patcher) patchKeyedList(a, b []yaml.Node, akeys, bkeys []string, path []string, ptr string) error {
+	entries := map[string]yaml.Node{}
+	for i, k := range akeys {
+		entries[k] = a[i]
+	}
+	wanted := map[string]bool{}
+	for _, k := range bkeys {
+		wanted[k] = true
+	}
+
+	current := append([]string{}, akeys...)
+	for i := len(current) - 1; i >= 0; i-- {
+		if !wanted[current[i]] && !p.opts.ignored(addPath(path, current[i])) {
+			p.remove(ptr + pointerIndex(i))
+			current = append(current[:i], current[i+1:]...)
+		}
+	}
+
+	pos := 0
+	for j, k := range bkeys {
+		i := indexOf(current, k, pos)
+		if i < 0 {
+			if p.opts.ignored(addPath(path, k)) {
+				continue
+			}
+			if err := p.add("add", ptr+pointerIndex(pos), b[j]); err != nil {
+				return err
+			}
+			current = append(current[:pos], append([]string{k}, current[pos:]...)...)
+			pos++
+			continue
+		}
+		if i != pos {
+			p.ops = append(p.ops, PatchOperation{Op: "move", From: ptr + pointerIndex(i), Path: ptr + pointerIndex(pos)})
+			current = append(current[:i], current[i+1:]...)
+			current = append(current[:pos], append([]string{k}, current[pos:]...)...)
+		}
+		if err := p.patch(entries[k], b[j], addPath(path, k), ptr+pointerIndex(pos)); err != nil {
+			return err
+		}
+		pos++
+	}
+	return nil
+}
+
+func (p *patcher) add(op string, ptr string, node yaml.Node) error {
+	data, err := yaml.ToJSON(node)
+	if err != nil {
+		return err
+	}
+	p.ops = append(p.ops, PatchOperation{Op: op, Path: ptr, Value: data})
+	return nil
+}
+
+func (p *patcher) remove(ptr string) {
+	p.ops = append(p.ops, PatchOperation{Op: "remove", Path: ptr})
+}
+
+// listKeys determines the keys of all list entries. It fails if any
+// entry has no key or keys are not unique.
+func listKeys(selector KeySelector, list []yaml.Node) ([]string, bool) {
+	keys := make([]string, len(list))
+	found := map[string]bool{}
+	for i, e := range list {
+		k, ok := selector.key(e)
+		if !ok || found[k] {
+			return nil, false
+		}
+		found[k] = true
+		keys[i] = k
+	}
+	return keys, true
+}
+
+func indexOf(list []string, s string, start int) int {
+	for i := start; i < len(list); i++ {
+		if list[i] == s {
+			return i
+		}
+	}
+	return -1
+}
+
+func sortedKeys(m map[string]yaml.Node) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func value(node yaml.Node) interface{} {
+	if node == nil {
+		return nil
+	}
+	return node.Value()
+}
+
+// pointerStep formats a JSON pointer step according to RFC 6901.
+func pointerStep(s string) string {
+	return "/" + strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+func pointerIndex(i int) string {
+	return fmt.Sprintf("/%d", i)
+}
@@ -0,0 +1,289 @@
+package compare
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+// applyPatch applies a JSON Patch to the json representation of a document.
+func applyPatch(doc interface{}, ops []PatchOperation) interface{} {
+	var steps func(ptr string) []string
+	steps = func(ptr string) []string {
+		if ptr == "" {
+			return nil
+		}
+		list := strings.Split(ptr[1:], "/")
+		for i, s := range list {
+			list[i] = strings.ReplaceAll(strings.ReplaceAll(s, "~1", "/"), "~0", "~")
+		}
+		return list
+	}
+	var update func(node interface{}, path []string, f func(parent interface{}, step string) interface{}) interface{}
+	update = func(node interface{}, path []string, f func(parent interface{}, step string) interface{}) interface{} {
+		if len(path) == 1 {
+			return f(node, path[0])
+		}
+		switch v := node.(type) {
+		case map[string]interface{}:
+			v[path[0]] = update(v[path[0]], path[1:], f)
+			return v
+		case []interface{}:
+			i, err := strconv.Atoi(path[0])
+			Expect(err).NotTo(HaveOccurred())
+			v[i] = update(v[i], path[1:], f)
+			return v
+		}
+		Fail("invalid patch path " + strings.Join(path, "/"))
+		return nil
+	}
+	get := func(node interface{}, path []string) interface{} {
+		for _, s := range path {
+			switch v := node.(type) {
+			case map[string]interface{}:
+				node = v[s]
+			case []interface{}:
+				i, err := strconv.Atoi(s)
+				Expect(err).NotTo(HaveOccurred())
+				node = v[i]
+			}
+		}
+		return node
+	}
+	remove := func(path []string) {
+		doc = update(doc, path, func(parent interface{}, step string) interface{} {
+			switch v := parent.(type) {
+			case map[string]interface{}:
+				Expect(v).To(HaveKey(step))
+				delete(v, step)
+				return v
+			case []interface{}:
+				i, err := strconv.Atoi(step)
+				Expect(err).NotTo(HaveOccurred())
+				return append(v[:i], v[i+1:]...)
+			}
+			return parent
+		})
+	}
+	add := func(path []string, value interface{}, replace bool) {
+		if len(path) == 0 {
+			doc = value
+			return
+		}
+		doc = update(doc, path, func(parent interface{}, step string) interface{} {
+			switch v := parent.(type) {
+			case map[string]interface{}:
+				v[step] = value
+				return v
+			case []interface{}:
+				if step == "-" {
+					return append(v, value)
+				}
+				i, err := strconv.Atoi(step)
+				Expect(err).NotTo(HaveOccurred())
+				if replace {
+					v[i] = value
+					return v
+				}
+				Expect(i).To(BeNumerically("<=", len(v)))
+				return append(v[:i], append([]interface{}{value}, v[i:]...)...)
+			}
+			return parent
+		})
+	}
+	for _, o := range ops {
+		var value interface{}
+		if o.Value != nil {
+			Expect(json.Unmarshal(o.Value, &value)).To(Succeed())
+		}
+		switch o.Op {
+		case "add":
+			add(steps(o.Path), value, false)
+		case "replace":
+			add(steps(o.Path), value, true)
+		case "remove":
+			remove(steps(o.Path))
+		case "move":
+			value = get(doc, steps(o.From))
+			remove(steps(o.From))
+			add(steps(o.Path), value, false)
+		default:
+			Fail("unknown patch operation " + o.Op)
+		}
+	}
+	return doc
+}
+
+func jsonValue(node yaml.Node) interface{} {
+	data, err := yaml.ToJSON(node)
+	Expect(err).NotTo(HaveOccurred())
+	var value interface{}
+	Expect(json.Unmarshal(data, &value)).To(Succeed())
+	return value
+}
+
+var _ = Describe("JSON Patch", func() {
+	patch := func(a, b string) string {
+		ops, err := JSONPatch(parseYAML(a), parseYAML(b))
+		Expect(err).NotTo(HaveOccurred())
+		data, err := json.Marshal(ops)
+		Expect(err).NotTo(HaveOccurred())
+		return string(data)
+	}
+
+	It("is empty for equal documents", func() {
+		Expect(patch("foo: 1", "foo: 1")).To(Equal(`[]`))
+	})
+
+	It("replaces changed values", func() {
+		Expect(patch("foo: 1", "foo: bar")).To(MatchJSON(`[{"op":"replace","path":"/foo","value":"bar"}]`))
+	})
+
+	It("adds and removes map fields", func() {
+		Expect(patch("foo: 1", "bar: [1]")).To(MatchJSON(`[
+			{"op":"remove","path":"/foo"},
+			{"op":"add","path":"/bar","value":[1]}
+		]`))
+	})
+
+	It("escapes pointer steps", func() {
+		Expect(patch("a/b~c: 1", "a/b~c: 2")).To(MatchJSON(`[{"op":"replace","path":"/a~1b~0c","value":2}]`))
+	})
+
+	It("maps named list entries to indices", func() {
+		Expect(patch(`
+list:
+  - name: a
+    value: 1
+  - name: b
+    value: 2
+`, `
+list:
+  - name: a
+    value: 1
+  - name: b
+    value: 3
+`)).To(MatchJSON(`[{"op":"replace","path":"/list/1/value","value":3}]`))
+	})
+
+	It("orders removals of list entries by descending index", func() {
+		Expect(patch("list: [1, 2, 3]", "list: [1]")).To(MatchJSON(`[
+			{"op":"remove","path":"/list/2"},
+			{"op":"remove","path":"/list/1"}
+		]`))
+	})
+
+	It("inserts named list entries at their position", func() {
+		Expect(patch(`
+list:
+  - name: a
+  - name: c
+`, `
+list:
+  - name: a
+  - name: b
+  - name: c
+    v: 4
+`)).To(MatchJSON(`[
+			{"op":"add","path":"/list/1","value":{"name":"b"}},
+			{"op":"add","path":"/list/2/v","value":4}
+		]`))
+	})
+
+	It("moves reordered named list entries", func() {
+		Expect(patch(`
+list:
+  - name: a
+  - name: b
+`, `
+list:
+  - name: b
+  - name: a
+`)).To(MatchJSON(`[{"op":"move","from":"/list/1","path":"/list/0"}]`))
+	})
+
+	DescribeTable("transforms the left into the right document", func(a, b string, opts *Options) {
+		an, bn := parseYAML(a), parseYAML(b)
+		ops, err := JSONPatchWithOptions(an, bn, opts)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(applyPatch(jsonValue(an), ops)).To(Equal(jsonValue(bn)))
+	},
+		Entry("inserted named entries", `
+list:
+  - name: a
+  - name: c
+`, `
+list:
+  - name: a
+  - name: b
+  - name: c
+    v: 4
+`, nil),
+		Entry("removed and reordered named entries", `
+list:
+  - name: a
+    v: 1
+  - name: b
+  - name: c
+  - name: d
+`, `
+list:
+  - name: d
+  - name: e
+  - name: a
+    v: 2
+  - name: c
+`, nil),
+		Entry("lists of scalars", `
+list: [1, 2, 3]
+other: [1]
+`, `
+list: [3, 2]
+other: [1, 2, 3]
+`, nil),
+		Entry("nested structures", `
+a:
+  list:
+    - name: x
+      items: [1, 2]
+  b: 1
+`, `
+a:
+  list:
+    - name: y
+    - name: x
+      items: [2]
+  c: { d: 1 }
+`, nil),
+		Entry("changed types", `
+a: [1]
+b: { c: 1 }
+c: 1
+`, `
+a: { c: 1 }
+b: 1
+c: [1]
+`, nil),
+		Entry("composite keys", `
+items:
+  - kind: Service
+    metadata: { name: web }
+  - kind: Deployment
+    metadata: { name: web }
+    spec: 1
+`, `
+items:
+  - kind: Deployment
+    metadata: { name: web }
+    spec: 2
+  - kind: ConfigMap
+    metadata: { name: web }
+`, &Options{Keys: []KeySelector{{Path: "items", Fields: []string{"kind", "metadata.name"}}}}),
+	)
+})
@@ -0,0 +1,165 @@
+package compare
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Unified provides a unified diff of two texts with the given number
+// of context lines. It is empty if the texts are identical.
+func Unified(aName, bName string, a, b string, context int) string {
+	al := splitLines(a)
+	bl := splitLines(b)
+	edits := lineEdits(al, bl)
+
+	s := strings.Builder{}
+	for i := 0; i < len(edits); {
+		if edits[i].op == ' ' {
+			i++
+			continue
+		}
+		// collect a hunk of changes separated by at most 2*context unchanged lines
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+		end := i
+		for end < len(edits) {
+			if edits[end].op != ' ' {
+				end++
+				continue
+			}
+			n := end
+			for n < len(edits) && edits[n].op == ' ' {
+				n++
+			}
+			if n == len(edits) || n-end > 2*context {
+				end += context
+				if end > len(edits) {
+					end = len(edits)
+				}
+				break
+			}
+			end = n
+		}
+
+		if s.Len() == 0 {
+			fmt.Fprintf(&s, "--- %s\n+++ %s\n", aName, bName)
+		}
+		astart, bstart := edits[start].a, edits[start].b
+		acount, bcount := 0, 0
+		for _, e := range edits[start:end] {
+			if e.op != '+' {
+				acount++
+			}
+			if e.op != '-' {
+				bcount++
+			}
+		}
+		fmt.Fprintf(&s, "@@ -%s +%s @@\n", hunkRange(astart, acount), hunkRange(bstart, bcount))
+		for _, e := range edits[start:end] {
+			s.WriteByte(e.op)
+			s.WriteString(e.line)
+			s.WriteString("\n")
+		}
+		i = end
+	}
+	return s.String()
+}
+
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// edit is a line of an edit script. The op is ' ' for unchanged lines,
+// '-' for removed and '+' for added lines. The fields a and b keep the
+// index of the line in the old and the new text.
+type edit struct {
+	op   byte
+	line string
+	a, b int
+}
+
+// lineEdits determines a shortest edit script with the algorithm
+// of Myers.
+func lineEdits(a, b []string) []edit {
+	n, m := len(a), len(b)
+	max := n + m
+	v := make([]int, 2*max+2)
+	var trace [][]int
+
+	for d := 0; d <= max; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || k != d && v[max+k-1] < v[max+k+1] {
+				x = v[max+k+1]
+			} else {
+				x = v[max+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[max+k] = x
+			if x >= n && y >= m {
+				return backtrack(a, b, trace, v, d, max)
+			}
+		}
+	}
+	return nil
+}
+
+func backtrack(a, b []string, trace [][]int, v []int, d, max int) []edit {
+	var edits []edit
+	x, y := len(a), len(b)
+	for ; d >= 0; d-- {
+		k := x - y
+		var pk int
+		if d == 0 {
+			pk = k
+		} else if k == -d || k != d && trace[d][max+k-1] < trace[d][max+k+1] {
+			pk = k + 1
+		} else {
+			pk = k - 1
+		}
+		px := 0
+		if d > 0 {
+			px = trace[d][max+pk]
+		}
+		py := px - pk
+		for x > px && y > py {
+			x--
+			y--
+			edits = append(edits, edit{' ', a[x], x, y})
+		}
+		if d > 0 {
+			if x == px {
+				y--
+				edits = append(edits, edit{'+', b[y], x, y})
+			} else {
+				x--
+				edits = append(edits, edit{'-', a[x], x, y})
+			}
+		}
+		v = trace[d]
+	}
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
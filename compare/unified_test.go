@@ -0,0 +1,45 @@
+package compare
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Unified diff", func() {
+	It("is empty for equal texts", func() {
+		Expect(Unified("a", "b", "a\nb\n", "a\nb\n", 3)).To(Equal(""))
+	})
+
+	It("shows changed lines with context", func() {
+		Expect(Unified("a", "b", "1\n2\n3\n4\n5\n", "1\n2\nx\n4\n5\n", 1)).To(Equal(`--- a
++++ b
+@@ -2,3 +2,3 @@
+ 2
+-3
++x
+ 4
+`))
+	})
+
+	It("separates distant changes into hunks", func() {
+		Expect(Unified("a", "b", "1\n2\n3\n4\n5\n6\n7\n", "x\n2\n3\n4\n5\n6\ny\n", 1)).To(Equal(`--- a
++++ b
+@@ -1,2 +1,2 @@
+-1
++x
+ 2
+@@ -6,2 +6,2 @@
+ 6
+-7
++y
+`))
+	})
+
+	It("handles additions to empty texts", func() {
+		Expect(Unified("a", "b", "", "1\n", 3)).To(Equal(`--- a
++++ b
+@@ -0,0 +1 @@
++1
+`))
+	})
+})
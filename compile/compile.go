@@ -8,6 +8,7 @@ import (
 
 	"github.com/mandelsoft/spiff/dynaml"
 	"github.com/mandelsoft/spiff/flow"
+	"github.com/mandelsoft/spiff/legacy/candiedyaml"
 	"github.com/mandelsoft/spiff/yaml"
 )
 
@@ -15,11 +16,16 @@ var mapType = reflect.TypeOf(map[string]interface{}{})
 var arrayType = reflect.TypeOf([]interface{}{})
 
 type CompileError struct {
-	Path    []string
-	Message error
+	Path     []string
+	Message  error
+	Source   string
+	Position yaml.Position
 }
 
 func (c CompileError) Error() string {
+	if c.Position.IsValid() {
+		return fmt.Sprintf("%s:%s: %s: %s", c.Source, c.Position, strings.Join(c.Path, "."), c.Message)
+	}
 	return fmt.Sprintf("%s: %s", strings.Join(c.Path, "."), c.Message)
 }
 
@@ -54,7 +60,18 @@ func (c CompileErrors) Swap(i, j int) {
 
 func (c *CompileErrors) Add(path []string, err error) {
 	if err != nil {
-		*c = append(*c, CompileError{path, err})
+		*c = append(*c, CompileError{Path: path, Message: err})
+	}
+}
+
+// setPosition assigns a source location to all errors
+// not yet providing a more specific one.
+func (c CompileErrors) setPosition(source string, pos yaml.Position) {
+	for i := range c {
+		if !c[i].Position.IsValid() {
+			c[i].Source = source
+			c[i].Position = pos
+		}
 	}
 }
 
@@ -71,6 +88,14 @@ func compile(env dynaml.Binding, root interface{}) (yaml.Node, CompileErrors) {
 	var errors CompileErrors
 
 	switch rootVal := root.(type) {
+	case candiedyaml.Positioned:
+		pos := yaml.Position{Line: rootVal.Line, Column: rootVal.Column}
+		node, errs := compile(env, rootVal.Value)
+		errs.setPosition(env.SourceName(), pos)
+		if node != nil {
+			node = yaml.PositionedNode(node, pos)
+		}
+		return node, errs
 	case time.Time:
 		return yaml.NewNode(rootVal.Format("2019-01-08T10:06:26Z"), env.SourceName()), errors
 	case string:
@@ -37,6 +37,17 @@ val: (( blub( ))
 			Expect(err.Error()).To(Equal("val: parse error near symbol 7 - symbol 8: ' '"))
 		})
 
+		It("reports the source position of a compilation error", func() {
+			source := parsePositionedYAML(`
+---
+val:
+  alice: (( blub( ))
+`)
+			_, err := Compile("test", source)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("test:4:10: val.alice: parse error near symbol 7 - symbol 8: ' '"))
+		})
+
 		It("detects nested compilation error", func() {
 			source := parseYAML(`
 ---
@@ -16,8 +16,19 @@ func Test(t *testing.T) {
 }
 
 func parseYAML(source string) interface{} {
+	return decodeYAML(source, false)
+}
+
+func parsePositionedYAML(source string) interface{} {
+	return decodeYAML(source, true)
+}
+
+func decodeYAML(source string, positions bool) interface{} {
 	r := bytes.NewBuffer([]byte(source))
 	d := candiedyaml.NewDecoder(r)
+	if positions {
+		d.UsePositions()
+	}
 	for d.HasNext() {
 		var parsed interface{}
 		err := d.Decode(&parsed)
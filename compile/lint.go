@@ -0,0 +1,360 @@
+package compile
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mandelsoft/spiff/dynaml"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// static checks of templates and stubs
+//
+// Lint checks documents without evaluating them. It reports
+//  - dynaml parse errors
+//  - calls of unknown functions
+//  - wrong number of arguments for builtin functions
+//  - unknown controls and control options
+//  - references whose first path element is defined nowhere
+//  - stub fields not used by any template document
+
+// LintOptions configures the static checks.
+type LintOptions struct {
+	// Registry is used to look up functions and controls.
+	// If not set, the default registry is used.
+	Registry dynaml.Registry
+	// Interpolation enables checking embedded expressions in strings.
+	Interpolation bool
+	// Names are additionally known names, for example from bindings.
+	Names []string
+}
+
+// special names always resolvable by references
+var lintNames = []string{yaml.SELF, yaml.DOCNODE, yaml.ROOT, "__ctx", "__map"}
+
+type linter struct {
+	opts   LintOptions
+	names  map[string]struct{}
+	errors CompileErrors
+}
+
+// Lint statically checks a set of template documents and stubs and reports
+// all findings ordered by their source locations.
+func Lint(templates []yaml.Node, stubs []yaml.Node, opts LintOptions) CompileErrors {
+	if opts.Registry == nil {
+		opts.Registry = dynaml.DefaultRegistry()
+	}
+	l := &linter{opts: opts, names: map[string]struct{}{}}
+	for _, n := range lintNames {
+		l.names[n] = struct{}{}
+	}
+	for _, n := range opts.Names {
+		l.names[n] = struct{}{}
+	}
+
+	docs := append(append([]yaml.Node{}, templates...), stubs...)
+	for _, d := range docs {
+		l.collectNames(d)
+	}
+	for _, d := range docs {
+		l.check(nil, d)
+	}
+	for _, s := range stubs {
+		l.checkStub(nil, s, templates)
+	}
+
+	sort.SliceStable(l.errors, func(i, j int) bool {
+		a, b := l.errors[i], l.errors[j]
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		if a.Position.Line != b.Position.Line {
+			return a.Position.Line < b.Position.Line
+		}
+		return a.Position.Column < b.Position.Column
+	})
+	return l.errors
+}
+
+func (l *linter) add(path []string, node yaml.Node, msg string, args ...interface{}) {
+	l.errors = append(l.errors, CompileError{
+		Path:     path,
+		Message:  fmt.Errorf(msg, args...),
+		Source:   node.SourceName(),
+		Position: node.SourcePosition(),
+	})
+}
+
+func (l *linter) expression(path []string, node yaml.Node) (dynaml.Expression, error) {
+	sub := yaml.EmbeddedDynaml(node, l.opts.Interpolation)
+	if sub == nil {
+		return nil, nil
+	}
+	return dynaml.Parse(*sub, path, path)
+}
+
+// collectNames collects all map keys of a document including the keys
+// of map literals and the names bound by expressions. Templates may be
+// instantiated in the scope of such bindings, for example lambda
+// parameters.
+func (l *linter) collectNames(node yaml.Node) {
+	if node == nil {
+		return
+	}
+	switch v := node.Value().(type) {
+	case map[string]yaml.Node:
+		for k, e := range v {
+			l.names[k] = struct{}{}
+			l.collectNames(e)
+		}
+	case []yaml.Node:
+		for _, e := range v {
+			l.collectNames(e)
+		}
+	default:
+		if expr, err := l.expression(nil, node); expr != nil && err == nil {
+			dynaml.VisitExpressions(expr, func(e dynaml.Expression) bool {
+				switch v := e.(type) {
+				case dynaml.CreateMapExpr:
+					l.assignedNames(v)
+				case dynaml.ScopeExpr:
+					l.assignedNames(v.CreateMapExpr)
+				case dynaml.LambdaExpr:
+					for _, p := range v.Parameters {
+						l.names[p.Name] = struct{}{}
+					}
+				}
+				return true
+			})
+		}
+	}
+}
+
+func (l *linter) assignedNames(m dynaml.CreateMapExpr) {
+	for _, a := range m.Assignments {
+		if s, ok := a.Key.(dynaml.StringExpr); ok {
+			l.names[s.Value] = struct{}{}
+		}
+	}
+}
+
+func (l *linter) check(path []string, node yaml.Node) {
+	if node == nil {
+		return
+	}
+	switch v := node.Value().(type) {
+	case map[string]yaml.Node:
+		for _, k := range yaml.GetSortedKeys(v) {
+			sub := append(path[:len(path):len(path)], k)
+			l.checkControl(sub, k, v[k])
+			l.check(sub, v[k])
+		}
+	case []yaml.Node:
+		for i, e := range v {
+			l.check(append(path[:len(path):len(path)], fmt.Sprintf("[%d]", i)), e)
+		}
+	default:
+		expr, err := l.expression(path, node)
+		if err != nil {
+			l.add(path, node, "%s", err)
+			return
+		}
+		if expr != nil {
+			l.checkExpression(path, node, expr, map[string]struct{}{})
+		}
+	}
+}
+
+func (l *linter) checkControl(path []string, key string, node yaml.Node) {
+	if !strings.HasPrefix(key, "<<") {
+		return
+	}
+	n := key[2:]
+	if n == "" || n == "<" || n[0] == '!' || strings.HasPrefix(n, "<!") {
+		return
+	}
+	if _, ok := l.opts.Registry.LookupControl(n); !ok {
+		l.add(path, node, "unknown control or control option %q", key)
+	}
+}
+
+// checkExpression checks function calls and references of an expression.
+// Names bound by lambda parameters and local scopes are passed along.
+func (l *linter) checkExpression(path []string, node yaml.Node, expr dynaml.Expression, bound map[string]struct{}) {
+	dynaml.VisitExpressions(expr, func(e dynaml.Expression) bool {
+		switch v := e.(type) {
+		case dynaml.ReferenceExpr:
+			l.checkReference(path, node, v, bound)
+		case dynaml.QualifiedExpr:
+			// the reference is relative to the value of the expression
+			l.checkExpression(path, node, v.Expression, bound)
+			return false
+		case dynaml.OrExpr:
+			// the left operand may intentionally be undefined
+			l.checkFunctions(path, node, v.A)
+			l.checkExpression(path, node, v.B, bound)
+			return false
+		case dynaml.MarkerExpr:
+			// templates are evaluated in the scope of their instantiation
+			return !v.Has(dynaml.TEMPLATE)
+		case dynaml.LambdaExpr:
+			nested := extend(bound)
+			for _, p := range v.Parameters {
+				if p.Default != nil {
+					l.checkExpression(path, node, p.Default, bound)
+				}
+				nested[p.Name] = struct{}{}
+			}
+			l.checkExpression(path, node, v.E, nested)
+			return false
+		case dynaml.ScopeExpr:
+			nested := extend(bound)
+			for _, a := range v.Assignments {
+				l.checkExpression(path, node, a.Key, bound)
+				l.checkExpression(path, node, a.Value, bound)
+				if s, ok := a.Key.(dynaml.StringExpr); ok {
+					nested[s.Value] = struct{}{}
+				}
+			}
+			l.checkExpression(path, node, v.E, nested)
+			return false
+		case dynaml.CallExpr:
+			name := functionName(v)
+			if name == "" {
+				return true
+			}
+			l.checkCall(path, node, name, v)
+			switch name {
+			case "defined", "valid", "optional", "stub", "catch":
+				// arguments may intentionally be undefined
+				for _, a := range v.Arguments {
+					l.checkFunctions(path, node, a)
+				}
+			default:
+				for _, a := range v.Arguments {
+					l.checkExpression(path, node, a, bound)
+				}
+			}
+			return false
+		}
+		return true
+	})
+}
+
+// checkFunctions checks the function calls of an expression, only.
+func (l *linter) checkFunctions(path []string, node yaml.Node, expr dynaml.Expression) {
+	dynaml.VisitExpressions(expr, func(e dynaml.Expression) bool {
+		if c, ok := e.(dynaml.CallExpr); ok {
+			if name := functionName(c); name != "" {
+				l.checkCall(path, node, name, c)
+			}
+		}
+		return true
+	})
+}
+
+func (l *linter) checkCall(path []string, node yaml.Node, name string, e dynaml.CallExpr) {
+	min, max, ok := dynaml.BuiltinArity(name)
+	if !ok {
+		if l.opts.Registry.LookupFunction(name) == nil {
+			l.add(path, node, "unknown function '%s'", name)
+		}
+		return
+	}
+	for _, a := range e.Arguments {
+		switch a.(type) {
+		case dynaml.ListExpansionExpr:
+			return
+		case dynaml.NameArgument:
+			l.add(path, node, "no named arguments for builtin function (%s)", name)
+			return
+		}
+	}
+	n := len(e.Arguments)
+	switch {
+	case n < min && !e.Curry:
+		l.add(path, node, "function '%s' requires at least %d argument(s), but found %d", name, min, n)
+	case max >= 0 && n > max:
+		l.add(path, node, "function '%s' accepts at most %d argument(s), but found %d", name, max, n)
+	}
+}
+
+func (l *linter) checkReference(path []string, node yaml.Node, ref dynaml.ReferenceExpr, bound map[string]struct{}) {
+	if ref.Tag != "" {
+		return
+	}
+	for _, name := range ref.Path {
+		if name == "" || strings.HasPrefix(name, "[") {
+			continue
+		}
+		if _, ok := bound[name]; ok {
+			return
+		}
+		if _, ok := l.names[name]; !ok {
+			l.add(path, node, "reference '%s' not found in any document", strings.Join(ref.Path, "."))
+		}
+		return
+	}
+}
+
+// checkStub reports stub fields not found in any template document.
+// Fields below template nodes not being plain maps are always considered
+// to be used, because they might be merged by expressions.
+func (l *linter) checkStub(path []string, stub yaml.Node, templates []yaml.Node) {
+	m, ok := stub.Value().(map[string]yaml.Node)
+	if !ok {
+		return
+	}
+	for _, t := range templates {
+		if t == nil {
+			continue
+		}
+		tm, ok := t.Value().(map[string]yaml.Node)
+		if !ok {
+			return
+		}
+		for k := range tm {
+			if strings.HasPrefix(k, "<<") {
+				return
+			}
+		}
+	}
+	for _, k := range yaml.GetSortedKeys(m) {
+		if strings.HasPrefix(k, "<<") {
+			continue
+		}
+		var sub []yaml.Node
+		for _, t := range templates {
+			if t != nil {
+				if e, ok := t.Value().(map[string]yaml.Node)[k]; ok {
+					sub = append(sub, e)
+				}
+			}
+		}
+		p := append(path[:len(path):len(path)], k)
+		if len(sub) == 0 {
+			l.add(p, m[k], "stub field not used by template")
+			continue
+		}
+		l.checkStub(p, m[k], sub)
+	}
+}
+
+func extend(bound map[string]struct{}) map[string]struct{} {
+	nested := map[string]struct{}{}
+	for n := range bound {
+		nested[n] = struct{}{}
+	}
+	return nested
+}
+
+// functionName provides the name of a function called by name.
+func functionName(e dynaml.CallExpr) string {
+	ref, ok := e.Function.(dynaml.ReferenceExpr)
+	if ok && ref.Tag == "" && len(ref.Path) == 1 && ref.Path[0] != "" && ref.Path[0] != yaml.SELF {
+		return ref.Path[0]
+	}
+	return ""
+}
@@ -0,0 +1,131 @@
+package compile
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+func lint(template string, stubs ...string) []string {
+	t, err := yaml.Parse("template", []byte(template))
+	Expect(err).To(Not(HaveOccurred()))
+	var s []yaml.Node
+	for i, src := range stubs {
+		n, err := yaml.Parse(fmt.Sprintf("stub%d", i+1), []byte(src))
+		Expect(err).To(Not(HaveOccurred()))
+		s = append(s, n)
+	}
+	result := []string{}
+	for _, e := range Lint([]yaml.Node{t}, s, LintOptions{}) {
+		result = append(result, e.Error())
+	}
+	return result
+}
+
+var _ = Describe("Lint", func() {
+	It("accepts valid documents", func() {
+		Expect(lint(`
+---
+list:
+  - name: alice
+names: (( map[list|e|->e.name] ))
+scoped: (( ($a=1) a + length(names) ))
+default: (( missing || "default" ))
+exists: (( defined(missing) ))
+lambda: (( |x,y=1|->x + y ))
+call: (( .lambda(1) ))
+warned: (( warn("outdated", 1) ))
+`)).To(BeEmpty())
+	})
+
+	It("reports all findings ordered by location", func() {
+		Expect(lint(`
+---
+parse: (( blub( ))
+unknown: (( foo(1) ))
+arity: (( length(1, 2) ))
+less: (( substr("alice") ))
+named: (( upper(x="a") ))
+reference: (( alice.bob ))
+control:
+  <<foo: 1
+`)).To(Equal([]string{
+			"template:3:8: parse: parse error near symbol 7 - symbol 8: ' '",
+			"template:4:10: unknown: unknown function 'foo'",
+			"template:5:8: arity: function 'length' accepts at most 1 argument(s), but found 2",
+			"template:6:7: less: function 'substr' requires at least 2 argument(s), but found 1",
+			"template:7:8: named: no named arguments for builtin function (upper)",
+			"template:8:12: reference: reference 'alice.bob' not found in any document",
+			"template:10:10: control.<<foo: unknown control or control option \"<<foo\"",
+		}))
+	})
+
+	It("accepts registered functions and curried builtins", func() {
+		Expect(lint(`
+---
+string: (( string(1) ))
+join: (( join*(",") ))
+expanded: (( substr([ "alice", 1 ]...) ))
+`)).To(BeEmpty())
+	})
+
+	It("accepts names bound where templates are instantiated", func() {
+		Expect(lint(`
+---
+spec: (( |name|->*template ))
+template:
+  <<: (( &template ))
+  value: (( name ))
+`)).To(BeEmpty())
+	})
+
+	It("checks marked expressions, but not value templates", func() {
+		Expect(lint(`
+---
+temp: (( &temporary(missing) ))
+value: (( &template(name) ))
+`)).To(Equal([]string{
+			"template:3:7: temp: reference 'missing' not found in any document",
+		}))
+	})
+
+	It("reports unused stub fields", func() {
+		Expect(lint(`
+---
+alice:
+  bob: 1
+merged:
+  <<: (( merge ))
+computed: (( {} ))
+`, `
+---
+alice:
+  bob: 2
+  peter: 3
+merged:
+  any: 4
+computed:
+  any: 5
+unused: 6
+`)).To(Equal([]string{
+			"stub1:5:10: alice.peter: stub field not used by template",
+			"stub1:10:9: unused: stub field not used by template",
+		}))
+	})
+
+	It("accepts references to stub fields", func() {
+		Expect(lint(`
+---
+value: (( stubonly ))
+`, `
+---
+stubonly: 1
+value: 2
+`)).To(Equal([]string{
+			"stub1:3:11: stubonly: stub field not used by template",
+		}))
+	})
+})
@@ -1,8 +1,12 @@
 package dynaml
 
 import (
+	"encoding/base64"
 	"fmt"
+	"io"
+
 	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/blowfish"
 )
 
 func func_bcrypt(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
@@ -25,7 +29,13 @@ func func_bcrypt(arguments []interface{}, binding Binding) (interface{}, Evaluat
 		}
 		cost = int(c)
 	}
-	result, err := bcrypt.GenerateFromPassword([]byte(str), cost)
+	var result []byte
+	var err error
+	if IsDeterministic(binding) {
+		result, err = bcryptWithSalt([]byte(str), cost, RandomSource(binding, "bcrypt", str, cost))
+	} else {
+		result, err = bcrypt.GenerateFromPassword([]byte(str), cost)
+	}
 	if err != nil {
 		return info.Error("bcrypt error: %s", err)
 	}
@@ -53,3 +63,45 @@ func func_bcrypt_check(arguments []interface{}, binding Binding) (interface{}, E
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(passwd))
 	return err == nil, info, true
 }
+
+// bcryptEncoding is the base64 variant used by bcrypt.
+var bcryptEncoding = base64.NewEncoding("./ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789").WithPadding(base64.NoPadding)
+
+// bcryptWithSalt is the bcrypt algorithm (version 2a) as implemented by
+// golang.org/x/crypto/bcrypt, but taking the salt from the given source
+// instead of crypto/rand. It is used to get reproducible hashes in
+// deterministic mode. The result can be verified by bcrypt_check.
+func bcryptWithSalt(password []byte, cost int, random io.Reader) ([]byte, error) {
+	if len(password) > 72 {
+		return nil, bcrypt.ErrPasswordTooLong
+	}
+	if cost < bcrypt.MinCost {
+		cost = bcrypt.DefaultCost
+	}
+	if cost > bcrypt.MaxCost {
+		return nil, bcrypt.InvalidCostError(cost)
+	}
+	salt := make([]byte, 16)
+	if _, err := io.ReadFull(random, salt); err != nil {
+		return nil, err
+	}
+
+	// the trailing NULL of C strings is part of the key
+	key := append(password[:len(password):len(password)], 0)
+	c, err := blowfish.NewSaltedCipher(key, salt)
+	if err != nil {
+		return nil, err
+	}
+	for i := uint64(0); i < 1<<uint(cost); i++ {
+		blowfish.ExpandKey(key, c)
+		blowfish.ExpandKey(salt, c)
+	}
+	data := []byte("OrpheanBeholderScryDoubt")
+	for i := 0; i < 24; i += 8 {
+		for j := 0; j < 64; j++ {
+			c.Encrypt(data[i:i+8], data[i:i+8])
+		}
+	}
+	// only 23 of the 24 encrypted bytes are encoded
+	return []byte(fmt.Sprintf("$2a$%02d$%s%s", cost, bcryptEncoding.EncodeToString(salt), bcryptEncoding.EncodeToString(data[:23]))), nil
+}
@@ -2,7 +2,9 @@ package dynaml
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/mandelsoft/spiff/yaml"
 
@@ -42,6 +44,111 @@ func RegisterFunction(name string, f Function) {
 
 var function_registry = NewFunctions()
 
+// arity describes the number of arguments accepted by a builtin function.
+// A negative maximum means an arbitrary number of arguments.
+type arity struct {
+	min, max int
+}
+
+// builtins describes the functions directly handled by the call expression.
+// It must cover all functions handled by CallExpr.Evaluate.
+var builtins = map[string]arity{
+	"defined":  {1, -1},
+	"optional": {2, 2},
+	"require":  {1, 1},
+	"valid":    {1, -1},
+	"stub":     {0, 1},
+	"catch":    {1, 1},
+	"sync":     {2, 4},
+
+	"static_ips":     {1, -1},
+	"join":           {1, -1},
+	"split":          {2, 3},
+	"split_match":    {2, 3},
+	"trim":           {1, 2},
+	"length":         {1, 1},
+	"uniq":           {1, 1},
+	"element":        {2, 2},
+	"contains":       {2, 2},
+	"index":          {2, 2},
+	"lastindex":      {2, 2},
+	"replace":        {3, 4},
+	"replace_match":  {3, 4},
+	"match":          {2, 3},
+	"sort":           {1, 2},
+	"exec":           {1, -1},
+	"exec_uncached":  {1, -1},
+	"pipe":           {2, -1},
+	"pipe_uncached":  {2, -1},
+	"eval":           {1, 1},
+	"env":            {1, -1},
+	"rand":           {0, 2},
+	"read":           {1, 3},
+	"read_uncached":  {1, 3},
+	"write":          {2, 3},
+	"lookup_file":    {2, -1},
+	"lookup_dir":     {2, -1},
+	"list_files":     {1, 1},
+	"list_dirs":      {1, 1},
+	"tempfile":       {1, 2},
+	"format":         {1, -1},
+	"error":          {1, -1},
+	"warn":           {1, 2},
+	"min_ip":         {1, 1},
+	"max_ip":         {1, 1},
+	"num_ip":         {1, 1},
+	"contains_ip":    {2, 2},
+	"makemap":        {0, -1},
+	"list_to_map":    {1, 2},
+	"ipset":          {2, -1},
+	"merge":          {1, -1},
+	"deepmerge":      {1, -1},
+	"base64":         {1, 2},
+	"base64_decode":  {1, 1},
+	"md5":            {1, 1},
+	"hash":           {1, 2},
+	"bcrypt":         {1, 2},
+	"bcrypt_check":   {2, 2},
+	"md5crypt":       {1, 1},
+	"md5crypt_check": {2, 2},
+	"asjson":         {1, 1},
+	"asyaml":         {1, 1},
+	"parse":          {1, 2},
+	"substr":         {2, 3},
+	"lower":          {1, 1},
+	"upper":          {1, 1},
+	"keys":           {1, 1},
+	"archive":        {1, 2},
+	"validate":       {2, -1},
+	"check":          {2, -1},
+	"type":           {1, 1},
+}
+
+// FunctionNames provides the sorted names of all builtin functions
+// and the functions of the default function registry.
+func FunctionNames() []string {
+	names := []string{}
+	for n := range builtins {
+		names = append(names, n)
+	}
+	for n := range function_registry.(*functionRegistry).functions {
+		if _, ok := builtins[n]; !ok {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BuiltinArity provides the minimum and maximum number of arguments
+// accepted by a builtin function. A negative maximum means an arbitrary
+// number of arguments. Functions provided by a function registry
+// are not covered.
+func BuiltinArity(name string) (int, int, bool) {
+	a, ok := builtins[name]
+	return a.min, a.max, ok
+}
+
 type NameArgument struct {
 	Name string
 	Expression
@@ -155,6 +262,9 @@ func (e CallExpr) Evaluate(binding Binding, locally bool) (interface{}, Evaluati
 
 		return LambdaValue{params, LambdaExpr{params, true, expr}, nil, binding}, DefaultInfo(), true
 	}
+	if p := ProfilerOf(binding); p != nil {
+		defer profileCall(p, funcName, value, time.Now())
+	}
 	switch funcName {
 	case "":
 		debug.Debug("calling lambda function %#v\n", value)
@@ -252,6 +362,8 @@ func (e CallExpr) Evaluate(binding Binding, locally bool) (interface{}, Evaluati
 
 	case "error":
 		result, sub, ok = func_error(values, binding)
+	case "warn":
+		result, sub, ok = func_warn(values, binding)
 
 	case "min_ip":
 		result, sub, ok = func_minIP(values, binding)
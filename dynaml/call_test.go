@@ -1,6 +1,11 @@
 package dynaml
 
 import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -335,4 +340,47 @@ var _ = Describe("calls", func() {
 			})
 		})
 	})
+
+	Describe("builtin arities", func() {
+		// collects the function names handled by the switch statements
+		// of CallExpr.Evaluate
+		handled := func() []string {
+			file, err := parser.ParseFile(token.NewFileSet(), "call.go", nil, 0)
+			Expect(err).To(Succeed())
+			names := []string{}
+			for _, d := range file.Decls {
+				f, ok := d.(*ast.FuncDecl)
+				if !ok || f.Name.Name != "Evaluate" || f.Recv == nil {
+					continue
+				}
+				ast.Inspect(f.Body, func(n ast.Node) bool {
+					if sw, ok := n.(*ast.SwitchStmt); ok {
+						if tag, ok := sw.Tag.(*ast.Ident); ok && tag.Name == "funcName" {
+							for _, c := range sw.Body.List {
+								for _, e := range c.(*ast.CaseClause).List {
+									name, err := strconv.Unquote(e.(*ast.BasicLit).Value)
+									Expect(err).To(Succeed())
+									if name != "" {
+										names = append(names, name)
+									}
+								}
+							}
+						}
+					}
+					return true
+				})
+			}
+			return names
+		}
+
+		It("are defined for all handled functions", func() {
+			names := handled()
+			Expect(names).To(ContainElement("warn"))
+			for _, n := range names {
+				_, _, ok := BuiltinArity(n)
+				Expect(ok).To(BeTrue(), "arity for %s", n)
+			}
+			Expect(names).To(HaveLen(len(builtins)))
+		})
+	})
 })
@@ -1,6 +1,7 @@
 package crypt
 
 import (
+	"io"
 	"math/rand"
 	"time"
 )
@@ -13,3 +14,15 @@ func GenerateSALT(length int) []byte {
 	}
 	return []byte(s)
 }
+
+// GenerateSALTFrom generates a salt using the given source of random data.
+func GenerateSALTFrom(random io.Reader, length int) ([]byte, error) {
+	buf := make([]byte, length)
+	if _, err := io.ReadFull(random, buf); err != nil {
+		return nil, err
+	}
+	for i, b := range buf {
+		buf[i] = itoa64[b%64]
+	}
+	return buf, nil
+}
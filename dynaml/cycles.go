@@ -0,0 +1,181 @@
+package dynaml
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+// markCycles detects reference cycles among unresolved expression nodes.
+// Nodes involved in a cycle get the cycle as issue, listing the
+// participants together with their source locations.
+func markCycles(root yaml.Node, nodes []UnresolvedNode) {
+	var candidates []int
+	for i, n := range nodes {
+		if _, ok := n.Value().(Expression); ok && !n.HasError() {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 {
+		return
+	}
+
+	edges := make([][]int, len(candidates))
+	for i, c := range candidates {
+		n := nodes[c]
+		for _, ref := range references(n.Value().(Expression)) {
+			target, ok := resolveReference(root, n.Context, ref)
+			if !ok {
+				continue
+			}
+			for j, d := range candidates {
+				if isPathPrefix(target, nodes[d].Context) || isPathPrefix(nodes[d].Context, target) {
+					edges[i] = append(edges[i], j)
+				}
+			}
+		}
+	}
+
+	for i, c := range candidates {
+		cycle := findCycle(i, edges)
+		if cycle == nil {
+			continue
+		}
+		var names []string
+		issue := yaml.NewIssue("")
+		for _, j := range cycle {
+			n := nodes[candidates[j]]
+			name := strings.Join(n.Context, ".")
+			names = append(names, name)
+			if j != i || len(issue.Nested) == 0 {
+				issue.Nested = append(issue.Nested, yaml.NewIssue("%s\tin %s", name, yaml.SourceLocation(n.Node)))
+			}
+		}
+		issue.Issue = "cyclic dependency: " + strings.Join(names, " -> ")
+		nodes[c].Node = yaml.IssueNode(nodes[c].Node, false, false, issue)
+		nodes[c].Cycle = names
+	}
+}
+
+// findCycle determines the shortest cycle starting and ending with
+// the given node.
+func findCycle(start int, edges [][]int) []int {
+	prev := map[int]int{}
+	queue := []int{}
+	for _, n := range edges[start] {
+		if n == start {
+			return []int{start, start}
+		}
+		if _, ok := prev[n]; !ok {
+			prev[n] = start
+			queue = append(queue, n)
+		}
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, n := range edges[cur] {
+			if n == start {
+				cycle := []int{start}
+				for p := cur; p != start; p = prev[p] {
+					cycle = append([]int{p}, cycle...)
+				}
+				return append([]int{start}, cycle...)
+			}
+			if _, ok := prev[n]; !ok {
+				prev[n] = cur
+				queue = append(queue, n)
+			}
+		}
+	}
+	return nil
+}
+
+func isPathPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i, p := range prefix {
+		if path[i] != p {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveReference determines the document path of a reference
+// used by a node, as far as it can be followed in the document.
+// List entries are addressed by their index.
+func resolveReference(root yaml.Node, context []string, ref []string) ([]string, bool) {
+	if len(ref) == 0 {
+		return nil, false
+	}
+	if ref[0] == "" {
+		return normalizePath(root, nil, ref[1:]), true
+	}
+	switch ref[0] {
+	case yaml.SELF, yaml.DOCNODE, yaml.ROOT, "__ctx":
+		return nil, false
+	}
+	for i := len(context) - 1; i >= 0; i-- {
+		scope, ok := yaml.FindR(true, root, nil, context[:i]...)
+		if !ok {
+			continue
+		}
+		if m, ok := scope.Value().(map[string]yaml.Node); ok && m[ref[0]] != nil {
+			return normalizePath(root, context[:i], ref), true
+		}
+	}
+	return nil, false
+}
+
+// normalizePath follows a path relative to a scope as far as possible.
+// The remaining steps are kept as they are.
+func normalizePath(root yaml.Node, scope []string, path []string) []string {
+	result := append([]string{}, scope...)
+	here, _ := yaml.FindR(true, root, nil, scope...)
+	for i, step := range path {
+		if here == nil {
+			return append(result, path[i:]...)
+		}
+		switch v := here.Value().(type) {
+		case map[string]yaml.Node:
+			here = v[step]
+		case []yaml.Node:
+			index, ok := yaml.ListIndex(v, step, here.KeyName())
+			if !ok {
+				return append(result, path[i:]...)
+			}
+			here = v[index]
+			step = fmt.Sprintf("[%d]", index)
+		default:
+			return append(result, path[i:]...)
+		}
+		result = append(result, step)
+	}
+	return result
+}
+
+// references determines the untagged references used by an expression.
+// Lambda bodies are ignored, because they are evaluated in the scope of
+// their invocation, and qualified references are relative to the value
+// of their expression.
+func references(e Expression) [][]string {
+	var refs [][]string
+	VisitExpressions(e, func(e Expression) bool {
+		switch v := e.(type) {
+		case ReferenceExpr:
+			if v.Tag == "" {
+				refs = append(refs, v.Path)
+			}
+		case QualifiedExpr:
+			refs = append(refs, references(v.Expression)...)
+			return false
+		case LambdaExpr:
+			return false
+		}
+		return true
+	})
+	return refs
+}
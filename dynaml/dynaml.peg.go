@@ -20,6 +20,10 @@ const (
 	ruleSubsequentMarker
 	ruleMarker
 	ruleTagMarker
+	ruleDeprecatedMarker
+	ruleDeprecationMessage
+	ruleInsertionMarker
+	ruleInsertionKey
 	ruleMarkerExpression
 	ruleExpression
 	ruleScoped
@@ -87,6 +91,8 @@ const (
 	ruleReplace
 	ruleRequired
 	ruleOn
+	ruleMergeKeys
+	ruleMergeKey
 	ruleAuto
 	ruleDefault
 	ruleSync
@@ -138,6 +144,10 @@ var rul3s = [...]string{
 	"SubsequentMarker",
 	"Marker",
 	"TagMarker",
+	"DeprecatedMarker",
+	"DeprecationMessage",
+	"InsertionMarker",
+	"InsertionKey",
 	"MarkerExpression",
 	"Expression",
 	"Scoped",
@@ -205,6 +215,8 @@ var rul3s = [...]string{
 	"Replace",
 	"Required",
 	"On",
+	"MergeKeys",
+	"MergeKey",
 	"Auto",
 	"Default",
 	"Sync",
@@ -560,7 +572,7 @@ func (t *tokens32) Expand(index int) tokenTree {
 type DynamlGrammar struct {
 	Buffer string
 	buffer []rune
-	rules  [111]func() bool
+	rules  [117]func() bool
 	Parse  func(rule ...int) error
 	Reset  func()
 	Pretty bool
@@ -871,7 +883,7 @@ func (p *DynamlGrammar) Init() {
 			position, tokenIndex, depth = position14, tokenIndex14, depth14
 			return false
 		},
-		/* 4 Marker <- <('&' (('t' 'e' 'm' 'p' 'l' 'a' 't' 'e') / ('t' 'e' 'm' 'p' 'o' 'r' 'a' 'r' 'y') / ('l' 'o' 'c' 'a' 'l') / ('i' 'n' 'j' 'e' 'c' 't') / ('s' 't' 'a' 't' 'e') / ('d' 'e' 'f' 'a' 'u' 'l' 't') / ('d' 'y' 'n' 'a' 'm' 'i' 'c') / TagMarker))> */
+		/* 4 Marker <- <('&' (('t' 'e' 'm' 'p' 'l' 'a' 't' 'e') / ('t' 'e' 'm' 'p' 'o' 'r' 'a' 'r' 'y') / ('l' 'o' 'c' 'a' 'l') / ('i' 'n' 'j' 'e' 'c' 't') / ('s' 't' 'a' 't' 'e') / ('d' 'e' 'f' 'a' 'u' 'l' 't') / ('d' 'y' 'n' 'a' 'm' 'i' 'c') / ('d' 'e' 'l' 'e' 't' 'e') / ('f' 'i' 'n' 'a' 'l') / DeprecatedMarker / InsertionMarker / TagMarker))> */
 		func() bool {
 			position16, tokenIndex16, depth16 := position, tokenIndex, depth
 			{
@@ -1091,6 +1103,68 @@ func (p *DynamlGrammar) Init() {
 					position++
 					goto l18
 				l25:
+					position, tokenIndex, depth = position18, tokenIndex18, depth18
+					if buffer[position] != rune('d') {
+						goto l26
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l26
+					}
+					position++
+					if buffer[position] != rune('l') {
+						goto l26
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l26
+					}
+					position++
+					if buffer[position] != rune('t') {
+						goto l26
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l26
+					}
+					position++
+					goto l18
+				l26:
+					position, tokenIndex, depth = position18, tokenIndex18, depth18
+					if buffer[position] != rune('f') {
+						goto l27
+					}
+					position++
+					if buffer[position] != rune('i') {
+						goto l27
+					}
+					position++
+					if buffer[position] != rune('n') {
+						goto l27
+					}
+					position++
+					if buffer[position] != rune('a') {
+						goto l27
+					}
+					position++
+					if buffer[position] != rune('l') {
+						goto l27
+					}
+					position++
+					goto l18
+				l27:
+					position, tokenIndex, depth = position18, tokenIndex18, depth18
+					if !_rules[ruleDeprecatedMarker]() {
+						goto l28
+					}
+					goto l18
+				l28:
+					position, tokenIndex, depth = position18, tokenIndex18, depth18
+					if !_rules[ruleInsertionMarker]() {
+						goto l29
+					}
+					goto l18
+				l29:
 					position, tokenIndex, depth = position18, tokenIndex18, depth18
 					if !_rules[ruleTagMarker]() {
 						goto l16
@@ -1107,4169 +1181,4692 @@ func (p *DynamlGrammar) Init() {
 		},
 		/* 5 TagMarker <- <('t' 'a' 'g' ':' '*'? Tag)> */
 		func() bool {
-			position26, tokenIndex26, depth26 := position, tokenIndex, depth
+			position30, tokenIndex30, depth30 := position, tokenIndex, depth
 			{
-				position27 := position
+				position31 := position
 				depth++
 				if buffer[position] != rune('t') {
-					goto l26
+					goto l30
 				}
 				position++
 				if buffer[position] != rune('a') {
-					goto l26
+					goto l30
 				}
 				position++
 				if buffer[position] != rune('g') {
-					goto l26
+					goto l30
 				}
 				position++
 				if buffer[position] != rune(':') {
-					goto l26
+					goto l30
 				}
 				position++
 				{
-					position28, tokenIndex28, depth28 := position, tokenIndex, depth
+					position32, tokenIndex32, depth32 := position, tokenIndex, depth
 					if buffer[position] != rune('*') {
-						goto l28
+						goto l32
 					}
 					position++
-					goto l29
-				l28:
-					position, tokenIndex, depth = position28, tokenIndex28, depth28
+					goto l33
+				l32:
+					position, tokenIndex, depth = position32, tokenIndex32, depth32
 				}
-			l29:
+			l33:
 				if !_rules[ruleTag]() {
-					goto l26
+					goto l30
 				}
 				depth--
-				add(ruleTagMarker, position27)
+				add(ruleTagMarker, position31)
 			}
 			return true
-		l26:
-			position, tokenIndex, depth = position26, tokenIndex26, depth26
+		l30:
+			position, tokenIndex, depth = position30, tokenIndex30, depth30
 			return false
 		},
-		/* 6 MarkerExpression <- <Grouped> */
+		/* 6 DeprecatedMarker <- <('d' 'e' 'p' 'r' 'e' 'c' 'a' 't' 'e' 'd' ('(' ws DeprecationMessage ws ')')?)> */
 		func() bool {
-			position30, tokenIndex30, depth30 := position, tokenIndex, depth
+			position34, tokenIndex34, depth34 := position, tokenIndex, depth
 			{
-				position31 := position
+				position35 := position
+				depth++
+				if buffer[position] != rune('d') {
+					goto l34
+				}
+				position++
+				if buffer[position] != rune('e') {
+					goto l34
+				}
+				position++
+				if buffer[position] != rune('p') {
+					goto l34
+				}
+				position++
+				if buffer[position] != rune('r') {
+					goto l34
+				}
+				position++
+				if buffer[position] != rune('e') {
+					goto l34
+				}
+				position++
+				if buffer[position] != rune('c') {
+					goto l34
+				}
+				position++
+				if buffer[position] != rune('a') {
+					goto l34
+				}
+				position++
+				if buffer[position] != rune('t') {
+					goto l34
+				}
+				position++
+				if buffer[position] != rune('e') {
+					goto l34
+				}
+				position++
+				if buffer[position] != rune('d') {
+					goto l34
+				}
+				position++
+				{
+					position36, tokenIndex36, depth36 := position, tokenIndex, depth
+					if buffer[position] != rune('(') {
+						goto l36
+					}
+					position++
+					if !_rules[rulews]() {
+						goto l36
+					}
+					if !_rules[ruleDeprecationMessage]() {
+						goto l36
+					}
+					if !_rules[rulews]() {
+						goto l36
+					}
+					if buffer[position] != rune(')') {
+						goto l36
+					}
+					position++
+					goto l37
+				l36:
+					position, tokenIndex, depth = position36, tokenIndex36, depth36
+				}
+			l37:
+				depth--
+				add(ruleDeprecatedMarker, position35)
+			}
+			return true
+		l34:
+			position, tokenIndex, depth = position34, tokenIndex34, depth34
+			return false
+		},
+		/* 7 DeprecationMessage <- <('"' (('\\' .) / (!'"' .))* '"')> */
+		func() bool {
+			position38, tokenIndex38, depth38 := position, tokenIndex, depth
+			{
+				position39 := position
+				depth++
+				if buffer[position] != rune('"') {
+					goto l38
+				}
+				position++
+			l40:
+				{
+					position41, tokenIndex41, depth41 := position, tokenIndex, depth
+					{
+						position42, tokenIndex42, depth42 := position, tokenIndex, depth
+						if buffer[position] != rune('\\') {
+							goto l43
+						}
+						position++
+						if !matchDot() {
+							goto l43
+						}
+						goto l42
+					l43:
+						position, tokenIndex, depth = position42, tokenIndex42, depth42
+						{
+							position44, tokenIndex44, depth44 := position, tokenIndex, depth
+							if buffer[position] != rune('"') {
+								goto l44
+							}
+							position++
+							goto l41
+						l44:
+							position, tokenIndex, depth = position44, tokenIndex44, depth44
+						}
+						if !matchDot() {
+							goto l41
+						}
+					}
+				l42:
+					goto l40
+				l41:
+					position, tokenIndex, depth = position41, tokenIndex41, depth41
+				}
+				if buffer[position] != rune('"') {
+					goto l38
+				}
+				position++
+				depth--
+				add(ruleDeprecationMessage, position39)
+			}
+			return true
+		l38:
+			position, tokenIndex, depth = position38, tokenIndex38, depth38
+			return false
+		},
+		/* 8 InsertionMarker <- <((('b' 'e' 'f' 'o' 'r' 'e') / ('a' 'f' 't' 'e' 'r')) ':' InsertionKey)> */
+		func() bool {
+			position45, tokenIndex45, depth45 := position, tokenIndex, depth
+			{
+				position46 := position
+				depth++
+				{
+					position47, tokenIndex47, depth47 := position, tokenIndex, depth
+					if buffer[position] != rune('b') {
+						goto l48
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l48
+					}
+					position++
+					if buffer[position] != rune('f') {
+						goto l48
+					}
+					position++
+					if buffer[position] != rune('o') {
+						goto l48
+					}
+					position++
+					if buffer[position] != rune('r') {
+						goto l48
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l48
+					}
+					position++
+					goto l47
+				l48:
+					position, tokenIndex, depth = position47, tokenIndex47, depth47
+					if buffer[position] != rune('a') {
+						goto l45
+					}
+					position++
+					if buffer[position] != rune('f') {
+						goto l45
+					}
+					position++
+					if buffer[position] != rune('t') {
+						goto l45
+					}
+					position++
+					if buffer[position] != rune('e') {
+						goto l45
+					}
+					position++
+					if buffer[position] != rune('r') {
+						goto l45
+					}
+					position++
+				}
+			l47:
+				if buffer[position] != rune(':') {
+					goto l45
+				}
+				position++
+				if !_rules[ruleInsertionKey]() {
+					goto l45
+				}
+				depth--
+				add(ruleInsertionMarker, position46)
+			}
+			return true
+		l45:
+			position, tokenIndex, depth = position45, tokenIndex45, depth45
+			return false
+		},
+		/* 9 InsertionKey <- <(!(' ' / '\t' / '\n' / '(' / ')') .)+> */
+		func() bool {
+			position49, tokenIndex49, depth49 := position, tokenIndex, depth
+			{
+				position50 := position
+				depth++
+				{
+					position53, tokenIndex53, depth53 := position, tokenIndex, depth
+					{
+						position54, tokenIndex54, depth54 := position, tokenIndex, depth
+						if buffer[position] != rune(' ') {
+							goto l55
+						}
+						position++
+						goto l54
+					l55:
+						position, tokenIndex, depth = position54, tokenIndex54, depth54
+						if buffer[position] != rune('\t') {
+							goto l56
+						}
+						position++
+						goto l54
+					l56:
+						position, tokenIndex, depth = position54, tokenIndex54, depth54
+						if buffer[position] != rune('\n') {
+							goto l57
+						}
+						position++
+						goto l54
+					l57:
+						position, tokenIndex, depth = position54, tokenIndex54, depth54
+						if buffer[position] != rune('(') {
+							goto l58
+						}
+						position++
+						goto l54
+					l58:
+						position, tokenIndex, depth = position54, tokenIndex54, depth54
+						if buffer[position] != rune(')') {
+							goto l53
+						}
+						position++
+					}
+				l54:
+					goto l49
+				l53:
+					position, tokenIndex, depth = position53, tokenIndex53, depth53
+				}
+				if !matchDot() {
+					goto l49
+				}
+			l51:
+				{
+					position52, tokenIndex52, depth52 := position, tokenIndex, depth
+					{
+						position59, tokenIndex59, depth59 := position, tokenIndex, depth
+						{
+							position60, tokenIndex60, depth60 := position, tokenIndex, depth
+							if buffer[position] != rune(' ') {
+								goto l61
+							}
+							position++
+							goto l60
+						l61:
+							position, tokenIndex, depth = position60, tokenIndex60, depth60
+							if buffer[position] != rune('\t') {
+								goto l62
+							}
+							position++
+							goto l60
+						l62:
+							position, tokenIndex, depth = position60, tokenIndex60, depth60
+							if buffer[position] != rune('\n') {
+								goto l63
+							}
+							position++
+							goto l60
+						l63:
+							position, tokenIndex, depth = position60, tokenIndex60, depth60
+							if buffer[position] != rune('(') {
+								goto l64
+							}
+							position++
+							goto l60
+						l64:
+							position, tokenIndex, depth = position60, tokenIndex60, depth60
+							if buffer[position] != rune(')') {
+								goto l59
+							}
+							position++
+						}
+					l60:
+						goto l52
+					l59:
+						position, tokenIndex, depth = position59, tokenIndex59, depth59
+					}
+					if !matchDot() {
+						goto l52
+					}
+					goto l51
+				l52:
+					position, tokenIndex, depth = position52, tokenIndex52, depth52
+				}
+				depth--
+				add(ruleInsertionKey, position50)
+			}
+			return true
+		l49:
+			position, tokenIndex, depth = position49, tokenIndex49, depth49
+			return false
+		},
+		/* 10 MarkerExpression <- <Grouped> */
+		func() bool {
+			position65, tokenIndex65, depth65 := position, tokenIndex, depth
+			{
+				position66 := position
 				depth++
 				if !_rules[ruleGrouped]() {
-					goto l30
+					goto l65
 				}
 				depth--
-				add(ruleMarkerExpression, position31)
+				add(ruleMarkerExpression, position66)
 			}
 			return true
-		l30:
-			position, tokenIndex, depth = position30, tokenIndex30, depth30
+		l65:
+			position, tokenIndex, depth = position65, tokenIndex65, depth65
 			return false
 		},
-		/* 7 Expression <- <((Scoped / LambdaExpr / Level7) ws)> */
+		/* 11 Expression <- <((Scoped / LambdaExpr / Level7) ws)> */
 		func() bool {
-			position32, tokenIndex32, depth32 := position, tokenIndex, depth
+			position67, tokenIndex67, depth67 := position, tokenIndex, depth
 			{
-				position33 := position
+				position68 := position
 				depth++
 				{
-					position34, tokenIndex34, depth34 := position, tokenIndex, depth
+					position69, tokenIndex69, depth69 := position, tokenIndex, depth
 					if !_rules[ruleScoped]() {
-						goto l35
+						goto l70
 					}
-					goto l34
-				l35:
-					position, tokenIndex, depth = position34, tokenIndex34, depth34
+					goto l69
+				l70:
+					position, tokenIndex, depth = position69, tokenIndex69, depth69
 					if !_rules[ruleLambdaExpr]() {
-						goto l36
+						goto l71
 					}
-					goto l34
-				l36:
-					position, tokenIndex, depth = position34, tokenIndex34, depth34
+					goto l69
+				l71:
+					position, tokenIndex, depth = position69, tokenIndex69, depth69
 					if !_rules[ruleLevel7]() {
-						goto l32
+						goto l67
 					}
 				}
-			l34:
+			l69:
 				if !_rules[rulews]() {
-					goto l32
+					goto l67
 				}
 				depth--
-				add(ruleExpression, position33)
+				add(ruleExpression, position68)
 			}
 			return true
-		l32:
-			position, tokenIndex, depth = position32, tokenIndex32, depth32
+		l67:
+			position, tokenIndex, depth = position67, tokenIndex67, depth67
 			return false
 		},
-		/* 8 Scoped <- <(ws Scope ws Expression)> */
+		/* 12 Scoped <- <(ws Scope ws Expression)> */
 		func() bool {
-			position37, tokenIndex37, depth37 := position, tokenIndex, depth
+			position72, tokenIndex72, depth72 := position, tokenIndex, depth
 			{
-				position38 := position
+				position73 := position
 				depth++
 				if !_rules[rulews]() {
-					goto l37
+					goto l72
 				}
 				if !_rules[ruleScope]() {
-					goto l37
+					goto l72
 				}
 				if !_rules[rulews]() {
-					goto l37
+					goto l72
 				}
 				if !_rules[ruleExpression]() {
-					goto l37
+					goto l72
 				}
 				depth--
-				add(ruleScoped, position38)
+				add(ruleScoped, position73)
 			}
 			return true
-		l37:
-			position, tokenIndex, depth = position37, tokenIndex37, depth37
+		l72:
+			position, tokenIndex, depth = position72, tokenIndex72, depth72
 			return false
 		},
-		/* 9 Scope <- <(CreateScope ws Assignments? ')')> */
+		/* 13 Scope <- <(CreateScope ws Assignments? ')')> */
 		func() bool {
-			position39, tokenIndex39, depth39 := position, tokenIndex, depth
+			position74, tokenIndex74, depth74 := position, tokenIndex, depth
 			{
-				position40 := position
+				position75 := position
 				depth++
 				if !_rules[ruleCreateScope]() {
-					goto l39
+					goto l74
 				}
 				if !_rules[rulews]() {
-					goto l39
+					goto l74
 				}
 				{
-					position41, tokenIndex41, depth41 := position, tokenIndex, depth
+					position76, tokenIndex76, depth76 := position, tokenIndex, depth
 					if !_rules[ruleAssignments]() {
-						goto l41
+						goto l76
 					}
-					goto l42
-				l41:
-					position, tokenIndex, depth = position41, tokenIndex41, depth41
+					goto l77
+				l76:
+					position, tokenIndex, depth = position76, tokenIndex76, depth76
 				}
-			l42:
+			l77:
 				if buffer[position] != rune(')') {
-					goto l39
+					goto l74
 				}
 				position++
 				depth--
-				add(ruleScope, position40)
+				add(ruleScope, position75)
 			}
 			return true
-		l39:
-			position, tokenIndex, depth = position39, tokenIndex39, depth39
+		l74:
+			position, tokenIndex, depth = position74, tokenIndex74, depth74
 			return false
 		},
-		/* 10 CreateScope <- <'('> */
+		/* 14 CreateScope <- <'('> */
 		func() bool {
-			position43, tokenIndex43, depth43 := position, tokenIndex, depth
+			position78, tokenIndex78, depth78 := position, tokenIndex, depth
 			{
-				position44 := position
+				position79 := position
 				depth++
 				if buffer[position] != rune('(') {
-					goto l43
+					goto l78
 				}
 				position++
 				depth--
-				add(ruleCreateScope, position44)
+				add(ruleCreateScope, position79)
 			}
 			return true
-		l43:
-			position, tokenIndex, depth = position43, tokenIndex43, depth43
+		l78:
+			position, tokenIndex, depth = position78, tokenIndex78, depth78
 			return false
 		},
-		/* 11 Level7 <- <(ws Level6 (req_ws Or)*)> */
+		/* 15 Level7 <- <(ws Level6 (req_ws Or)*)> */
 		func() bool {
-			position45, tokenIndex45, depth45 := position, tokenIndex, depth
+			position80, tokenIndex80, depth80 := position, tokenIndex, depth
 			{
-				position46 := position
+				position81 := position
 				depth++
 				if !_rules[rulews]() {
-					goto l45
+					goto l80
 				}
 				if !_rules[ruleLevel6]() {
-					goto l45
+					goto l80
 				}
-			l47:
+			l82:
 				{
-					position48, tokenIndex48, depth48 := position, tokenIndex, depth
+					position83, tokenIndex83, depth83 := position, tokenIndex, depth
 					if !_rules[rulereq_ws]() {
-						goto l48
+						goto l83
 					}
 					if !_rules[ruleOr]() {
-						goto l48
+						goto l83
 					}
-					goto l47
-				l48:
-					position, tokenIndex, depth = position48, tokenIndex48, depth48
+					goto l82
+				l83:
+					position, tokenIndex, depth = position83, tokenIndex83, depth83
 				}
 				depth--
-				add(ruleLevel7, position46)
+				add(ruleLevel7, position81)
 			}
 			return true
-		l45:
-			position, tokenIndex, depth = position45, tokenIndex45, depth45
+		l80:
+			position, tokenIndex, depth = position80, tokenIndex80, depth80
 			return false
 		},
-		/* 12 Or <- <(OrOp req_ws Level6)> */
+		/* 16 Or <- <(OrOp req_ws Level6)> */
 		func() bool {
-			position49, tokenIndex49, depth49 := position, tokenIndex, depth
+			position84, tokenIndex84, depth84 := position, tokenIndex, depth
 			{
-				position50 := position
+				position85 := position
 				depth++
 				if !_rules[ruleOrOp]() {
-					goto l49
+					goto l84
 				}
 				if !_rules[rulereq_ws]() {
-					goto l49
+					goto l84
 				}
 				if !_rules[ruleLevel6]() {
-					goto l49
+					goto l84
 				}
 				depth--
-				add(ruleOr, position50)
+				add(ruleOr, position85)
 			}
 			return true
-		l49:
-			position, tokenIndex, depth = position49, tokenIndex49, depth49
+		l84:
+			position, tokenIndex, depth = position84, tokenIndex84, depth84
 			return false
 		},
-		/* 13 OrOp <- <(('|' '|') / ('/' '/'))> */
+		/* 17 OrOp <- <(('|' '|') / ('/' '/'))> */
 		func() bool {
-			position51, tokenIndex51, depth51 := position, tokenIndex, depth
+			position86, tokenIndex86, depth86 := position, tokenIndex, depth
 			{
-				position52 := position
+				position87 := position
 				depth++
 				{
-					position53, tokenIndex53, depth53 := position, tokenIndex, depth
+					position88, tokenIndex88, depth88 := position, tokenIndex, depth
 					if buffer[position] != rune('|') {
-						goto l54
+						goto l89
 					}
 					position++
 					if buffer[position] != rune('|') {
-						goto l54
+						goto l89
 					}
 					position++
-					goto l53
-				l54:
-					position, tokenIndex, depth = position53, tokenIndex53, depth53
+					goto l88
+				l89:
+					position, tokenIndex, depth = position88, tokenIndex88, depth88
 					if buffer[position] != rune('/') {
-						goto l51
+						goto l86
 					}
 					position++
 					if buffer[position] != rune('/') {
-						goto l51
+						goto l86
 					}
 					position++
 				}
-			l53:
+			l88:
 				depth--
-				add(ruleOrOp, position52)
+				add(ruleOrOp, position87)
 			}
 			return true
-		l51:
-			position, tokenIndex, depth = position51, tokenIndex51, depth51
+		l86:
+			position, tokenIndex, depth = position86, tokenIndex86, depth86
 			return false
 		},
-		/* 14 Level6 <- <(Conditional / Level5)> */
+		/* 18 Level6 <- <(Conditional / Level5)> */
 		func() bool {
-			position55, tokenIndex55, depth55 := position, tokenIndex, depth
+			position90, tokenIndex90, depth90 := position, tokenIndex, depth
 			{
-				position56 := position
+				position91 := position
 				depth++
 				{
-					position57, tokenIndex57, depth57 := position, tokenIndex, depth
+					position92, tokenIndex92, depth92 := position, tokenIndex, depth
 					if !_rules[ruleConditional]() {
-						goto l58
+						goto l93
 					}
-					goto l57
-				l58:
-					position, tokenIndex, depth = position57, tokenIndex57, depth57
+					goto l92
+				l93:
+					position, tokenIndex, depth = position92, tokenIndex92, depth92
 					if !_rules[ruleLevel5]() {
-						goto l55
+						goto l90
 					}
 				}
-			l57:
+			l92:
 				depth--
-				add(ruleLevel6, position56)
+				add(ruleLevel6, position91)
 			}
 			return true
-		l55:
-			position, tokenIndex, depth = position55, tokenIndex55, depth55
+		l90:
+			position, tokenIndex, depth = position90, tokenIndex90, depth90
 			return false
 		},
-		/* 15 Conditional <- <(Level5 ws '?' Expression ':' Expression)> */
+		/* 19 Conditional <- <(Level5 ws '?' Expression ':' Expression)> */
 		func() bool {
-			position59, tokenIndex59, depth59 := position, tokenIndex, depth
+			position94, tokenIndex94, depth94 := position, tokenIndex, depth
 			{
-				position60 := position
+				position95 := position
 				depth++
 				if !_rules[ruleLevel5]() {
-					goto l59
+					goto l94
 				}
 				if !_rules[rulews]() {
-					goto l59
+					goto l94
 				}
 				if buffer[position] != rune('?') {
-					goto l59
+					goto l94
 				}
 				position++
 				if !_rules[ruleExpression]() {
-					goto l59
+					goto l94
 				}
 				if buffer[position] != rune(':') {
-					goto l59
+					goto l94
 				}
 				position++
 				if !_rules[ruleExpression]() {
-					goto l59
+					goto l94
 				}
 				depth--
-				add(ruleConditional, position60)
+				add(ruleConditional, position95)
 			}
 			return true
-		l59:
-			position, tokenIndex, depth = position59, tokenIndex59, depth59
+		l94:
+			position, tokenIndex, depth = position94, tokenIndex94, depth94
 			return false
 		},
-		/* 16 Level5 <- <(Level4 Concatenation*)> */
+		/* 20 Level5 <- <(Level4 Concatenation*)> */
 		func() bool {
-			position61, tokenIndex61, depth61 := position, tokenIndex, depth
+			position96, tokenIndex96, depth96 := position, tokenIndex, depth
 			{
-				position62 := position
+				position97 := position
 				depth++
 				if !_rules[ruleLevel4]() {
-					goto l61
+					goto l96
 				}
-			l63:
+			l98:
 				{
-					position64, tokenIndex64, depth64 := position, tokenIndex, depth
+					position99, tokenIndex99, depth99 := position, tokenIndex, depth
 					if !_rules[ruleConcatenation]() {
-						goto l64
+						goto l99
 					}
-					goto l63
-				l64:
-					position, tokenIndex, depth = position64, tokenIndex64, depth64
+					goto l98
+				l99:
+					position, tokenIndex, depth = position99, tokenIndex99, depth99
 				}
 				depth--
-				add(ruleLevel5, position62)
+				add(ruleLevel5, position97)
 			}
 			return true
-		l61:
-			position, tokenIndex, depth = position61, tokenIndex61, depth61
+		l96:
+			position, tokenIndex, depth = position96, tokenIndex96, depth96
 			return false
 		},
-		/* 17 Concatenation <- <(req_ws Level4)> */
+		/* 21 Concatenation <- <(req_ws Level4)> */
 		func() bool {
-			position65, tokenIndex65, depth65 := position, tokenIndex, depth
+			position100, tokenIndex100, depth100 := position, tokenIndex, depth
 			{
-				position66 := position
+				position101 := position
 				depth++
 				if !_rules[rulereq_ws]() {
-					goto l65
+					goto l100
 				}
 				if !_rules[ruleLevel4]() {
-					goto l65
+					goto l100
 				}
 				depth--
-				add(ruleConcatenation, position66)
+				add(ruleConcatenation, position101)
 			}
 			return true
-		l65:
-			position, tokenIndex, depth = position65, tokenIndex65, depth65
+		l100:
+			position, tokenIndex, depth = position100, tokenIndex100, depth100
 			return false
 		},
-		/* 18 Level4 <- <(Level3 (req_ws (LogOr / LogAnd))*)> */
+		/* 22 Level4 <- <(Level3 (req_ws (LogOr / LogAnd))*)> */
 		func() bool {
-			position67, tokenIndex67, depth67 := position, tokenIndex, depth
+			position102, tokenIndex102, depth102 := position, tokenIndex, depth
 			{
-				position68 := position
+				position103 := position
 				depth++
 				if !_rules[ruleLevel3]() {
-					goto l67
+					goto l102
 				}
-			l69:
+			l104:
 				{
-					position70, tokenIndex70, depth70 := position, tokenIndex, depth
+					position105, tokenIndex105, depth105 := position, tokenIndex, depth
 					if !_rules[rulereq_ws]() {
-						goto l70
+						goto l105
 					}
 					{
-						position71, tokenIndex71, depth71 := position, tokenIndex, depth
+						position106, tokenIndex106, depth106 := position, tokenIndex, depth
 						if !_rules[ruleLogOr]() {
-							goto l72
+							goto l107
 						}
-						goto l71
-					l72:
-						position, tokenIndex, depth = position71, tokenIndex71, depth71
+						goto l106
+					l107:
+						position, tokenIndex, depth = position106, tokenIndex106, depth106
 						if !_rules[ruleLogAnd]() {
-							goto l70
+							goto l105
 						}
 					}
-				l71:
-					goto l69
-				l70:
-					position, tokenIndex, depth = position70, tokenIndex70, depth70
+				l106:
+					goto l104
+				l105:
+					position, tokenIndex, depth = position105, tokenIndex105, depth105
 				}
 				depth--
-				add(ruleLevel4, position68)
+				add(ruleLevel4, position103)
 			}
 			return true
-		l67:
-			position, tokenIndex, depth = position67, tokenIndex67, depth67
+		l102:
+			position, tokenIndex, depth = position102, tokenIndex102, depth102
 			return false
 		},
-		/* 19 LogOr <- <('-' 'o' 'r' req_ws Level3)> */
+		/* 23 LogOr <- <('-' 'o' 'r' req_ws Level3)> */
 		func() bool {
-			position73, tokenIndex73, depth73 := position, tokenIndex, depth
+			position108, tokenIndex108, depth108 := position, tokenIndex, depth
 			{
-				position74 := position
+				position109 := position
 				depth++
 				if buffer[position] != rune('-') {
-					goto l73
+					goto l108
 				}
 				position++
 				if buffer[position] != rune('o') {
-					goto l73
+					goto l108
 				}
 				position++
 				if buffer[position] != rune('r') {
-					goto l73
+					goto l108
 				}
 				position++
 				if !_rules[rulereq_ws]() {
-					goto l73
+					goto l108
 				}
 				if !_rules[ruleLevel3]() {
-					goto l73
+					goto l108
 				}
 				depth--
-				add(ruleLogOr, position74)
+				add(ruleLogOr, position109)
 			}
 			return true
-		l73:
-			position, tokenIndex, depth = position73, tokenIndex73, depth73
+		l108:
+			position, tokenIndex, depth = position108, tokenIndex108, depth108
 			return false
 		},
-		/* 20 LogAnd <- <('-' 'a' 'n' 'd' req_ws Level3)> */
+		/* 24 LogAnd <- <('-' 'a' 'n' 'd' req_ws Level3)> */
 		func() bool {
-			position75, tokenIndex75, depth75 := position, tokenIndex, depth
+			position110, tokenIndex110, depth110 := position, tokenIndex, depth
 			{
-				position76 := position
+				position111 := position
 				depth++
 				if buffer[position] != rune('-') {
-					goto l75
+					goto l110
 				}
 				position++
 				if buffer[position] != rune('a') {
-					goto l75
+					goto l110
 				}
 				position++
 				if buffer[position] != rune('n') {
-					goto l75
+					goto l110
 				}
 				position++
 				if buffer[position] != rune('d') {
-					goto l75
+					goto l110
 				}
 				position++
 				if !_rules[rulereq_ws]() {
-					goto l75
+					goto l110
 				}
 				if !_rules[ruleLevel3]() {
-					goto l75
+					goto l110
 				}
 				depth--
-				add(ruleLogAnd, position76)
+				add(ruleLogAnd, position111)
 			}
 			return true
-		l75:
-			position, tokenIndex, depth = position75, tokenIndex75, depth75
+		l110:
+			position, tokenIndex, depth = position110, tokenIndex110, depth110
 			return false
 		},
-		/* 21 Level3 <- <(Level2 (req_ws Comparison)*)> */
+		/* 25 Level3 <- <(Level2 (req_ws Comparison)*)> */
 		func() bool {
-			position77, tokenIndex77, depth77 := position, tokenIndex, depth
+			position112, tokenIndex112, depth112 := position, tokenIndex, depth
 			{
-				position78 := position
+				position113 := position
 				depth++
 				if !_rules[ruleLevel2]() {
-					goto l77
+					goto l112
 				}
-			l79:
+			l114:
 				{
-					position80, tokenIndex80, depth80 := position, tokenIndex, depth
+					position115, tokenIndex115, depth115 := position, tokenIndex, depth
 					if !_rules[rulereq_ws]() {
-						goto l80
+						goto l115
 					}
 					if !_rules[ruleComparison]() {
-						goto l80
+						goto l115
 					}
-					goto l79
-				l80:
-					position, tokenIndex, depth = position80, tokenIndex80, depth80
+					goto l114
+				l115:
+					position, tokenIndex, depth = position115, tokenIndex115, depth115
 				}
 				depth--
-				add(ruleLevel3, position78)
+				add(ruleLevel3, position113)
 			}
 			return true
-		l77:
-			position, tokenIndex, depth = position77, tokenIndex77, depth77
+		l112:
+			position, tokenIndex, depth = position112, tokenIndex112, depth112
 			return false
 		},
-		/* 22 Comparison <- <(CompareOp req_ws Level2)> */
+		/* 26 Comparison <- <(CompareOp req_ws Level2)> */
 		func() bool {
-			position81, tokenIndex81, depth81 := position, tokenIndex, depth
+			position116, tokenIndex116, depth116 := position, tokenIndex, depth
 			{
-				position82 := position
+				position117 := position
 				depth++
 				if !_rules[ruleCompareOp]() {
-					goto l81
+					goto l116
 				}
 				if !_rules[rulereq_ws]() {
-					goto l81
+					goto l116
 				}
 				if !_rules[ruleLevel2]() {
-					goto l81
+					goto l116
 				}
 				depth--
-				add(ruleComparison, position82)
+				add(ruleComparison, position117)
 			}
 			return true
-		l81:
-			position, tokenIndex, depth = position81, tokenIndex81, depth81
+		l116:
+			position, tokenIndex, depth = position116, tokenIndex116, depth116
 			return false
 		},
-		/* 23 CompareOp <- <(('=' '=') / ('!' '=') / ('<' '=') / ('>' '=') / '>' / '<' / '>')> */
+		/* 27 CompareOp <- <(('=' '=') / ('!' '=') / ('<' '=') / ('>' '=') / '>' / '<' / '>')> */
 		func() bool {
-			position83, tokenIndex83, depth83 := position, tokenIndex, depth
+			position118, tokenIndex118, depth118 := position, tokenIndex, depth
 			{
-				position84 := position
+				position119 := position
 				depth++
 				{
-					position85, tokenIndex85, depth85 := position, tokenIndex, depth
+					position120, tokenIndex120, depth120 := position, tokenIndex, depth
 					if buffer[position] != rune('=') {
-						goto l86
+						goto l121
 					}
 					position++
 					if buffer[position] != rune('=') {
-						goto l86
+						goto l121
 					}
 					position++
-					goto l85
-				l86:
-					position, tokenIndex, depth = position85, tokenIndex85, depth85
+					goto l120
+				l121:
+					position, tokenIndex, depth = position120, tokenIndex120, depth120
 					if buffer[position] != rune('!') {
-						goto l87
+						goto l122
 					}
 					position++
 					if buffer[position] != rune('=') {
-						goto l87
+						goto l122
 					}
 					position++
-					goto l85
-				l87:
-					position, tokenIndex, depth = position85, tokenIndex85, depth85
+					goto l120
+				l122:
+					position, tokenIndex, depth = position120, tokenIndex120, depth120
 					if buffer[position] != rune('<') {
-						goto l88
+						goto l123
 					}
 					position++
 					if buffer[position] != rune('=') {
-						goto l88
+						goto l123
 					}
 					position++
-					goto l85
-				l88:
-					position, tokenIndex, depth = position85, tokenIndex85, depth85
+					goto l120
+				l123:
+					position, tokenIndex, depth = position120, tokenIndex120, depth120
 					if buffer[position] != rune('>') {
-						goto l89
+						goto l124
 					}
 					position++
 					if buffer[position] != rune('=') {
-						goto l89
+						goto l124
 					}
 					position++
-					goto l85
-				l89:
-					position, tokenIndex, depth = position85, tokenIndex85, depth85
+					goto l120
+				l124:
+					position, tokenIndex, depth = position120, tokenIndex120, depth120
 					if buffer[position] != rune('>') {
-						goto l90
+						goto l125
 					}
 					position++
-					goto l85
-				l90:
-					position, tokenIndex, depth = position85, tokenIndex85, depth85
+					goto l120
+				l125:
+					position, tokenIndex, depth = position120, tokenIndex120, depth120
 					if buffer[position] != rune('<') {
-						goto l91
+						goto l126
 					}
 					position++
-					goto l85
-				l91:
-					position, tokenIndex, depth = position85, tokenIndex85, depth85
+					goto l120
+				l126:
+					position, tokenIndex, depth = position120, tokenIndex120, depth120
 					if buffer[position] != rune('>') {
-						goto l83
+						goto l118
 					}
 					position++
 				}
-			l85:
+			l120:
 				depth--
-				add(ruleCompareOp, position84)
+				add(ruleCompareOp, position119)
 			}
 			return true
-		l83:
-			position, tokenIndex, depth = position83, tokenIndex83, depth83
+		l118:
+			position, tokenIndex, depth = position118, tokenIndex118, depth118
 			return false
 		},
-		/* 24 Level2 <- <(Level1 (req_ws (Addition / Subtraction))*)> */
+		/* 28 Level2 <- <(Level1 (req_ws (Addition / Subtraction))*)> */
 		func() bool {
-			position92, tokenIndex92, depth92 := position, tokenIndex, depth
+			position127, tokenIndex127, depth127 := position, tokenIndex, depth
 			{
-				position93 := position
+				position128 := position
 				depth++
 				if !_rules[ruleLevel1]() {
-					goto l92
+					goto l127
 				}
-			l94:
+			l129:
 				{
-					position95, tokenIndex95, depth95 := position, tokenIndex, depth
+					position130, tokenIndex130, depth130 := position, tokenIndex, depth
 					if !_rules[rulereq_ws]() {
-						goto l95
+						goto l130
 					}
 					{
-						position96, tokenIndex96, depth96 := position, tokenIndex, depth
+						position131, tokenIndex131, depth131 := position, tokenIndex, depth
 						if !_rules[ruleAddition]() {
-							goto l97
+							goto l132
 						}
-						goto l96
-					l97:
-						position, tokenIndex, depth = position96, tokenIndex96, depth96
+						goto l131
+					l132:
+						position, tokenIndex, depth = position131, tokenIndex131, depth131
 						if !_rules[ruleSubtraction]() {
-							goto l95
+							goto l130
 						}
 					}
-				l96:
-					goto l94
-				l95:
-					position, tokenIndex, depth = position95, tokenIndex95, depth95
+				l131:
+					goto l129
+				l130:
+					position, tokenIndex, depth = position130, tokenIndex130, depth130
 				}
 				depth--
-				add(ruleLevel2, position93)
+				add(ruleLevel2, position128)
 			}
 			return true
-		l92:
-			position, tokenIndex, depth = position92, tokenIndex92, depth92
+		l127:
+			position, tokenIndex, depth = position127, tokenIndex127, depth127
 			return false
 		},
-		/* 25 Addition <- <('+' req_ws Level1)> */
+		/* 29 Addition <- <('+' req_ws Level1)> */
 		func() bool {
-			position98, tokenIndex98, depth98 := position, tokenIndex, depth
+			position133, tokenIndex133, depth133 := position, tokenIndex, depth
 			{
-				position99 := position
+				position134 := position
 				depth++
 				if buffer[position] != rune('+') {
-					goto l98
+					goto l133
 				}
 				position++
 				if !_rules[rulereq_ws]() {
-					goto l98
+					goto l133
 				}
 				if !_rules[ruleLevel1]() {
-					goto l98
+					goto l133
 				}
 				depth--
-				add(ruleAddition, position99)
+				add(ruleAddition, position134)
 			}
 			return true
-		l98:
-			position, tokenIndex, depth = position98, tokenIndex98, depth98
+		l133:
+			position, tokenIndex, depth = position133, tokenIndex133, depth133
 			return false
 		},
-		/* 26 Subtraction <- <('-' req_ws Level1)> */
+		/* 30 Subtraction <- <('-' req_ws Level1)> */
 		func() bool {
-			position100, tokenIndex100, depth100 := position, tokenIndex, depth
+			position135, tokenIndex135, depth135 := position, tokenIndex, depth
 			{
-				position101 := position
+				position136 := position
 				depth++
 				if buffer[position] != rune('-') {
-					goto l100
+					goto l135
 				}
 				position++
 				if !_rules[rulereq_ws]() {
-					goto l100
+					goto l135
 				}
 				if !_rules[ruleLevel1]() {
-					goto l100
+					goto l135
 				}
 				depth--
-				add(ruleSubtraction, position101)
+				add(ruleSubtraction, position136)
 			}
 			return true
-		l100:
-			position, tokenIndex, depth = position100, tokenIndex100, depth100
+		l135:
+			position, tokenIndex, depth = position135, tokenIndex135, depth135
 			return false
 		},
-		/* 27 Level1 <- <(Level0 (req_ws (Multiplication / Division / Modulo))*)> */
+		/* 31 Level1 <- <(Level0 (req_ws (Multiplication / Division / Modulo))*)> */
 		func() bool {
-			position102, tokenIndex102, depth102 := position, tokenIndex, depth
+			position137, tokenIndex137, depth137 := position, tokenIndex, depth
 			{
-				position103 := position
+				position138 := position
 				depth++
 				if !_rules[ruleLevel0]() {
-					goto l102
+					goto l137
 				}
-			l104:
+			l139:
 				{
-					position105, tokenIndex105, depth105 := position, tokenIndex, depth
+					position140, tokenIndex140, depth140 := position, tokenIndex, depth
 					if !_rules[rulereq_ws]() {
-						goto l105
+						goto l140
 					}
 					{
-						position106, tokenIndex106, depth106 := position, tokenIndex, depth
+						position141, tokenIndex141, depth141 := position, tokenIndex, depth
 						if !_rules[ruleMultiplication]() {
-							goto l107
+							goto l142
 						}
-						goto l106
-					l107:
-						position, tokenIndex, depth = position106, tokenIndex106, depth106
+						goto l141
+					l142:
+						position, tokenIndex, depth = position141, tokenIndex141, depth141
 						if !_rules[ruleDivision]() {
-							goto l108
+							goto l143
 						}
-						goto l106
-					l108:
-						position, tokenIndex, depth = position106, tokenIndex106, depth106
+						goto l141
+					l143:
+						position, tokenIndex, depth = position141, tokenIndex141, depth141
 						if !_rules[ruleModulo]() {
-							goto l105
+							goto l140
 						}
 					}
-				l106:
-					goto l104
-				l105:
-					position, tokenIndex, depth = position105, tokenIndex105, depth105
+				l141:
+					goto l139
+				l140:
+					position, tokenIndex, depth = position140, tokenIndex140, depth140
 				}
 				depth--
-				add(ruleLevel1, position103)
+				add(ruleLevel1, position138)
 			}
 			return true
-		l102:
-			position, tokenIndex, depth = position102, tokenIndex102, depth102
+		l137:
+			position, tokenIndex, depth = position137, tokenIndex137, depth137
 			return false
 		},
-		/* 28 Multiplication <- <('*' req_ws Level0)> */
+		/* 32 Multiplication <- <('*' req_ws Level0)> */
 		func() bool {
-			position109, tokenIndex109, depth109 := position, tokenIndex, depth
+			position144, tokenIndex144, depth144 := position, tokenIndex, depth
 			{
-				position110 := position
+				position145 := position
 				depth++
 				if buffer[position] != rune('*') {
-					goto l109
+					goto l144
 				}
 				position++
 				if !_rules[rulereq_ws]() {
-					goto l109
+					goto l144
 				}
 				if !_rules[ruleLevel0]() {
-					goto l109
+					goto l144
 				}
 				depth--
-				add(ruleMultiplication, position110)
+				add(ruleMultiplication, position145)
 			}
 			return true
-		l109:
-			position, tokenIndex, depth = position109, tokenIndex109, depth109
+		l144:
+			position, tokenIndex, depth = position144, tokenIndex144, depth144
 			return false
 		},
-		/* 29 Division <- <('/' req_ws Level0)> */
+		/* 33 Division <- <('/' req_ws Level0)> */
 		func() bool {
-			position111, tokenIndex111, depth111 := position, tokenIndex, depth
+			position146, tokenIndex146, depth146 := position, tokenIndex, depth
 			{
-				position112 := position
+				position147 := position
 				depth++
 				if buffer[position] != rune('/') {
-					goto l111
+					goto l146
 				}
 				position++
 				if !_rules[rulereq_ws]() {
-					goto l111
+					goto l146
 				}
 				if !_rules[ruleLevel0]() {
-					goto l111
+					goto l146
 				}
 				depth--
-				add(ruleDivision, position112)
+				add(ruleDivision, position147)
 			}
 			return true
-		l111:
-			position, tokenIndex, depth = position111, tokenIndex111, depth111
+		l146:
+			position, tokenIndex, depth = position146, tokenIndex146, depth146
 			return false
 		},
-		/* 30 Modulo <- <('%' req_ws Level0)> */
+		/* 34 Modulo <- <('%' req_ws Level0)> */
 		func() bool {
-			position113, tokenIndex113, depth113 := position, tokenIndex, depth
+			position148, tokenIndex148, depth148 := position, tokenIndex, depth
 			{
-				position114 := position
+				position149 := position
 				depth++
 				if buffer[position] != rune('%') {
-					goto l113
+					goto l148
 				}
 				position++
 				if !_rules[rulereq_ws]() {
-					goto l113
+					goto l148
 				}
 				if !_rules[ruleLevel0]() {
-					goto l113
+					goto l148
 				}
 				depth--
-				add(ruleModulo, position114)
+				add(ruleModulo, position149)
 			}
 			return true
-		l113:
-			position, tokenIndex, depth = position113, tokenIndex113, depth113
+		l148:
+			position, tokenIndex, depth = position148, tokenIndex148, depth148
 			return false
 		},
-		/* 31 Level0 <- <(IP / String / Number / Boolean / Undefined / Nil / Symbol / Not / Substitution / Merge / Auto / Lambda / Chained)> */
+		/* 35 Level0 <- <(IP / String / Number / Boolean / Undefined / Nil / Symbol / Not / Substitution / Merge / Auto / Lambda / Chained)> */
 		func() bool {
-			position115, tokenIndex115, depth115 := position, tokenIndex, depth
+			position150, tokenIndex150, depth150 := position, tokenIndex, depth
 			{
-				position116 := position
+				position151 := position
 				depth++
 				{
-					position117, tokenIndex117, depth117 := position, tokenIndex, depth
+					position152, tokenIndex152, depth152 := position, tokenIndex, depth
 					if !_rules[ruleIP]() {
-						goto l118
+						goto l153
 					}
-					goto l117
-				l118:
-					position, tokenIndex, depth = position117, tokenIndex117, depth117
+					goto l152
+				l153:
+					position, tokenIndex, depth = position152, tokenIndex152, depth152
 					if !_rules[ruleString]() {
-						goto l119
+						goto l154
 					}
-					goto l117
-				l119:
-					position, tokenIndex, depth = position117, tokenIndex117, depth117
+					goto l152
+				l154:
+					position, tokenIndex, depth = position152, tokenIndex152, depth152
 					if !_rules[ruleNumber]() {
-						goto l120
+						goto l155
 					}
-					goto l117
-				l120:
-					position, tokenIndex, depth = position117, tokenIndex117, depth117
+					goto l152
+				l155:
+					position, tokenIndex, depth = position152, tokenIndex152, depth152
 					if !_rules[ruleBoolean]() {
-						goto l121
+						goto l156
 					}
-					goto l117
-				l121:
-					position, tokenIndex, depth = position117, tokenIndex117, depth117
+					goto l152
+				l156:
+					position, tokenIndex, depth = position152, tokenIndex152, depth152
 					if !_rules[ruleUndefined]() {
-						goto l122
+						goto l157
 					}
-					goto l117
-				l122:
-					position, tokenIndex, depth = position117, tokenIndex117, depth117
+					goto l152
+				l157:
+					position, tokenIndex, depth = position152, tokenIndex152, depth152
 					if !_rules[ruleNil]() {
-						goto l123
+						goto l158
 					}
-					goto l117
-				l123:
-					position, tokenIndex, depth = position117, tokenIndex117, depth117
+					goto l152
+				l158:
+					position, tokenIndex, depth = position152, tokenIndex152, depth152
 					if !_rules[ruleSymbol]() {
-						goto l124
+						goto l159
 					}
-					goto l117
-				l124:
-					position, tokenIndex, depth = position117, tokenIndex117, depth117
+					goto l152
+				l159:
+					position, tokenIndex, depth = position152, tokenIndex152, depth152
 					if !_rules[ruleNot]() {
-						goto l125
+						goto l160
 					}
-					goto l117
-				l125:
-					position, tokenIndex, depth = position117, tokenIndex117, depth117
+					goto l152
+				l160:
+					position, tokenIndex, depth = position152, tokenIndex152, depth152
 					if !_rules[ruleSubstitution]() {
-						goto l126
+						goto l161
 					}
-					goto l117
-				l126:
-					position, tokenIndex, depth = position117, tokenIndex117, depth117
+					goto l152
+				l161:
+					position, tokenIndex, depth = position152, tokenIndex152, depth152
 					if !_rules[ruleMerge]() {
-						goto l127
+						goto l162
 					}
-					goto l117
-				l127:
-					position, tokenIndex, depth = position117, tokenIndex117, depth117
+					goto l152
+				l162:
+					position, tokenIndex, depth = position152, tokenIndex152, depth152
 					if !_rules[ruleAuto]() {
-						goto l128
+						goto l163
 					}
-					goto l117
-				l128:
-					position, tokenIndex, depth = position117, tokenIndex117, depth117
+					goto l152
+				l163:
+					position, tokenIndex, depth = position152, tokenIndex152, depth152
 					if !_rules[ruleLambda]() {
-						goto l129
+						goto l164
 					}
-					goto l117
-				l129:
-					position, tokenIndex, depth = position117, tokenIndex117, depth117
+					goto l152
+				l164:
+					position, tokenIndex, depth = position152, tokenIndex152, depth152
 					if !_rules[ruleChained]() {
-						goto l115
+						goto l150
 					}
 				}
-			l117:
+			l152:
 				depth--
-				add(ruleLevel0, position116)
+				add(ruleLevel0, position151)
 			}
 			return true
-		l115:
-			position, tokenIndex, depth = position115, tokenIndex115, depth115
+		l150:
+			position, tokenIndex, depth = position150, tokenIndex150, depth150
 			return false
 		},
-		/* 32 Chained <- <((MapMapping / Sync / Catch / Mapping / FilterList / FilterMap / MapSelection / Selection / Sum / List / Map / Range / Grouped / Reference / TopIndex) ChainedQualifiedExpression*)> */
+		/* 36 Chained <- <((MapMapping / Sync / Catch / Mapping / FilterList / FilterMap / MapSelection / Selection / Sum / List / Map / Range / Grouped / Reference / TopIndex) ChainedQualifiedExpression*)> */
 		func() bool {
-			position130, tokenIndex130, depth130 := position, tokenIndex, depth
+			position165, tokenIndex165, depth165 := position, tokenIndex, depth
 			{
-				position131 := position
+				position166 := position
 				depth++
 				{
-					position132, tokenIndex132, depth132 := position, tokenIndex, depth
+					position167, tokenIndex167, depth167 := position, tokenIndex, depth
 					if !_rules[ruleMapMapping]() {
-						goto l133
+						goto l168
 					}
-					goto l132
-				l133:
-					position, tokenIndex, depth = position132, tokenIndex132, depth132
+					goto l167
+				l168:
+					position, tokenIndex, depth = position167, tokenIndex167, depth167
 					if !_rules[ruleSync]() {
-						goto l134
+						goto l169
 					}
-					goto l132
-				l134:
-					position, tokenIndex, depth = position132, tokenIndex132, depth132
+					goto l167
+				l169:
+					position, tokenIndex, depth = position167, tokenIndex167, depth167
 					if !_rules[ruleCatch]() {
-						goto l135
+						goto l170
 					}
-					goto l132
-				l135:
-					position, tokenIndex, depth = position132, tokenIndex132, depth132
+					goto l167
+				l170:
+					position, tokenIndex, depth = position167, tokenIndex167, depth167
 					if !_rules[ruleMapping]() {
-						goto l136
+						goto l171
 					}
-					goto l132
-				l136:
-					position, tokenIndex, depth = position132, tokenIndex132, depth132
+					goto l167
+				l171:
+					position, tokenIndex, depth = position167, tokenIndex167, depth167
 					if !_rules[ruleFilterList]() {
-						goto l137
+						goto l172
 					}
-					goto l132
-				l137:
-					position, tokenIndex, depth = position132, tokenIndex132, depth132
+					goto l167
+				l172:
+					position, tokenIndex, depth = position167, tokenIndex167, depth167
 					if !_rules[ruleFilterMap]() {
-						goto l138
+						goto l173
 					}
-					goto l132
-				l138:
-					position, tokenIndex, depth = position132, tokenIndex132, depth132
+					goto l167
+				l173:
+					position, tokenIndex, depth = position167, tokenIndex167, depth167
 					if !_rules[ruleMapSelection]() {
-						goto l139
+						goto l174
 					}
-					goto l132
-				l139:
-					position, tokenIndex, depth = position132, tokenIndex132, depth132
+					goto l167
+				l174:
+					position, tokenIndex, depth = position167, tokenIndex167, depth167
 					if !_rules[ruleSelection]() {
-						goto l140
+						goto l175
 					}
-					goto l132
-				l140:
-					position, tokenIndex, depth = position132, tokenIndex132, depth132
+					goto l167
+				l175:
+					position, tokenIndex, depth = position167, tokenIndex167, depth167
 					if !_rules[ruleSum]() {
-						goto l141
+						goto l176
 					}
-					goto l132
-				l141:
-					position, tokenIndex, depth = position132, tokenIndex132, depth132
+					goto l167
+				l176:
+					position, tokenIndex, depth = position167, tokenIndex167, depth167
 					if !_rules[ruleList]() {
-						goto l142
+						goto l177
 					}
-					goto l132
-				l142:
-					position, tokenIndex, depth = position132, tokenIndex132, depth132
+					goto l167
+				l177:
+					position, tokenIndex, depth = position167, tokenIndex167, depth167
 					if !_rules[ruleMap]() {
-						goto l143
+						goto l178
 					}
-					goto l132
-				l143:
-					position, tokenIndex, depth = position132, tokenIndex132, depth132
+					goto l167
+				l178:
+					position, tokenIndex, depth = position167, tokenIndex167, depth167
 					if !_rules[ruleRange]() {
-						goto l144
+						goto l179
 					}
-					goto l132
-				l144:
-					position, tokenIndex, depth = position132, tokenIndex132, depth132
+					goto l167
+				l179:
+					position, tokenIndex, depth = position167, tokenIndex167, depth167
 					if !_rules[ruleGrouped]() {
-						goto l145
+						goto l180
 					}
-					goto l132
-				l145:
-					position, tokenIndex, depth = position132, tokenIndex132, depth132
+					goto l167
+				l180:
+					position, tokenIndex, depth = position167, tokenIndex167, depth167
 					if !_rules[ruleReference]() {
-						goto l146
+						goto l181
 					}
-					goto l132
-				l146:
-					position, tokenIndex, depth = position132, tokenIndex132, depth132
+					goto l167
+				l181:
+					position, tokenIndex, depth = position167, tokenIndex167, depth167
 					if !_rules[ruleTopIndex]() {
-						goto l130
+						goto l165
 					}
 				}
-			l132:
-			l147:
+			l167:
+			l182:
 				{
-					position148, tokenIndex148, depth148 := position, tokenIndex, depth
+					position183, tokenIndex183, depth183 := position, tokenIndex, depth
 					if !_rules[ruleChainedQualifiedExpression]() {
-						goto l148
+						goto l183
 					}
-					goto l147
-				l148:
-					position, tokenIndex, depth = position148, tokenIndex148, depth148
+					goto l182
+				l183:
+					position, tokenIndex, depth = position183, tokenIndex183, depth183
 				}
 				depth--
-				add(ruleChained, position131)
+				add(ruleChained, position166)
 			}
 			return true
-		l130:
-			position, tokenIndex, depth = position130, tokenIndex130, depth130
+		l165:
+			position, tokenIndex, depth = position165, tokenIndex165, depth165
 			return false
 		},
-		/* 33 ChainedQualifiedExpression <- <(ChainedCall / Currying / ChainedRef / ChainedDynRef / Projection)> */
+		/* 37 ChainedQualifiedExpression <- <(ChainedCall / Currying / ChainedRef / ChainedDynRef / Projection)> */
 		func() bool {
-			position149, tokenIndex149, depth149 := position, tokenIndex, depth
+			position184, tokenIndex184, depth184 := position, tokenIndex, depth
 			{
-				position150 := position
+				position185 := position
 				depth++
 				{
-					position151, tokenIndex151, depth151 := position, tokenIndex, depth
+					position186, tokenIndex186, depth186 := position, tokenIndex, depth
 					if !_rules[ruleChainedCall]() {
-						goto l152
+						goto l187
 					}
-					goto l151
-				l152:
-					position, tokenIndex, depth = position151, tokenIndex151, depth151
+					goto l186
+				l187:
+					position, tokenIndex, depth = position186, tokenIndex186, depth186
 					if !_rules[ruleCurrying]() {
-						goto l153
+						goto l188
 					}
-					goto l151
-				l153:
-					position, tokenIndex, depth = position151, tokenIndex151, depth151
+					goto l186
+				l188:
+					position, tokenIndex, depth = position186, tokenIndex186, depth186
 					if !_rules[ruleChainedRef]() {
-						goto l154
+						goto l189
 					}
-					goto l151
-				l154:
-					position, tokenIndex, depth = position151, tokenIndex151, depth151
+					goto l186
+				l189:
+					position, tokenIndex, depth = position186, tokenIndex186, depth186
 					if !_rules[ruleChainedDynRef]() {
-						goto l155
+						goto l190
 					}
-					goto l151
-				l155:
-					position, tokenIndex, depth = position151, tokenIndex151, depth151
+					goto l186
+				l190:
+					position, tokenIndex, depth = position186, tokenIndex186, depth186
 					if !_rules[ruleProjection]() {
-						goto l149
+						goto l184
 					}
 				}
-			l151:
+			l186:
 				depth--
-				add(ruleChainedQualifiedExpression, position150)
+				add(ruleChainedQualifiedExpression, position185)
 			}
 			return true
-		l149:
-			position, tokenIndex, depth = position149, tokenIndex149, depth149
+		l184:
+			position, tokenIndex, depth = position184, tokenIndex184, depth184
 			return false
 		},
-		/* 34 ChainedRef <- <(PathComponent FollowUpRef)> */
+		/* 38 ChainedRef <- <(PathComponent FollowUpRef)> */
 		func() bool {
-			position156, tokenIndex156, depth156 := position, tokenIndex, depth
+			position191, tokenIndex191, depth191 := position, tokenIndex, depth
 			{
-				position157 := position
+				position192 := position
 				depth++
 				if !_rules[rulePathComponent]() {
-					goto l156
+					goto l191
 				}
 				if !_rules[ruleFollowUpRef]() {
-					goto l156
+					goto l191
 				}
 				depth--
-				add(ruleChainedRef, position157)
+				add(ruleChainedRef, position192)
 			}
 			return true
-		l156:
-			position, tokenIndex, depth = position156, tokenIndex156, depth156
+		l191:
+			position, tokenIndex, depth = position191, tokenIndex191, depth191
 			return false
 		},
-		/* 35 ChainedDynRef <- <('.'? Indices)> */
+		/* 39 ChainedDynRef <- <('.'? Indices)> */
 		func() bool {
-			position158, tokenIndex158, depth158 := position, tokenIndex, depth
+			position193, tokenIndex193, depth193 := position, tokenIndex, depth
 			{
-				position159 := position
+				position194 := position
 				depth++
 				{
-					position160, tokenIndex160, depth160 := position, tokenIndex, depth
+					position195, tokenIndex195, depth195 := position, tokenIndex, depth
 					if buffer[position] != rune('.') {
-						goto l160
+						goto l195
 					}
 					position++
-					goto l161
-				l160:
-					position, tokenIndex, depth = position160, tokenIndex160, depth160
+					goto l196
+				l195:
+					position, tokenIndex, depth = position195, tokenIndex195, depth195
 				}
-			l161:
+			l196:
 				if !_rules[ruleIndices]() {
-					goto l158
+					goto l193
 				}
 				depth--
-				add(ruleChainedDynRef, position159)
+				add(ruleChainedDynRef, position194)
 			}
 			return true
-		l158:
-			position, tokenIndex, depth = position158, tokenIndex158, depth158
+		l193:
+			position, tokenIndex, depth = position193, tokenIndex193, depth193
 			return false
 		},
-		/* 36 TopIndex <- <('.' Indices)> */
+		/* 40 TopIndex <- <('.' Indices)> */
 		func() bool {
-			position162, tokenIndex162, depth162 := position, tokenIndex, depth
+			position197, tokenIndex197, depth197 := position, tokenIndex, depth
 			{
-				position163 := position
+				position198 := position
 				depth++
 				if buffer[position] != rune('.') {
-					goto l162
+					goto l197
 				}
 				position++
 				if !_rules[ruleIndices]() {
-					goto l162
+					goto l197
 				}
 				depth--
-				add(ruleTopIndex, position163)
+				add(ruleTopIndex, position198)
 			}
 			return true
-		l162:
-			position, tokenIndex, depth = position162, tokenIndex162, depth162
+		l197:
+			position, tokenIndex, depth = position197, tokenIndex197, depth197
 			return false
 		},
-		/* 37 Indices <- <(StartList ExpressionList ']')> */
+		/* 41 Indices <- <(StartList ExpressionList ']')> */
 		func() bool {
-			position164, tokenIndex164, depth164 := position, tokenIndex, depth
+			position199, tokenIndex199, depth199 := position, tokenIndex, depth
 			{
-				position165 := position
+				position200 := position
 				depth++
 				if !_rules[ruleStartList]() {
-					goto l164
+					goto l199
 				}
 				if !_rules[ruleExpressionList]() {
-					goto l164
+					goto l199
 				}
 				if buffer[position] != rune(']') {
-					goto l164
+					goto l199
 				}
 				position++
 				depth--
-				add(ruleIndices, position165)
+				add(ruleIndices, position200)
 			}
 			return true
-		l164:
-			position, tokenIndex, depth = position164, tokenIndex164, depth164
+		l199:
+			position, tokenIndex, depth = position199, tokenIndex199, depth199
 			return false
 		},
-		/* 38 Slice <- <Range> */
+		/* 42 Slice <- <Range> */
 		func() bool {
-			position166, tokenIndex166, depth166 := position, tokenIndex, depth
+			position201, tokenIndex201, depth201 := position, tokenIndex, depth
 			{
-				position167 := position
+				position202 := position
 				depth++
 				if !_rules[ruleRange]() {
-					goto l166
+					goto l201
 				}
 				depth--
-				add(ruleSlice, position167)
+				add(ruleSlice, position202)
 			}
 			return true
-		l166:
-			position, tokenIndex, depth = position166, tokenIndex166, depth166
+		l201:
+			position, tokenIndex, depth = position201, tokenIndex201, depth201
 			return false
 		},
-		/* 39 Currying <- <('*' ChainedCall)> */
+		/* 43 Currying <- <('*' ChainedCall)> */
 		func() bool {
-			position168, tokenIndex168, depth168 := position, tokenIndex, depth
+			position203, tokenIndex203, depth203 := position, tokenIndex, depth
 			{
-				position169 := position
+				position204 := position
 				depth++
 				if buffer[position] != rune('*') {
-					goto l168
+					goto l203
 				}
 				position++
 				if !_rules[ruleChainedCall]() {
-					goto l168
+					goto l203
 				}
 				depth--
-				add(ruleCurrying, position169)
+				add(ruleCurrying, position204)
 			}
 			return true
-		l168:
-			position, tokenIndex, depth = position168, tokenIndex168, depth168
+		l203:
+			position, tokenIndex, depth = position203, tokenIndex203, depth203
 			return false
 		},
-		/* 40 ChainedCall <- <(StartArguments NameArgumentList? ')')> */
+		/* 44 ChainedCall <- <(StartArguments NameArgumentList? ')')> */
 		func() bool {
-			position170, tokenIndex170, depth170 := position, tokenIndex, depth
+			position205, tokenIndex205, depth205 := position, tokenIndex, depth
 			{
-				position171 := position
+				position206 := position
 				depth++
 				if !_rules[ruleStartArguments]() {
-					goto l170
+					goto l205
 				}
 				{
-					position172, tokenIndex172, depth172 := position, tokenIndex, depth
+					position207, tokenIndex207, depth207 := position, tokenIndex, depth
 					if !_rules[ruleNameArgumentList]() {
-						goto l172
+						goto l207
 					}
-					goto l173
-				l172:
-					position, tokenIndex, depth = position172, tokenIndex172, depth172
+					goto l208
+				l207:
+					position, tokenIndex, depth = position207, tokenIndex207, depth207
 				}
-			l173:
+			l208:
 				if buffer[position] != rune(')') {
-					goto l170
+					goto l205
 				}
 				position++
 				depth--
-				add(ruleChainedCall, position171)
+				add(ruleChainedCall, position206)
 			}
 			return true
-		l170:
-			position, tokenIndex, depth = position170, tokenIndex170, depth170
+		l205:
+			position, tokenIndex, depth = position205, tokenIndex205, depth205
 			return false
 		},
-		/* 41 StartArguments <- <('(' ws)> */
+		/* 45 StartArguments <- <('(' ws)> */
 		func() bool {
-			position174, tokenIndex174, depth174 := position, tokenIndex, depth
+			position209, tokenIndex209, depth209 := position, tokenIndex, depth
 			{
-				position175 := position
+				position210 := position
 				depth++
 				if buffer[position] != rune('(') {
-					goto l174
+					goto l209
 				}
 				position++
 				if !_rules[rulews]() {
-					goto l174
+					goto l209
 				}
 				depth--
-				add(ruleStartArguments, position175)
+				add(ruleStartArguments, position210)
 			}
 			return true
-		l174:
-			position, tokenIndex, depth = position174, tokenIndex174, depth174
+		l209:
+			position, tokenIndex, depth = position209, tokenIndex209, depth209
 			return false
 		},
-		/* 42 NameArgumentList <- <(((NextNameArgument (',' NextNameArgument)*) / NextExpression) (',' NextExpression)*)> */
+		/* 46 NameArgumentList <- <(((NextNameArgument (',' NextNameArgument)*) / NextExpression) (',' NextExpression)*)> */
 		func() bool {
-			position176, tokenIndex176, depth176 := position, tokenIndex, depth
+			position211, tokenIndex211, depth211 := position, tokenIndex, depth
 			{
-				position177 := position
+				position212 := position
 				depth++
 				{
-					position178, tokenIndex178, depth178 := position, tokenIndex, depth
+					position213, tokenIndex213, depth213 := position, tokenIndex, depth
 					if !_rules[ruleNextNameArgument]() {
-						goto l179
+						goto l214
 					}
-				l180:
+				l215:
 					{
-						position181, tokenIndex181, depth181 := position, tokenIndex, depth
+						position216, tokenIndex216, depth216 := position, tokenIndex, depth
 						if buffer[position] != rune(',') {
-							goto l181
+							goto l216
 						}
 						position++
 						if !_rules[ruleNextNameArgument]() {
-							goto l181
+							goto l216
 						}
-						goto l180
-					l181:
-						position, tokenIndex, depth = position181, tokenIndex181, depth181
+						goto l215
+					l216:
+						position, tokenIndex, depth = position216, tokenIndex216, depth216
 					}
-					goto l178
-				l179:
-					position, tokenIndex, depth = position178, tokenIndex178, depth178
+					goto l213
+				l214:
+					position, tokenIndex, depth = position213, tokenIndex213, depth213
 					if !_rules[ruleNextExpression]() {
-						goto l176
+						goto l211
 					}
 				}
-			l178:
-			l182:
+			l213:
+			l217:
 				{
-					position183, tokenIndex183, depth183 := position, tokenIndex, depth
+					position218, tokenIndex218, depth218 := position, tokenIndex, depth
 					if buffer[position] != rune(',') {
-						goto l183
+						goto l218
 					}
 					position++
 					if !_rules[ruleNextExpression]() {
-						goto l183
+						goto l218
 					}
-					goto l182
-				l183:
-					position, tokenIndex, depth = position183, tokenIndex183, depth183
+					goto l217
+				l218:
+					position, tokenIndex, depth = position218, tokenIndex218, depth218
 				}
 				depth--
-				add(ruleNameArgumentList, position177)
+				add(ruleNameArgumentList, position212)
 			}
 			return true
-		l176:
-			position, tokenIndex, depth = position176, tokenIndex176, depth176
+		l211:
+			position, tokenIndex, depth = position211, tokenIndex211, depth211
 			return false
 		},
-		/* 43 NextNameArgument <- <(ws Name ws '=' ws Expression ws)> */
+		/* 47 NextNameArgument <- <(ws Name ws '=' ws Expression ws)> */
 		func() bool {
-			position184, tokenIndex184, depth184 := position, tokenIndex, depth
+			position219, tokenIndex219, depth219 := position, tokenIndex, depth
 			{
-				position185 := position
+				position220 := position
 				depth++
 				if !_rules[rulews]() {
-					goto l184
+					goto l219
 				}
 				if !_rules[ruleName]() {
-					goto l184
+					goto l219
 				}
 				if !_rules[rulews]() {
-					goto l184
+					goto l219
 				}
 				if buffer[position] != rune('=') {
-					goto l184
+					goto l219
 				}
 				position++
 				if !_rules[rulews]() {
-					goto l184
+					goto l219
 				}
 				if !_rules[ruleExpression]() {
-					goto l184
+					goto l219
 				}
 				if !_rules[rulews]() {
-					goto l184
+					goto l219
 				}
 				depth--
-				add(ruleNextNameArgument, position185)
+				add(ruleNextNameArgument, position220)
 			}
 			return true
-		l184:
-			position, tokenIndex, depth = position184, tokenIndex184, depth184
+		l219:
+			position, tokenIndex, depth = position219, tokenIndex219, depth219
 			return false
 		},
-		/* 44 ExpressionList <- <(NextExpression (',' NextExpression)*)> */
+		/* 48 ExpressionList <- <(NextExpression (',' NextExpression)*)> */
 		func() bool {
-			position186, tokenIndex186, depth186 := position, tokenIndex, depth
+			position221, tokenIndex221, depth221 := position, tokenIndex, depth
 			{
-				position187 := position
+				position222 := position
 				depth++
 				if !_rules[ruleNextExpression]() {
-					goto l186
+					goto l221
 				}
-			l188:
+			l223:
 				{
-					position189, tokenIndex189, depth189 := position, tokenIndex, depth
+					position224, tokenIndex224, depth224 := position, tokenIndex, depth
 					if buffer[position] != rune(',') {
-						goto l189
+						goto l224
 					}
 					position++
 					if !_rules[ruleNextExpression]() {
-						goto l189
+						goto l224
 					}
-					goto l188
-				l189:
-					position, tokenIndex, depth = position189, tokenIndex189, depth189
+					goto l223
+				l224:
+					position, tokenIndex, depth = position224, tokenIndex224, depth224
 				}
 				depth--
-				add(ruleExpressionList, position187)
+				add(ruleExpressionList, position222)
 			}
 			return true
-		l186:
-			position, tokenIndex, depth = position186, tokenIndex186, depth186
+		l221:
+			position, tokenIndex, depth = position221, tokenIndex221, depth221
 			return false
 		},
-		/* 45 NextExpression <- <(Expression ListExpansion?)> */
+		/* 49 NextExpression <- <(Expression ListExpansion?)> */
 		func() bool {
-			position190, tokenIndex190, depth190 := position, tokenIndex, depth
+			position225, tokenIndex225, depth225 := position, tokenIndex, depth
 			{
-				position191 := position
+				position226 := position
 				depth++
 				if !_rules[ruleExpression]() {
-					goto l190
+					goto l225
 				}
 				{
-					position192, tokenIndex192, depth192 := position, tokenIndex, depth
+					position227, tokenIndex227, depth227 := position, tokenIndex, depth
 					if !_rules[ruleListExpansion]() {
-						goto l192
+						goto l227
 					}
-					goto l193
-				l192:
-					position, tokenIndex, depth = position192, tokenIndex192, depth192
+					goto l228
+				l227:
+					position, tokenIndex, depth = position227, tokenIndex227, depth227
 				}
-			l193:
+			l228:
 				depth--
-				add(ruleNextExpression, position191)
+				add(ruleNextExpression, position226)
 			}
 			return true
-		l190:
-			position, tokenIndex, depth = position190, tokenIndex190, depth190
+		l225:
+			position, tokenIndex, depth = position225, tokenIndex225, depth225
 			return false
 		},
-		/* 46 ListExpansion <- <('.' '.' '.' ws)> */
+		/* 50 ListExpansion <- <('.' '.' '.' ws)> */
 		func() bool {
-			position194, tokenIndex194, depth194 := position, tokenIndex, depth
+			position229, tokenIndex229, depth229 := position, tokenIndex, depth
 			{
-				position195 := position
+				position230 := position
 				depth++
 				if buffer[position] != rune('.') {
-					goto l194
+					goto l229
 				}
 				position++
 				if buffer[position] != rune('.') {
-					goto l194
+					goto l229
 				}
 				position++
 				if buffer[position] != rune('.') {
-					goto l194
+					goto l229
 				}
 				position++
 				if !_rules[rulews]() {
-					goto l194
+					goto l229
 				}
 				depth--
-				add(ruleListExpansion, position195)
+				add(ruleListExpansion, position230)
 			}
 			return true
-		l194:
-			position, tokenIndex, depth = position194, tokenIndex194, depth194
+		l229:
+			position, tokenIndex, depth = position229, tokenIndex229, depth229
 			return false
 		},
-		/* 47 Projection <- <('.'? (('[' '*' ']') / Slice) ProjectionValue ChainedQualifiedExpression*)> */
+		/* 51 Projection <- <('.'? (('[' '*' ']') / Slice) ProjectionValue ChainedQualifiedExpression*)> */
 		func() bool {
-			position196, tokenIndex196, depth196 := position, tokenIndex, depth
+			position231, tokenIndex231, depth231 := position, tokenIndex, depth
 			{
-				position197 := position
+				position232 := position
 				depth++
 				{
-					position198, tokenIndex198, depth198 := position, tokenIndex, depth
+					position233, tokenIndex233, depth233 := position, tokenIndex, depth
 					if buffer[position] != rune('.') {
-						goto l198
+						goto l233
 					}
 					position++
-					goto l199
-				l198:
-					position, tokenIndex, depth = position198, tokenIndex198, depth198
+					goto l234
+				l233:
+					position, tokenIndex, depth = position233, tokenIndex233, depth233
 				}
-			l199:
+			l234:
 				{
-					position200, tokenIndex200, depth200 := position, tokenIndex, depth
+					position235, tokenIndex235, depth235 := position, tokenIndex, depth
 					if buffer[position] != rune('[') {
-						goto l201
+						goto l236
 					}
 					position++
 					if buffer[position] != rune('*') {
-						goto l201
+						goto l236
 					}
 					position++
 					if buffer[position] != rune(']') {
-						goto l201
+						goto l236
 					}
 					position++
-					goto l200
-				l201:
-					position, tokenIndex, depth = position200, tokenIndex200, depth200
+					goto l235
+				l236:
+					position, tokenIndex, depth = position235, tokenIndex235, depth235
 					if !_rules[ruleSlice]() {
-						goto l196
+						goto l231
 					}
 				}
-			l200:
+			l235:
 				if !_rules[ruleProjectionValue]() {
-					goto l196
+					goto l231
 				}
-			l202:
+			l237:
 				{
-					position203, tokenIndex203, depth203 := position, tokenIndex, depth
+					position238, tokenIndex238, depth238 := position, tokenIndex, depth
 					if !_rules[ruleChainedQualifiedExpression]() {
-						goto l203
+						goto l238
 					}
-					goto l202
-				l203:
-					position, tokenIndex, depth = position203, tokenIndex203, depth203
+					goto l237
+				l238:
+					position, tokenIndex, depth = position238, tokenIndex238, depth238
 				}
 				depth--
-				add(ruleProjection, position197)
+				add(ruleProjection, position232)
 			}
 			return true
-		l196:
-			position, tokenIndex, depth = position196, tokenIndex196, depth196
+		l231:
+			position, tokenIndex, depth = position231, tokenIndex231, depth231
 			return false
 		},
-		/* 48 ProjectionValue <- <Action0> */
+		/* 52 ProjectionValue <- <Action0> */
 		func() bool {
-			position204, tokenIndex204, depth204 := position, tokenIndex, depth
+			position239, tokenIndex239, depth239 := position, tokenIndex, depth
 			{
-				position205 := position
+				position240 := position
 				depth++
 				if !_rules[ruleAction0]() {
-					goto l204
+					goto l239
 				}
 				depth--
-				add(ruleProjectionValue, position205)
+				add(ruleProjectionValue, position240)
 			}
 			return true
-		l204:
-			position, tokenIndex, depth = position204, tokenIndex204, depth204
+		l239:
+			position, tokenIndex, depth = position239, tokenIndex239, depth239
 			return false
 		},
-		/* 49 Substitution <- <('*' Level0)> */
+		/* 53 Substitution <- <('*' Level0)> */
 		func() bool {
-			position206, tokenIndex206, depth206 := position, tokenIndex, depth
+			position241, tokenIndex241, depth241 := position, tokenIndex, depth
 			{
-				position207 := position
+				position242 := position
 				depth++
 				if buffer[position] != rune('*') {
-					goto l206
+					goto l241
 				}
 				position++
 				if !_rules[ruleLevel0]() {
-					goto l206
+					goto l241
 				}
 				depth--
-				add(ruleSubstitution, position207)
+				add(ruleSubstitution, position242)
 			}
 			return true
-		l206:
-			position, tokenIndex, depth = position206, tokenIndex206, depth206
+		l241:
+			position, tokenIndex, depth = position241, tokenIndex241, depth241
 			return false
 		},
-		/* 50 Not <- <('!' ws Level0)> */
+		/* 54 Not <- <('!' ws Level0)> */
 		func() bool {
-			position208, tokenIndex208, depth208 := position, tokenIndex, depth
+			position243, tokenIndex243, depth243 := position, tokenIndex, depth
 			{
-				position209 := position
+				position244 := position
 				depth++
 				if buffer[position] != rune('!') {
-					goto l208
+					goto l243
 				}
 				position++
 				if !_rules[rulews]() {
-					goto l208
+					goto l243
 				}
 				if !_rules[ruleLevel0]() {
-					goto l208
+					goto l243
 				}
 				depth--
-				add(ruleNot, position209)
+				add(ruleNot, position244)
 			}
 			return true
-		l208:
-			position, tokenIndex, depth = position208, tokenIndex208, depth208
+		l243:
+			position, tokenIndex, depth = position243, tokenIndex243, depth243
 			return false
 		},
-		/* 51 Grouped <- <('(' Expression ')')> */
+		/* 55 Grouped <- <('(' Expression ')')> */
 		func() bool {
-			position210, tokenIndex210, depth210 := position, tokenIndex, depth
+			position245, tokenIndex245, depth245 := position, tokenIndex, depth
 			{
-				position211 := position
+				position246 := position
 				depth++
 				if buffer[position] != rune('(') {
-					goto l210
+					goto l245
 				}
 				position++
 				if !_rules[ruleExpression]() {
-					goto l210
+					goto l245
 				}
 				if buffer[position] != rune(')') {
-					goto l210
+					goto l245
 				}
 				position++
 				depth--
-				add(ruleGrouped, position211)
+				add(ruleGrouped, position246)
 			}
 			return true
-		l210:
-			position, tokenIndex, depth = position210, tokenIndex210, depth210
+		l245:
+			position, tokenIndex, depth = position245, tokenIndex245, depth245
 			return false
 		},
-		/* 52 Range <- <(StartRange Expression? RangeOp Expression? ']')> */
+		/* 56 Range <- <(StartRange Expression? RangeOp Expression? ']')> */
 		func() bool {
-			position212, tokenIndex212, depth212 := position, tokenIndex, depth
+			position247, tokenIndex247, depth247 := position, tokenIndex, depth
 			{
-				position213 := position
+				position248 := position
 				depth++
 				if !_rules[ruleStartRange]() {
-					goto l212
+					goto l247
 				}
 				{
-					position214, tokenIndex214, depth214 := position, tokenIndex, depth
+					position249, tokenIndex249, depth249 := position, tokenIndex, depth
 					if !_rules[ruleExpression]() {
-						goto l214
+						goto l249
 					}
-					goto l215
-				l214:
-					position, tokenIndex, depth = position214, tokenIndex214, depth214
+					goto l250
+				l249:
+					position, tokenIndex, depth = position249, tokenIndex249, depth249
 				}
-			l215:
+			l250:
 				if !_rules[ruleRangeOp]() {
-					goto l212
+					goto l247
 				}
 				{
-					position216, tokenIndex216, depth216 := position, tokenIndex, depth
+					position251, tokenIndex251, depth251 := position, tokenIndex, depth
 					if !_rules[ruleExpression]() {
-						goto l216
+						goto l251
 					}
-					goto l217
-				l216:
-					position, tokenIndex, depth = position216, tokenIndex216, depth216
+					goto l252
+				l251:
+					position, tokenIndex, depth = position251, tokenIndex251, depth251
 				}
-			l217:
+			l252:
 				if buffer[position] != rune(']') {
-					goto l212
+					goto l247
 				}
 				position++
 				depth--
-				add(ruleRange, position213)
+				add(ruleRange, position248)
 			}
 			return true
-		l212:
-			position, tokenIndex, depth = position212, tokenIndex212, depth212
+		l247:
+			position, tokenIndex, depth = position247, tokenIndex247, depth247
 			return false
 		},
-		/* 53 StartRange <- <'['> */
+		/* 57 StartRange <- <'['> */
 		func() bool {
-			position218, tokenIndex218, depth218 := position, tokenIndex, depth
+			position253, tokenIndex253, depth253 := position, tokenIndex, depth
 			{
-				position219 := position
+				position254 := position
 				depth++
 				if buffer[position] != rune('[') {
-					goto l218
+					goto l253
 				}
 				position++
 				depth--
-				add(ruleStartRange, position219)
+				add(ruleStartRange, position254)
 			}
 			return true
-		l218:
-			position, tokenIndex, depth = position218, tokenIndex218, depth218
+		l253:
+			position, tokenIndex, depth = position253, tokenIndex253, depth253
 			return false
 		},
-		/* 54 RangeOp <- <('.' '.')> */
+		/* 58 RangeOp <- <('.' '.')> */
 		func() bool {
-			position220, tokenIndex220, depth220 := position, tokenIndex, depth
+			position255, tokenIndex255, depth255 := position, tokenIndex, depth
 			{
-				position221 := position
+				position256 := position
 				depth++
 				if buffer[position] != rune('.') {
-					goto l220
+					goto l255
 				}
 				position++
 				if buffer[position] != rune('.') {
-					goto l220
+					goto l255
 				}
 				position++
 				depth--
-				add(ruleRangeOp, position221)
+				add(ruleRangeOp, position256)
 			}
 			return true
-		l220:
-			position, tokenIndex, depth = position220, tokenIndex220, depth220
+		l255:
+			position, tokenIndex, depth = position255, tokenIndex255, depth255
 			return false
 		},
-		/* 55 Number <- <('-'? [0-9] ([0-9] / '_')* ('.' [0-9] [0-9]*)? (('e' / 'E') '-'? [0-9] [0-9]*)? !(':' ':'))> */
+		/* 59 Number <- <('-'? [0-9] ([0-9] / '_')* ('.' [0-9] [0-9]*)? (('e' / 'E') '-'? [0-9] [0-9]*)? !(':' ':'))> */
 		func() bool {
-			position222, tokenIndex222, depth222 := position, tokenIndex, depth
+			position257, tokenIndex257, depth257 := position, tokenIndex, depth
 			{
-				position223 := position
+				position258 := position
 				depth++
 				{
-					position224, tokenIndex224, depth224 := position, tokenIndex, depth
+					position259, tokenIndex259, depth259 := position, tokenIndex, depth
 					if buffer[position] != rune('-') {
-						goto l224
+						goto l259
 					}
 					position++
-					goto l225
-				l224:
-					position, tokenIndex, depth = position224, tokenIndex224, depth224
+					goto l260
+				l259:
+					position, tokenIndex, depth = position259, tokenIndex259, depth259
 				}
-			l225:
+			l260:
 				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l222
+					goto l257
 				}
 				position++
-			l226:
+			l261:
 				{
-					position227, tokenIndex227, depth227 := position, tokenIndex, depth
+					position262, tokenIndex262, depth262 := position, tokenIndex, depth
 					{
-						position228, tokenIndex228, depth228 := position, tokenIndex, depth
+						position263, tokenIndex263, depth263 := position, tokenIndex, depth
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l229
+							goto l264
 						}
 						position++
-						goto l228
-					l229:
-						position, tokenIndex, depth = position228, tokenIndex228, depth228
+						goto l263
+					l264:
+						position, tokenIndex, depth = position263, tokenIndex263, depth263
 						if buffer[position] != rune('_') {
-							goto l227
+							goto l262
 						}
 						position++
 					}
-				l228:
-					goto l226
-				l227:
-					position, tokenIndex, depth = position227, tokenIndex227, depth227
+				l263:
+					goto l261
+				l262:
+					position, tokenIndex, depth = position262, tokenIndex262, depth262
 				}
 				{
-					position230, tokenIndex230, depth230 := position, tokenIndex, depth
+					position265, tokenIndex265, depth265 := position, tokenIndex, depth
 					if buffer[position] != rune('.') {
-						goto l230
+						goto l265
 					}
 					position++
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l230
+						goto l265
 					}
 					position++
-				l232:
+				l267:
 					{
-						position233, tokenIndex233, depth233 := position, tokenIndex, depth
+						position268, tokenIndex268, depth268 := position, tokenIndex, depth
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l233
+							goto l268
 						}
 						position++
-						goto l232
-					l233:
-						position, tokenIndex, depth = position233, tokenIndex233, depth233
+						goto l267
+					l268:
+						position, tokenIndex, depth = position268, tokenIndex268, depth268
 					}
-					goto l231
-				l230:
-					position, tokenIndex, depth = position230, tokenIndex230, depth230
+					goto l266
+				l265:
+					position, tokenIndex, depth = position265, tokenIndex265, depth265
 				}
-			l231:
+			l266:
 				{
-					position234, tokenIndex234, depth234 := position, tokenIndex, depth
+					position269, tokenIndex269, depth269 := position, tokenIndex, depth
 					{
-						position236, tokenIndex236, depth236 := position, tokenIndex, depth
+						position271, tokenIndex271, depth271 := position, tokenIndex, depth
 						if buffer[position] != rune('e') {
-							goto l237
+							goto l272
 						}
 						position++
-						goto l236
-					l237:
-						position, tokenIndex, depth = position236, tokenIndex236, depth236
+						goto l271
+					l272:
+						position, tokenIndex, depth = position271, tokenIndex271, depth271
 						if buffer[position] != rune('E') {
-							goto l234
+							goto l269
 						}
 						position++
 					}
-				l236:
+				l271:
 					{
-						position238, tokenIndex238, depth238 := position, tokenIndex, depth
+						position273, tokenIndex273, depth273 := position, tokenIndex, depth
 						if buffer[position] != rune('-') {
-							goto l238
+							goto l273
 						}
 						position++
-						goto l239
-					l238:
-						position, tokenIndex, depth = position238, tokenIndex238, depth238
+						goto l274
+					l273:
+						position, tokenIndex, depth = position273, tokenIndex273, depth273
 					}
-				l239:
+				l274:
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l234
+						goto l269
 					}
 					position++
-				l240:
+				l275:
 					{
-						position241, tokenIndex241, depth241 := position, tokenIndex, depth
+						position276, tokenIndex276, depth276 := position, tokenIndex, depth
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l241
+							goto l276
 						}
 						position++
-						goto l240
-					l241:
-						position, tokenIndex, depth = position241, tokenIndex241, depth241
+						goto l275
+					l276:
+						position, tokenIndex, depth = position276, tokenIndex276, depth276
 					}
-					goto l235
-				l234:
-					position, tokenIndex, depth = position234, tokenIndex234, depth234
+					goto l270
+				l269:
+					position, tokenIndex, depth = position269, tokenIndex269, depth269
 				}
-			l235:
+			l270:
 				{
-					position242, tokenIndex242, depth242 := position, tokenIndex, depth
+					position277, tokenIndex277, depth277 := position, tokenIndex, depth
 					if buffer[position] != rune(':') {
-						goto l242
+						goto l277
 					}
 					position++
 					if buffer[position] != rune(':') {
-						goto l242
+						goto l277
 					}
 					position++
-					goto l222
-				l242:
-					position, tokenIndex, depth = position242, tokenIndex242, depth242
+					goto l257
+				l277:
+					position, tokenIndex, depth = position277, tokenIndex277, depth277
 				}
 				depth--
-				add(ruleNumber, position223)
+				add(ruleNumber, position258)
 			}
 			return true
-		l222:
-			position, tokenIndex, depth = position222, tokenIndex222, depth222
+		l257:
+			position, tokenIndex, depth = position257, tokenIndex257, depth257
 			return false
 		},
-		/* 56 String <- <('"' (('\\' '"') / (!'"' .))* '"')> */
+		/* 60 String <- <('"' (('\\' '"') / (!'"' .))* '"')> */
 		func() bool {
-			position243, tokenIndex243, depth243 := position, tokenIndex, depth
+			position278, tokenIndex278, depth278 := position, tokenIndex, depth
 			{
-				position244 := position
+				position279 := position
 				depth++
 				if buffer[position] != rune('"') {
-					goto l243
+					goto l278
 				}
 				position++
-			l245:
+			l280:
 				{
-					position246, tokenIndex246, depth246 := position, tokenIndex, depth
+					position281, tokenIndex281, depth281 := position, tokenIndex, depth
 					{
-						position247, tokenIndex247, depth247 := position, tokenIndex, depth
+						position282, tokenIndex282, depth282 := position, tokenIndex, depth
 						if buffer[position] != rune('\\') {
-							goto l248
+							goto l283
 						}
 						position++
 						if buffer[position] != rune('"') {
-							goto l248
+							goto l283
 						}
 						position++
-						goto l247
-					l248:
-						position, tokenIndex, depth = position247, tokenIndex247, depth247
+						goto l282
+					l283:
+						position, tokenIndex, depth = position282, tokenIndex282, depth282
 						{
-							position249, tokenIndex249, depth249 := position, tokenIndex, depth
+							position284, tokenIndex284, depth284 := position, tokenIndex, depth
 							if buffer[position] != rune('"') {
-								goto l249
+								goto l284
 							}
 							position++
-							goto l246
-						l249:
-							position, tokenIndex, depth = position249, tokenIndex249, depth249
+							goto l281
+						l284:
+							position, tokenIndex, depth = position284, tokenIndex284, depth284
 						}
 						if !matchDot() {
-							goto l246
+							goto l281
 						}
 					}
-				l247:
-					goto l245
-				l246:
-					position, tokenIndex, depth = position246, tokenIndex246, depth246
+				l282:
+					goto l280
+				l281:
+					position, tokenIndex, depth = position281, tokenIndex281, depth281
 				}
 				if buffer[position] != rune('"') {
-					goto l243
+					goto l278
 				}
 				position++
 				depth--
-				add(ruleString, position244)
+				add(ruleString, position279)
 			}
 			return true
-		l243:
-			position, tokenIndex, depth = position243, tokenIndex243, depth243
+		l278:
+			position, tokenIndex, depth = position278, tokenIndex278, depth278
 			return false
 		},
-		/* 57 Boolean <- <(('t' 'r' 'u' 'e') / ('f' 'a' 'l' 's' 'e'))> */
+		/* 61 Boolean <- <(('t' 'r' 'u' 'e') / ('f' 'a' 'l' 's' 'e'))> */
 		func() bool {
-			position250, tokenIndex250, depth250 := position, tokenIndex, depth
+			position285, tokenIndex285, depth285 := position, tokenIndex, depth
 			{
-				position251 := position
+				position286 := position
 				depth++
 				{
-					position252, tokenIndex252, depth252 := position, tokenIndex, depth
+					position287, tokenIndex287, depth287 := position, tokenIndex, depth
 					if buffer[position] != rune('t') {
-						goto l253
+						goto l288
 					}
 					position++
 					if buffer[position] != rune('r') {
-						goto l253
+						goto l288
 					}
 					position++
 					if buffer[position] != rune('u') {
-						goto l253
+						goto l288
 					}
 					position++
 					if buffer[position] != rune('e') {
-						goto l253
+						goto l288
 					}
 					position++
-					goto l252
-				l253:
-					position, tokenIndex, depth = position252, tokenIndex252, depth252
+					goto l287
+				l288:
+					position, tokenIndex, depth = position287, tokenIndex287, depth287
 					if buffer[position] != rune('f') {
-						goto l250
+						goto l285
 					}
 					position++
 					if buffer[position] != rune('a') {
-						goto l250
+						goto l285
 					}
 					position++
 					if buffer[position] != rune('l') {
-						goto l250
+						goto l285
 					}
 					position++
 					if buffer[position] != rune('s') {
-						goto l250
+						goto l285
 					}
 					position++
 					if buffer[position] != rune('e') {
-						goto l250
+						goto l285
 					}
 					position++
 				}
-			l252:
+			l287:
 				depth--
-				add(ruleBoolean, position251)
+				add(ruleBoolean, position286)
 			}
 			return true
-		l250:
-			position, tokenIndex, depth = position250, tokenIndex250, depth250
+		l285:
+			position, tokenIndex, depth = position285, tokenIndex285, depth285
 			return false
 		},
-		/* 58 Nil <- <(('n' 'i' 'l') / '~')> */
+		/* 62 Nil <- <(('n' 'i' 'l') / '~')> */
 		func() bool {
-			position254, tokenIndex254, depth254 := position, tokenIndex, depth
+			position289, tokenIndex289, depth289 := position, tokenIndex, depth
 			{
-				position255 := position
+				position290 := position
 				depth++
 				{
-					position256, tokenIndex256, depth256 := position, tokenIndex, depth
+					position291, tokenIndex291, depth291 := position, tokenIndex, depth
 					if buffer[position] != rune('n') {
-						goto l257
+						goto l292
 					}
 					position++
 					if buffer[position] != rune('i') {
-						goto l257
+						goto l292
 					}
 					position++
 					if buffer[position] != rune('l') {
-						goto l257
+						goto l292
 					}
 					position++
-					goto l256
-				l257:
-					position, tokenIndex, depth = position256, tokenIndex256, depth256
+					goto l291
+				l292:
+					position, tokenIndex, depth = position291, tokenIndex291, depth291
 					if buffer[position] != rune('~') {
-						goto l254
+						goto l289
 					}
 					position++
 				}
-			l256:
+			l291:
 				depth--
-				add(ruleNil, position255)
+				add(ruleNil, position290)
 			}
 			return true
-		l254:
-			position, tokenIndex, depth = position254, tokenIndex254, depth254
+		l289:
+			position, tokenIndex, depth = position289, tokenIndex289, depth289
 			return false
 		},
-		/* 59 Undefined <- <('~' '~')> */
+		/* 63 Undefined <- <('~' '~')> */
 		func() bool {
-			position258, tokenIndex258, depth258 := position, tokenIndex, depth
+			position293, tokenIndex293, depth293 := position, tokenIndex, depth
 			{
-				position259 := position
+				position294 := position
 				depth++
 				if buffer[position] != rune('~') {
-					goto l258
+					goto l293
 				}
 				position++
 				if buffer[position] != rune('~') {
-					goto l258
+					goto l293
 				}
 				position++
 				depth--
-				add(ruleUndefined, position259)
+				add(ruleUndefined, position294)
 			}
 			return true
-		l258:
-			position, tokenIndex, depth = position258, tokenIndex258, depth258
+		l293:
+			position, tokenIndex, depth = position293, tokenIndex293, depth293
 			return false
 		},
-		/* 60 Symbol <- <('$' Name)> */
+		/* 64 Symbol <- <('$' Name)> */
 		func() bool {
-			position260, tokenIndex260, depth260 := position, tokenIndex, depth
+			position295, tokenIndex295, depth295 := position, tokenIndex, depth
 			{
-				position261 := position
+				position296 := position
 				depth++
 				if buffer[position] != rune('$') {
-					goto l260
+					goto l295
 				}
 				position++
 				if !_rules[ruleName]() {
-					goto l260
+					goto l295
 				}
 				depth--
-				add(ruleSymbol, position261)
+				add(ruleSymbol, position296)
 			}
 			return true
-		l260:
-			position, tokenIndex, depth = position260, tokenIndex260, depth260
+		l295:
+			position, tokenIndex, depth = position295, tokenIndex295, depth295
 			return false
 		},
-		/* 61 List <- <(StartList ExpressionList? ']')> */
+		/* 65 List <- <(StartList ExpressionList? ']')> */
 		func() bool {
-			position262, tokenIndex262, depth262 := position, tokenIndex, depth
+			position297, tokenIndex297, depth297 := position, tokenIndex, depth
 			{
-				position263 := position
+				position298 := position
 				depth++
 				if !_rules[ruleStartList]() {
-					goto l262
+					goto l297
 				}
 				{
-					position264, tokenIndex264, depth264 := position, tokenIndex, depth
+					position299, tokenIndex299, depth299 := position, tokenIndex, depth
 					if !_rules[ruleExpressionList]() {
-						goto l264
+						goto l299
 					}
-					goto l265
-				l264:
-					position, tokenIndex, depth = position264, tokenIndex264, depth264
+					goto l300
+				l299:
+					position, tokenIndex, depth = position299, tokenIndex299, depth299
 				}
-			l265:
+			l300:
 				if buffer[position] != rune(']') {
-					goto l262
+					goto l297
 				}
 				position++
 				depth--
-				add(ruleList, position263)
+				add(ruleList, position298)
 			}
 			return true
-		l262:
-			position, tokenIndex, depth = position262, tokenIndex262, depth262
+		l297:
+			position, tokenIndex, depth = position297, tokenIndex297, depth297
 			return false
 		},
-		/* 62 StartList <- <('[' ws)> */
+		/* 66 StartList <- <('[' ws)> */
 		func() bool {
-			position266, tokenIndex266, depth266 := position, tokenIndex, depth
+			position301, tokenIndex301, depth301 := position, tokenIndex, depth
 			{
-				position267 := position
+				position302 := position
 				depth++
 				if buffer[position] != rune('[') {
-					goto l266
+					goto l301
 				}
 				position++
 				if !_rules[rulews]() {
-					goto l266
+					goto l301
 				}
 				depth--
-				add(ruleStartList, position267)
+				add(ruleStartList, position302)
 			}
 			return true
-		l266:
-			position, tokenIndex, depth = position266, tokenIndex266, depth266
+		l301:
+			position, tokenIndex, depth = position301, tokenIndex301, depth301
 			return false
 		},
-		/* 63 Map <- <(CreateMap ws Assignments? '}')> */
+		/* 67 Map <- <(CreateMap ws Assignments? '}')> */
 		func() bool {
-			position268, tokenIndex268, depth268 := position, tokenIndex, depth
+			position303, tokenIndex303, depth303 := position, tokenIndex, depth
 			{
-				position269 := position
+				position304 := position
 				depth++
 				if !_rules[ruleCreateMap]() {
-					goto l268
+					goto l303
 				}
 				if !_rules[rulews]() {
-					goto l268
+					goto l303
 				}
 				{
-					position270, tokenIndex270, depth270 := position, tokenIndex, depth
+					position305, tokenIndex305, depth305 := position, tokenIndex, depth
 					if !_rules[ruleAssignments]() {
-						goto l270
+						goto l305
 					}
-					goto l271
-				l270:
-					position, tokenIndex, depth = position270, tokenIndex270, depth270
+					goto l306
+				l305:
+					position, tokenIndex, depth = position305, tokenIndex305, depth305
 				}
-			l271:
+			l306:
 				if buffer[position] != rune('}') {
-					goto l268
+					goto l303
 				}
 				position++
 				depth--
-				add(ruleMap, position269)
+				add(ruleMap, position304)
 			}
 			return true
-		l268:
-			position, tokenIndex, depth = position268, tokenIndex268, depth268
+		l303:
+			position, tokenIndex, depth = position303, tokenIndex303, depth303
 			return false
 		},
-		/* 64 CreateMap <- <'{'> */
+		/* 68 CreateMap <- <'{'> */
 		func() bool {
-			position272, tokenIndex272, depth272 := position, tokenIndex, depth
+			position307, tokenIndex307, depth307 := position, tokenIndex, depth
 			{
-				position273 := position
+				position308 := position
 				depth++
 				if buffer[position] != rune('{') {
-					goto l272
+					goto l307
 				}
 				position++
 				depth--
-				add(ruleCreateMap, position273)
+				add(ruleCreateMap, position308)
 			}
 			return true
-		l272:
-			position, tokenIndex, depth = position272, tokenIndex272, depth272
+		l307:
+			position, tokenIndex, depth = position307, tokenIndex307, depth307
 			return false
 		},
-		/* 65 Assignments <- <(Assignment (',' Assignment)*)> */
+		/* 69 Assignments <- <(Assignment (',' Assignment)*)> */
 		func() bool {
-			position274, tokenIndex274, depth274 := position, tokenIndex, depth
+			position309, tokenIndex309, depth309 := position, tokenIndex, depth
 			{
-				position275 := position
+				position310 := position
 				depth++
 				if !_rules[ruleAssignment]() {
-					goto l274
+					goto l309
 				}
-			l276:
+			l311:
 				{
-					position277, tokenIndex277, depth277 := position, tokenIndex, depth
+					position312, tokenIndex312, depth312 := position, tokenIndex, depth
 					if buffer[position] != rune(',') {
-						goto l277
+						goto l312
 					}
 					position++
 					if !_rules[ruleAssignment]() {
-						goto l277
+						goto l312
 					}
-					goto l276
-				l277:
-					position, tokenIndex, depth = position277, tokenIndex277, depth277
+					goto l311
+				l312:
+					position, tokenIndex, depth = position312, tokenIndex312, depth312
 				}
 				depth--
-				add(ruleAssignments, position275)
+				add(ruleAssignments, position310)
 			}
 			return true
-		l274:
-			position, tokenIndex, depth = position274, tokenIndex274, depth274
+		l309:
+			position, tokenIndex, depth = position309, tokenIndex309, depth309
 			return false
 		},
-		/* 66 Assignment <- <(Expression '=' Expression)> */
+		/* 70 Assignment <- <(Expression '=' Expression)> */
 		func() bool {
-			position278, tokenIndex278, depth278 := position, tokenIndex, depth
+			position313, tokenIndex313, depth313 := position, tokenIndex, depth
 			{
-				position279 := position
+				position314 := position
 				depth++
 				if !_rules[ruleExpression]() {
-					goto l278
+					goto l313
 				}
 				if buffer[position] != rune('=') {
-					goto l278
+					goto l313
 				}
 				position++
 				if !_rules[ruleExpression]() {
-					goto l278
+					goto l313
 				}
 				depth--
-				add(ruleAssignment, position279)
+				add(ruleAssignment, position314)
 			}
 			return true
-		l278:
-			position, tokenIndex, depth = position278, tokenIndex278, depth278
+		l313:
+			position, tokenIndex, depth = position313, tokenIndex313, depth313
 			return false
 		},
-		/* 67 Merge <- <(RefMerge / SimpleMerge)> */
+		/* 71 Merge <- <(RefMerge / SimpleMerge)> */
 		func() bool {
-			position280, tokenIndex280, depth280 := position, tokenIndex, depth
+			position315, tokenIndex315, depth315 := position, tokenIndex, depth
 			{
-				position281 := position
+				position316 := position
 				depth++
 				{
-					position282, tokenIndex282, depth282 := position, tokenIndex, depth
+					position317, tokenIndex317, depth317 := position, tokenIndex, depth
 					if !_rules[ruleRefMerge]() {
-						goto l283
+						goto l318
 					}
-					goto l282
-				l283:
-					position, tokenIndex, depth = position282, tokenIndex282, depth282
+					goto l317
+				l318:
+					position, tokenIndex, depth = position317, tokenIndex317, depth317
 					if !_rules[ruleSimpleMerge]() {
-						goto l280
+						goto l315
 					}
 				}
-			l282:
+			l317:
 				depth--
-				add(ruleMerge, position281)
+				add(ruleMerge, position316)
 			}
 			return true
-		l280:
-			position, tokenIndex, depth = position280, tokenIndex280, depth280
+		l315:
+			position, tokenIndex, depth = position315, tokenIndex315, depth315
 			return false
 		},
-		/* 68 RefMerge <- <('m' 'e' 'r' 'g' 'e' !(req_ws Required) (req_ws (Replace / On))? req_ws Reference)> */
+		/* 72 RefMerge <- <('m' 'e' 'r' 'g' 'e' !(req_ws Required) (req_ws (Replace / On))? req_ws Reference)> */
 		func() bool {
-			position284, tokenIndex284, depth284 := position, tokenIndex, depth
+			position319, tokenIndex319, depth319 := position, tokenIndex, depth
 			{
-				position285 := position
+				position320 := position
 				depth++
 				if buffer[position] != rune('m') {
-					goto l284
+					goto l319
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l284
+					goto l319
 				}
 				position++
 				if buffer[position] != rune('r') {
-					goto l284
+					goto l319
 				}
 				position++
 				if buffer[position] != rune('g') {
-					goto l284
+					goto l319
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l284
+					goto l319
 				}
 				position++
 				{
-					position286, tokenIndex286, depth286 := position, tokenIndex, depth
+					position321, tokenIndex321, depth321 := position, tokenIndex, depth
 					if !_rules[rulereq_ws]() {
-						goto l286
+						goto l321
 					}
 					if !_rules[ruleRequired]() {
-						goto l286
+						goto l321
 					}
-					goto l284
-				l286:
-					position, tokenIndex, depth = position286, tokenIndex286, depth286
+					goto l319
+				l321:
+					position, tokenIndex, depth = position321, tokenIndex321, depth321
 				}
 				{
-					position287, tokenIndex287, depth287 := position, tokenIndex, depth
+					position322, tokenIndex322, depth322 := position, tokenIndex, depth
 					if !_rules[rulereq_ws]() {
-						goto l287
+						goto l322
 					}
 					{
-						position289, tokenIndex289, depth289 := position, tokenIndex, depth
+						position324, tokenIndex324, depth324 := position, tokenIndex, depth
 						if !_rules[ruleReplace]() {
-							goto l290
+							goto l325
 						}
-						goto l289
-					l290:
-						position, tokenIndex, depth = position289, tokenIndex289, depth289
+						goto l324
+					l325:
+						position, tokenIndex, depth = position324, tokenIndex324, depth324
 						if !_rules[ruleOn]() {
-							goto l287
+							goto l322
 						}
 					}
-				l289:
-					goto l288
-				l287:
-					position, tokenIndex, depth = position287, tokenIndex287, depth287
+				l324:
+					goto l323
+				l322:
+					position, tokenIndex, depth = position322, tokenIndex322, depth322
 				}
-			l288:
+			l323:
 				if !_rules[rulereq_ws]() {
-					goto l284
+					goto l319
 				}
 				if !_rules[ruleReference]() {
-					goto l284
+					goto l319
 				}
 				depth--
-				add(ruleRefMerge, position285)
+				add(ruleRefMerge, position320)
 			}
 			return true
-		l284:
-			position, tokenIndex, depth = position284, tokenIndex284, depth284
+		l319:
+			position, tokenIndex, depth = position319, tokenIndex319, depth319
 			return false
 		},
-		/* 69 SimpleMerge <- <('m' 'e' 'r' 'g' 'e' !'(' (req_ws (Replace / Required / On))?)> */
+		/* 73 SimpleMerge <- <('m' 'e' 'r' 'g' 'e' !'(' (req_ws (Replace / Required / On))?)> */
 		func() bool {
-			position291, tokenIndex291, depth291 := position, tokenIndex, depth
+			position326, tokenIndex326, depth326 := position, tokenIndex, depth
 			{
-				position292 := position
+				position327 := position
 				depth++
 				if buffer[position] != rune('m') {
-					goto l291
+					goto l326
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l291
+					goto l326
 				}
 				position++
 				if buffer[position] != rune('r') {
-					goto l291
+					goto l326
 				}
 				position++
 				if buffer[position] != rune('g') {
-					goto l291
+					goto l326
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l291
+					goto l326
 				}
 				position++
 				{
-					position293, tokenIndex293, depth293 := position, tokenIndex, depth
+					position328, tokenIndex328, depth328 := position, tokenIndex, depth
 					if buffer[position] != rune('(') {
-						goto l293
+						goto l328
 					}
 					position++
-					goto l291
-				l293:
-					position, tokenIndex, depth = position293, tokenIndex293, depth293
+					goto l326
+				l328:
+					position, tokenIndex, depth = position328, tokenIndex328, depth328
 				}
 				{
-					position294, tokenIndex294, depth294 := position, tokenIndex, depth
+					position329, tokenIndex329, depth329 := position, tokenIndex, depth
 					if !_rules[rulereq_ws]() {
-						goto l294
+						goto l329
 					}
 					{
-						position296, tokenIndex296, depth296 := position, tokenIndex, depth
+						position331, tokenIndex331, depth331 := position, tokenIndex, depth
 						if !_rules[ruleReplace]() {
-							goto l297
+							goto l332
 						}
-						goto l296
-					l297:
-						position, tokenIndex, depth = position296, tokenIndex296, depth296
+						goto l331
+					l332:
+						position, tokenIndex, depth = position331, tokenIndex331, depth331
 						if !_rules[ruleRequired]() {
-							goto l298
+							goto l333
 						}
-						goto l296
-					l298:
-						position, tokenIndex, depth = position296, tokenIndex296, depth296
+						goto l331
+					l333:
+						position, tokenIndex, depth = position331, tokenIndex331, depth331
 						if !_rules[ruleOn]() {
-							goto l294
+							goto l329
 						}
 					}
-				l296:
-					goto l295
-				l294:
-					position, tokenIndex, depth = position294, tokenIndex294, depth294
+				l331:
+					goto l330
+				l329:
+					position, tokenIndex, depth = position329, tokenIndex329, depth329
 				}
-			l295:
+			l330:
 				depth--
-				add(ruleSimpleMerge, position292)
+				add(ruleSimpleMerge, position327)
 			}
 			return true
-		l291:
-			position, tokenIndex, depth = position291, tokenIndex291, depth291
+		l326:
+			position, tokenIndex, depth = position326, tokenIndex326, depth326
 			return false
 		},
-		/* 70 Replace <- <('r' 'e' 'p' 'l' 'a' 'c' 'e')> */
+		/* 74 Replace <- <('r' 'e' 'p' 'l' 'a' 'c' 'e')> */
 		func() bool {
-			position299, tokenIndex299, depth299 := position, tokenIndex, depth
+			position334, tokenIndex334, depth334 := position, tokenIndex, depth
 			{
-				position300 := position
+				position335 := position
 				depth++
 				if buffer[position] != rune('r') {
-					goto l299
+					goto l334
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l299
+					goto l334
 				}
 				position++
 				if buffer[position] != rune('p') {
-					goto l299
+					goto l334
 				}
 				position++
 				if buffer[position] != rune('l') {
-					goto l299
+					goto l334
 				}
 				position++
 				if buffer[position] != rune('a') {
-					goto l299
+					goto l334
 				}
 				position++
 				if buffer[position] != rune('c') {
-					goto l299
+					goto l334
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l299
+					goto l334
 				}
 				position++
 				depth--
-				add(ruleReplace, position300)
+				add(ruleReplace, position335)
 			}
 			return true
-		l299:
-			position, tokenIndex, depth = position299, tokenIndex299, depth299
+		l334:
+			position, tokenIndex, depth = position334, tokenIndex334, depth334
 			return false
 		},
-		/* 71 Required <- <('r' 'e' 'q' 'u' 'i' 'r' 'e' 'd')> */
+		/* 75 Required <- <('r' 'e' 'q' 'u' 'i' 'r' 'e' 'd')> */
 		func() bool {
-			position301, tokenIndex301, depth301 := position, tokenIndex, depth
+			position336, tokenIndex336, depth336 := position, tokenIndex, depth
 			{
-				position302 := position
+				position337 := position
 				depth++
 				if buffer[position] != rune('r') {
-					goto l301
+					goto l336
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l301
+					goto l336
 				}
 				position++
 				if buffer[position] != rune('q') {
-					goto l301
+					goto l336
 				}
 				position++
 				if buffer[position] != rune('u') {
-					goto l301
+					goto l336
 				}
 				position++
 				if buffer[position] != rune('i') {
-					goto l301
+					goto l336
 				}
 				position++
 				if buffer[position] != rune('r') {
-					goto l301
+					goto l336
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l301
+					goto l336
 				}
 				position++
 				if buffer[position] != rune('d') {
-					goto l301
+					goto l336
 				}
 				position++
 				depth--
-				add(ruleRequired, position302)
+				add(ruleRequired, position337)
 			}
 			return true
-		l301:
-			position, tokenIndex, depth = position301, tokenIndex301, depth301
+		l336:
+			position, tokenIndex, depth = position336, tokenIndex336, depth336
 			return false
 		},
-		/* 72 On <- <('o' 'n' req_ws Name)> */
+		/* 76 On <- <('o' 'n' req_ws MergeKeys)> */
 		func() bool {
-			position303, tokenIndex303, depth303 := position, tokenIndex, depth
+			position338, tokenIndex338, depth338 := position, tokenIndex, depth
 			{
-				position304 := position
+				position339 := position
 				depth++
 				if buffer[position] != rune('o') {
-					goto l303
+					goto l338
 				}
 				position++
 				if buffer[position] != rune('n') {
-					goto l303
+					goto l338
 				}
 				position++
 				if !_rules[rulereq_ws]() {
-					goto l303
+					goto l338
 				}
-				if !_rules[ruleName]() {
-					goto l303
+				if !_rules[ruleMergeKeys]() {
+					goto l338
 				}
 				depth--
-				add(ruleOn, position304)
+				add(ruleOn, position339)
 			}
 			return true
-		l303:
-			position, tokenIndex, depth = position303, tokenIndex303, depth303
+		l338:
+			position, tokenIndex, depth = position338, tokenIndex338, depth338
+			return false
+		},
+		/* 77 MergeKeys <- <(MergeKey (ws ',' ws MergeKey)*)> */
+		func() bool {
+			position340, tokenIndex340, depth340 := position, tokenIndex, depth
+			{
+				position341 := position
+				depth++
+				if !_rules[ruleMergeKey]() {
+					goto l340
+				}
+			l342:
+				{
+					position343, tokenIndex343, depth343 := position, tokenIndex, depth
+					if !_rules[rulews]() {
+						goto l343
+					}
+					if buffer[position] != rune(',') {
+						goto l343
+					}
+					position++
+					if !_rules[rulews]() {
+						goto l343
+					}
+					if !_rules[ruleMergeKey]() {
+						goto l343
+					}
+					goto l342
+				l343:
+					position, tokenIndex, depth = position343, tokenIndex343, depth343
+				}
+				depth--
+				add(ruleMergeKeys, position341)
+			}
+			return true
+		l340:
+			position, tokenIndex, depth = position340, tokenIndex340, depth340
+			return false
+		},
+		/* 78 MergeKey <- <(([a-z] / [A-Z] / [0-9] / '_') ([a-z] / [A-Z] / [0-9] / '_' / '-')* ('.' ([a-z] / [A-Z] / [0-9] / '_') ([a-z] / [A-Z] / [0-9] / '_' / '-')*)*)> */
+		func() bool {
+			position344, tokenIndex344, depth344 := position, tokenIndex, depth
+			{
+				position345 := position
+				depth++
+				{
+					position346, tokenIndex346, depth346 := position, tokenIndex, depth
+					if c := buffer[position]; c < rune('a') || c > rune('z') {
+						goto l347
+					}
+					position++
+					goto l346
+				l347:
+					position, tokenIndex, depth = position346, tokenIndex346, depth346
+					if c := buffer[position]; c < rune('A') || c > rune('Z') {
+						goto l348
+					}
+					position++
+					goto l346
+				l348:
+					position, tokenIndex, depth = position346, tokenIndex346, depth346
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l349
+					}
+					position++
+					goto l346
+				l349:
+					position, tokenIndex, depth = position346, tokenIndex346, depth346
+					if buffer[position] != rune('_') {
+						goto l344
+					}
+					position++
+				}
+			l346:
+			l350:
+				{
+					position351, tokenIndex351, depth351 := position, tokenIndex, depth
+					{
+						position352, tokenIndex352, depth352 := position, tokenIndex, depth
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l353
+						}
+						position++
+						goto l352
+					l353:
+						position, tokenIndex, depth = position352, tokenIndex352, depth352
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l354
+						}
+						position++
+						goto l352
+					l354:
+						position, tokenIndex, depth = position352, tokenIndex352, depth352
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l355
+						}
+						position++
+						goto l352
+					l355:
+						position, tokenIndex, depth = position352, tokenIndex352, depth352
+						if buffer[position] != rune('_') {
+							goto l356
+						}
+						position++
+						goto l352
+					l356:
+						position, tokenIndex, depth = position352, tokenIndex352, depth352
+						if buffer[position] != rune('-') {
+							goto l351
+						}
+						position++
+					}
+				l352:
+					goto l350
+				l351:
+					position, tokenIndex, depth = position351, tokenIndex351, depth351
+				}
+			l357:
+				{
+					position358, tokenIndex358, depth358 := position, tokenIndex, depth
+					if buffer[position] != rune('.') {
+						goto l358
+					}
+					position++
+					{
+						position359, tokenIndex359, depth359 := position, tokenIndex, depth
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l360
+						}
+						position++
+						goto l359
+					l360:
+						position, tokenIndex, depth = position359, tokenIndex359, depth359
+						if c := buffer[position]; c < rune('A') || c > rune('Z') {
+							goto l361
+						}
+						position++
+						goto l359
+					l361:
+						position, tokenIndex, depth = position359, tokenIndex359, depth359
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l362
+						}
+						position++
+						goto l359
+					l362:
+						position, tokenIndex, depth = position359, tokenIndex359, depth359
+						if buffer[position] != rune('_') {
+							goto l358
+						}
+						position++
+					}
+				l359:
+				l363:
+					{
+						position364, tokenIndex364, depth364 := position, tokenIndex, depth
+						{
+							position365, tokenIndex365, depth365 := position, tokenIndex, depth
+							if c := buffer[position]; c < rune('a') || c > rune('z') {
+								goto l366
+							}
+							position++
+							goto l365
+						l366:
+							position, tokenIndex, depth = position365, tokenIndex365, depth365
+							if c := buffer[position]; c < rune('A') || c > rune('Z') {
+								goto l367
+							}
+							position++
+							goto l365
+						l367:
+							position, tokenIndex, depth = position365, tokenIndex365, depth365
+							if c := buffer[position]; c < rune('0') || c > rune('9') {
+								goto l368
+							}
+							position++
+							goto l365
+						l368:
+							position, tokenIndex, depth = position365, tokenIndex365, depth365
+							if buffer[position] != rune('_') {
+								goto l369
+							}
+							position++
+							goto l365
+						l369:
+							position, tokenIndex, depth = position365, tokenIndex365, depth365
+							if buffer[position] != rune('-') {
+								goto l364
+							}
+							position++
+						}
+					l365:
+						goto l363
+					l364:
+						position, tokenIndex, depth = position364, tokenIndex364, depth364
+					}
+					goto l357
+				l358:
+					position, tokenIndex, depth = position358, tokenIndex358, depth358
+				}
+				depth--
+				add(ruleMergeKey, position345)
+			}
+			return true
+		l344:
+			position, tokenIndex, depth = position344, tokenIndex344, depth344
 			return false
 		},
-		/* 73 Auto <- <('a' 'u' 't' 'o')> */
+		/* 79 Auto <- <('a' 'u' 't' 'o')> */
 		func() bool {
-			position305, tokenIndex305, depth305 := position, tokenIndex, depth
+			position370, tokenIndex370, depth370 := position, tokenIndex, depth
 			{
-				position306 := position
+				position371 := position
 				depth++
 				if buffer[position] != rune('a') {
-					goto l305
+					goto l370
 				}
 				position++
 				if buffer[position] != rune('u') {
-					goto l305
+					goto l370
 				}
 				position++
 				if buffer[position] != rune('t') {
-					goto l305
+					goto l370
 				}
 				position++
 				if buffer[position] != rune('o') {
-					goto l305
+					goto l370
 				}
 				position++
 				depth--
-				add(ruleAuto, position306)
+				add(ruleAuto, position371)
 			}
 			return true
-		l305:
-			position, tokenIndex, depth = position305, tokenIndex305, depth305
+		l370:
+			position, tokenIndex, depth = position370, tokenIndex370, depth370
 			return false
 		},
-		/* 74 Default <- <Action1> */
+		/* 80 Default <- <Action1> */
 		func() bool {
-			position307, tokenIndex307, depth307 := position, tokenIndex, depth
+			position372, tokenIndex372, depth372 := position, tokenIndex, depth
 			{
-				position308 := position
+				position373 := position
 				depth++
 				if !_rules[ruleAction1]() {
-					goto l307
+					goto l372
 				}
 				depth--
-				add(ruleDefault, position308)
+				add(ruleDefault, position373)
 			}
 			return true
-		l307:
-			position, tokenIndex, depth = position307, tokenIndex307, depth307
+		l372:
+			position, tokenIndex, depth = position372, tokenIndex372, depth372
 			return false
 		},
-		/* 75 Sync <- <('s' 'y' 'n' 'c' '[' Level7 ((((LambdaExpr LambdaExt) / (LambdaOrExpr LambdaOrExpr)) (('|' Expression) / Default)) / (LambdaOrExpr Default Default)) ']')> */
+		/* 81 Sync <- <('s' 'y' 'n' 'c' '[' Level7 ((((LambdaExpr LambdaExt) / (LambdaOrExpr LambdaOrExpr)) (('|' Expression) / Default)) / (LambdaOrExpr Default Default)) ']')> */
 		func() bool {
-			position309, tokenIndex309, depth309 := position, tokenIndex, depth
+			position374, tokenIndex374, depth374 := position, tokenIndex, depth
 			{
-				position310 := position
+				position375 := position
 				depth++
 				if buffer[position] != rune('s') {
-					goto l309
+					goto l374
 				}
 				position++
 				if buffer[position] != rune('y') {
-					goto l309
+					goto l374
 				}
 				position++
 				if buffer[position] != rune('n') {
-					goto l309
+					goto l374
 				}
 				position++
 				if buffer[position] != rune('c') {
-					goto l309
+					goto l374
 				}
 				position++
 				if buffer[position] != rune('[') {
-					goto l309
+					goto l374
 				}
 				position++
 				if !_rules[ruleLevel7]() {
-					goto l309
+					goto l374
 				}
 				{
-					position311, tokenIndex311, depth311 := position, tokenIndex, depth
+					position376, tokenIndex376, depth376 := position, tokenIndex, depth
 					{
-						position313, tokenIndex313, depth313 := position, tokenIndex, depth
+						position378, tokenIndex378, depth378 := position, tokenIndex, depth
 						if !_rules[ruleLambdaExpr]() {
-							goto l314
+							goto l379
 						}
 						if !_rules[ruleLambdaExt]() {
-							goto l314
+							goto l379
 						}
-						goto l313
-					l314:
-						position, tokenIndex, depth = position313, tokenIndex313, depth313
+						goto l378
+					l379:
+						position, tokenIndex, depth = position378, tokenIndex378, depth378
 						if !_rules[ruleLambdaOrExpr]() {
-							goto l312
+							goto l377
 						}
 						if !_rules[ruleLambdaOrExpr]() {
-							goto l312
+							goto l377
 						}
 					}
-				l313:
+				l378:
 					{
-						position315, tokenIndex315, depth315 := position, tokenIndex, depth
+						position380, tokenIndex380, depth380 := position, tokenIndex, depth
 						if buffer[position] != rune('|') {
-							goto l316
+							goto l381
 						}
 						position++
 						if !_rules[ruleExpression]() {
-							goto l316
+							goto l381
 						}
-						goto l315
-					l316:
-						position, tokenIndex, depth = position315, tokenIndex315, depth315
+						goto l380
+					l381:
+						position, tokenIndex, depth = position380, tokenIndex380, depth380
 						if !_rules[ruleDefault]() {
-							goto l312
+							goto l377
 						}
 					}
-				l315:
-					goto l311
-				l312:
-					position, tokenIndex, depth = position311, tokenIndex311, depth311
+				l380:
+					goto l376
+				l377:
+					position, tokenIndex, depth = position376, tokenIndex376, depth376
 					if !_rules[ruleLambdaOrExpr]() {
-						goto l309
+						goto l374
 					}
 					if !_rules[ruleDefault]() {
-						goto l309
+						goto l374
 					}
 					if !_rules[ruleDefault]() {
-						goto l309
+						goto l374
 					}
 				}
-			l311:
+			l376:
 				if buffer[position] != rune(']') {
-					goto l309
+					goto l374
 				}
 				position++
 				depth--
-				add(ruleSync, position310)
+				add(ruleSync, position375)
 			}
 			return true
-		l309:
-			position, tokenIndex, depth = position309, tokenIndex309, depth309
+		l374:
+			position, tokenIndex, depth = position374, tokenIndex374, depth374
 			return false
 		},
-		/* 76 LambdaExt <- <(',' Expression)> */
+		/* 82 LambdaExt <- <(',' Expression)> */
 		func() bool {
-			position317, tokenIndex317, depth317 := position, tokenIndex, depth
+			position382, tokenIndex382, depth382 := position, tokenIndex, depth
 			{
-				position318 := position
+				position383 := position
 				depth++
 				if buffer[position] != rune(',') {
-					goto l317
+					goto l382
 				}
 				position++
 				if !_rules[ruleExpression]() {
-					goto l317
+					goto l382
 				}
 				depth--
-				add(ruleLambdaExt, position318)
+				add(ruleLambdaExt, position383)
 			}
 			return true
-		l317:
-			position, tokenIndex, depth = position317, tokenIndex317, depth317
+		l382:
+			position, tokenIndex, depth = position382, tokenIndex382, depth382
 			return false
 		},
-		/* 77 LambdaOrExpr <- <(LambdaExpr / ('|' Expression))> */
+		/* 83 LambdaOrExpr <- <(LambdaExpr / ('|' Expression))> */
 		func() bool {
-			position319, tokenIndex319, depth319 := position, tokenIndex, depth
+			position384, tokenIndex384, depth384 := position, tokenIndex, depth
 			{
-				position320 := position
+				position385 := position
 				depth++
 				{
-					position321, tokenIndex321, depth321 := position, tokenIndex, depth
+					position386, tokenIndex386, depth386 := position, tokenIndex, depth
 					if !_rules[ruleLambdaExpr]() {
-						goto l322
+						goto l387
 					}
-					goto l321
-				l322:
-					position, tokenIndex, depth = position321, tokenIndex321, depth321
+					goto l386
+				l387:
+					position, tokenIndex, depth = position386, tokenIndex386, depth386
 					if buffer[position] != rune('|') {
-						goto l319
+						goto l384
 					}
 					position++
 					if !_rules[ruleExpression]() {
-						goto l319
+						goto l384
 					}
 				}
-			l321:
+			l386:
 				depth--
-				add(ruleLambdaOrExpr, position320)
+				add(ruleLambdaOrExpr, position385)
 			}
 			return true
-		l319:
-			position, tokenIndex, depth = position319, tokenIndex319, depth319
+		l384:
+			position, tokenIndex, depth = position384, tokenIndex384, depth384
 			return false
 		},
-		/* 78 Catch <- <('c' 'a' 't' 'c' 'h' '[' Level7 LambdaOrExpr ']')> */
+		/* 84 Catch <- <('c' 'a' 't' 'c' 'h' '[' Level7 LambdaOrExpr ']')> */
 		func() bool {
-			position323, tokenIndex323, depth323 := position, tokenIndex, depth
+			position388, tokenIndex388, depth388 := position, tokenIndex, depth
 			{
-				position324 := position
+				position389 := position
 				depth++
 				if buffer[position] != rune('c') {
-					goto l323
+					goto l388
 				}
 				position++
 				if buffer[position] != rune('a') {
-					goto l323
+					goto l388
 				}
 				position++
 				if buffer[position] != rune('t') {
-					goto l323
+					goto l388
 				}
 				position++
 				if buffer[position] != rune('c') {
-					goto l323
+					goto l388
 				}
 				position++
 				if buffer[position] != rune('h') {
-					goto l323
+					goto l388
 				}
 				position++
 				if buffer[position] != rune('[') {
-					goto l323
+					goto l388
 				}
 				position++
 				if !_rules[ruleLevel7]() {
-					goto l323
+					goto l388
 				}
 				if !_rules[ruleLambdaOrExpr]() {
-					goto l323
+					goto l388
 				}
 				if buffer[position] != rune(']') {
-					goto l323
+					goto l388
 				}
 				position++
 				depth--
-				add(ruleCatch, position324)
+				add(ruleCatch, position389)
 			}
 			return true
-		l323:
-			position, tokenIndex, depth = position323, tokenIndex323, depth323
+		l388:
+			position, tokenIndex, depth = position388, tokenIndex388, depth388
 			return false
 		},
-		/* 79 FilterList <- <('f' 'i' 'l' 't' 'e' 'r' '[' Level7 LambdaOrExpr ']')> */
+		/* 85 FilterList <- <('f' 'i' 'l' 't' 'e' 'r' '[' Level7 LambdaOrExpr ']')> */
 		func() bool {
-			position325, tokenIndex325, depth325 := position, tokenIndex, depth
+			position390, tokenIndex390, depth390 := position, tokenIndex, depth
 			{
-				position326 := position
+				position391 := position
 				depth++
 				if buffer[position] != rune('f') {
-					goto l325
+					goto l390
 				}
 				position++
 				if buffer[position] != rune('i') {
-					goto l325
+					goto l390
 				}
 				position++
 				if buffer[position] != rune('l') {
-					goto l325
+					goto l390
 				}
 				position++
 				if buffer[position] != rune('t') {
-					goto l325
+					goto l390
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l325
+					goto l390
 				}
 				position++
 				if buffer[position] != rune('r') {
-					goto l325
+					goto l390
 				}
 				position++
 				if buffer[position] != rune('[') {
-					goto l325
+					goto l390
 				}
 				position++
 				if !_rules[ruleLevel7]() {
-					goto l325
+					goto l390
 				}
 				if !_rules[ruleLambdaOrExpr]() {
-					goto l325
+					goto l390
 				}
 				if buffer[position] != rune(']') {
-					goto l325
+					goto l390
 				}
 				position++
 				depth--
-				add(ruleFilterList, position326)
+				add(ruleFilterList, position391)
 			}
 			return true
-		l325:
-			position, tokenIndex, depth = position325, tokenIndex325, depth325
+		l390:
+			position, tokenIndex, depth = position390, tokenIndex390, depth390
 			return false
 		},
-		/* 80 FilterMap <- <('f' 'i' 'l' 't' 'e' 'r' '{' Level7 LambdaOrExpr '}')> */
+		/* 86 FilterMap <- <('f' 'i' 'l' 't' 'e' 'r' '{' Level7 LambdaOrExpr '}')> */
 		func() bool {
-			position327, tokenIndex327, depth327 := position, tokenIndex, depth
+			position392, tokenIndex392, depth392 := position, tokenIndex, depth
 			{
-				position328 := position
+				position393 := position
 				depth++
 				if buffer[position] != rune('f') {
-					goto l327
+					goto l392
 				}
 				position++
 				if buffer[position] != rune('i') {
-					goto l327
+					goto l392
 				}
 				position++
 				if buffer[position] != rune('l') {
-					goto l327
+					goto l392
 				}
 				position++
 				if buffer[position] != rune('t') {
-					goto l327
+					goto l392
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l327
+					goto l392
 				}
 				position++
 				if buffer[position] != rune('r') {
-					goto l327
+					goto l392
 				}
 				position++
 				if buffer[position] != rune('{') {
-					goto l327
+					goto l392
 				}
 				position++
 				if !_rules[ruleLevel7]() {
-					goto l327
+					goto l392
 				}
 				if !_rules[ruleLambdaOrExpr]() {
-					goto l327
+					goto l392
 				}
 				if buffer[position] != rune('}') {
-					goto l327
+					goto l392
 				}
 				position++
 				depth--
-				add(ruleFilterMap, position328)
+				add(ruleFilterMap, position393)
 			}
 			return true
-		l327:
-			position, tokenIndex, depth = position327, tokenIndex327, depth327
+		l392:
+			position, tokenIndex, depth = position392, tokenIndex392, depth392
 			return false
 		},
-		/* 81 MapMapping <- <('m' 'a' 'p' '{' Level7 LambdaOrExpr '}')> */
+		/* 87 MapMapping <- <('m' 'a' 'p' '{' Level7 LambdaOrExpr '}')> */
 		func() bool {
-			position329, tokenIndex329, depth329 := position, tokenIndex, depth
+			position394, tokenIndex394, depth394 := position, tokenIndex, depth
 			{
-				position330 := position
+				position395 := position
 				depth++
 				if buffer[position] != rune('m') {
-					goto l329
+					goto l394
 				}
 				position++
 				if buffer[position] != rune('a') {
-					goto l329
+					goto l394
 				}
 				position++
 				if buffer[position] != rune('p') {
-					goto l329
+					goto l394
 				}
 				position++
 				if buffer[position] != rune('{') {
-					goto l329
+					goto l394
 				}
 				position++
 				if !_rules[ruleLevel7]() {
-					goto l329
+					goto l394
 				}
 				if !_rules[ruleLambdaOrExpr]() {
-					goto l329
+					goto l394
 				}
 				if buffer[position] != rune('}') {
-					goto l329
+					goto l394
 				}
 				position++
 				depth--
-				add(ruleMapMapping, position330)
+				add(ruleMapMapping, position395)
 			}
 			return true
-		l329:
-			position, tokenIndex, depth = position329, tokenIndex329, depth329
+		l394:
+			position, tokenIndex, depth = position394, tokenIndex394, depth394
 			return false
 		},
-		/* 82 Mapping <- <('m' 'a' 'p' '[' Level7 LambdaOrExpr ']')> */
+		/* 88 Mapping <- <('m' 'a' 'p' '[' Level7 LambdaOrExpr ']')> */
 		func() bool {
-			position331, tokenIndex331, depth331 := position, tokenIndex, depth
+			position396, tokenIndex396, depth396 := position, tokenIndex, depth
 			{
-				position332 := position
+				position397 := position
 				depth++
 				if buffer[position] != rune('m') {
-					goto l331
+					goto l396
 				}
 				position++
 				if buffer[position] != rune('a') {
-					goto l331
+					goto l396
 				}
 				position++
 				if buffer[position] != rune('p') {
-					goto l331
+					goto l396
 				}
 				position++
 				if buffer[position] != rune('[') {
-					goto l331
+					goto l396
 				}
 				position++
 				if !_rules[ruleLevel7]() {
-					goto l331
+					goto l396
 				}
 				if !_rules[ruleLambdaOrExpr]() {
-					goto l331
+					goto l396
 				}
 				if buffer[position] != rune(']') {
-					goto l331
+					goto l396
 				}
 				position++
 				depth--
-				add(ruleMapping, position332)
+				add(ruleMapping, position397)
 			}
 			return true
-		l331:
-			position, tokenIndex, depth = position331, tokenIndex331, depth331
+		l396:
+			position, tokenIndex, depth = position396, tokenIndex396, depth396
 			return false
 		},
-		/* 83 MapSelection <- <('s' 'e' 'l' 'e' 'c' 't' '{' Level7 LambdaOrExpr '}')> */
+		/* 89 MapSelection <- <('s' 'e' 'l' 'e' 'c' 't' '{' Level7 LambdaOrExpr '}')> */
 		func() bool {
-			position333, tokenIndex333, depth333 := position, tokenIndex, depth
+			position398, tokenIndex398, depth398 := position, tokenIndex, depth
 			{
-				position334 := position
+				position399 := position
 				depth++
 				if buffer[position] != rune('s') {
-					goto l333
+					goto l398
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l333
+					goto l398
 				}
 				position++
 				if buffer[position] != rune('l') {
-					goto l333
+					goto l398
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l333
+					goto l398
 				}
 				position++
 				if buffer[position] != rune('c') {
-					goto l333
+					goto l398
 				}
 				position++
 				if buffer[position] != rune('t') {
-					goto l333
+					goto l398
 				}
 				position++
 				if buffer[position] != rune('{') {
-					goto l333
+					goto l398
 				}
 				position++
 				if !_rules[ruleLevel7]() {
-					goto l333
+					goto l398
 				}
 				if !_rules[ruleLambdaOrExpr]() {
-					goto l333
+					goto l398
 				}
 				if buffer[position] != rune('}') {
-					goto l333
+					goto l398
 				}
 				position++
 				depth--
-				add(ruleMapSelection, position334)
+				add(ruleMapSelection, position399)
 			}
 			return true
-		l333:
-			position, tokenIndex, depth = position333, tokenIndex333, depth333
+		l398:
+			position, tokenIndex, depth = position398, tokenIndex398, depth398
 			return false
 		},
-		/* 84 Selection <- <('s' 'e' 'l' 'e' 'c' 't' '[' Level7 LambdaOrExpr ']')> */
+		/* 90 Selection <- <('s' 'e' 'l' 'e' 'c' 't' '[' Level7 LambdaOrExpr ']')> */
 		func() bool {
-			position335, tokenIndex335, depth335 := position, tokenIndex, depth
+			position400, tokenIndex400, depth400 := position, tokenIndex, depth
 			{
-				position336 := position
+				position401 := position
 				depth++
 				if buffer[position] != rune('s') {
-					goto l335
+					goto l400
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l335
+					goto l400
 				}
 				position++
 				if buffer[position] != rune('l') {
-					goto l335
+					goto l400
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l335
+					goto l400
 				}
 				position++
 				if buffer[position] != rune('c') {
-					goto l335
+					goto l400
 				}
 				position++
 				if buffer[position] != rune('t') {
-					goto l335
+					goto l400
 				}
 				position++
 				if buffer[position] != rune('[') {
-					goto l335
+					goto l400
 				}
 				position++
 				if !_rules[ruleLevel7]() {
-					goto l335
+					goto l400
 				}
 				if !_rules[ruleLambdaOrExpr]() {
-					goto l335
+					goto l400
 				}
 				if buffer[position] != rune(']') {
-					goto l335
+					goto l400
 				}
 				position++
 				depth--
-				add(ruleSelection, position336)
+				add(ruleSelection, position401)
 			}
 			return true
-		l335:
-			position, tokenIndex, depth = position335, tokenIndex335, depth335
+		l400:
+			position, tokenIndex, depth = position400, tokenIndex400, depth400
 			return false
 		},
-		/* 85 Sum <- <('s' 'u' 'm' '[' Level7 '|' Level7 LambdaOrExpr ']')> */
+		/* 91 Sum <- <('s' 'u' 'm' '[' Level7 '|' Level7 LambdaOrExpr ']')> */
 		func() bool {
-			position337, tokenIndex337, depth337 := position, tokenIndex, depth
+			position402, tokenIndex402, depth402 := position, tokenIndex, depth
 			{
-				position338 := position
+				position403 := position
 				depth++
 				if buffer[position] != rune('s') {
-					goto l337
+					goto l402
 				}
 				position++
 				if buffer[position] != rune('u') {
-					goto l337
+					goto l402
 				}
 				position++
 				if buffer[position] != rune('m') {
-					goto l337
+					goto l402
 				}
 				position++
 				if buffer[position] != rune('[') {
-					goto l337
+					goto l402
 				}
 				position++
 				if !_rules[ruleLevel7]() {
-					goto l337
+					goto l402
 				}
 				if buffer[position] != rune('|') {
-					goto l337
+					goto l402
 				}
 				position++
 				if !_rules[ruleLevel7]() {
-					goto l337
+					goto l402
 				}
 				if !_rules[ruleLambdaOrExpr]() {
-					goto l337
+					goto l402
 				}
 				if buffer[position] != rune(']') {
-					goto l337
+					goto l402
 				}
 				position++
 				depth--
-				add(ruleSum, position338)
+				add(ruleSum, position403)
 			}
 			return true
-		l337:
-			position, tokenIndex, depth = position337, tokenIndex337, depth337
+		l402:
+			position, tokenIndex, depth = position402, tokenIndex402, depth402
 			return false
 		},
-		/* 86 Lambda <- <('l' 'a' 'm' 'b' 'd' 'a' (LambdaRef / LambdaExpr))> */
+		/* 92 Lambda <- <('l' 'a' 'm' 'b' 'd' 'a' (LambdaRef / LambdaExpr))> */
 		func() bool {
-			position339, tokenIndex339, depth339 := position, tokenIndex, depth
+			position404, tokenIndex404, depth404 := position, tokenIndex, depth
 			{
-				position340 := position
+				position405 := position
 				depth++
 				if buffer[position] != rune('l') {
-					goto l339
+					goto l404
 				}
 				position++
 				if buffer[position] != rune('a') {
-					goto l339
+					goto l404
 				}
 				position++
 				if buffer[position] != rune('m') {
-					goto l339
+					goto l404
 				}
 				position++
 				if buffer[position] != rune('b') {
-					goto l339
+					goto l404
 				}
 				position++
 				if buffer[position] != rune('d') {
-					goto l339
+					goto l404
 				}
 				position++
 				if buffer[position] != rune('a') {
-					goto l339
+					goto l404
 				}
 				position++
 				{
-					position341, tokenIndex341, depth341 := position, tokenIndex, depth
+					position406, tokenIndex406, depth406 := position, tokenIndex, depth
 					if !_rules[ruleLambdaRef]() {
-						goto l342
+						goto l407
 					}
-					goto l341
-				l342:
-					position, tokenIndex, depth = position341, tokenIndex341, depth341
+					goto l406
+				l407:
+					position, tokenIndex, depth = position406, tokenIndex406, depth406
 					if !_rules[ruleLambdaExpr]() {
-						goto l339
+						goto l404
 					}
 				}
-			l341:
+			l406:
 				depth--
-				add(ruleLambda, position340)
+				add(ruleLambda, position405)
 			}
 			return true
-		l339:
-			position, tokenIndex, depth = position339, tokenIndex339, depth339
+		l404:
+			position, tokenIndex, depth = position404, tokenIndex404, depth404
 			return false
 		},
-		/* 87 LambdaRef <- <(req_ws Expression)> */
+		/* 93 LambdaRef <- <(req_ws Expression)> */
 		func() bool {
-			position343, tokenIndex343, depth343 := position, tokenIndex, depth
+			position408, tokenIndex408, depth408 := position, tokenIndex, depth
 			{
-				position344 := position
+				position409 := position
 				depth++
 				if !_rules[rulereq_ws]() {
-					goto l343
+					goto l408
 				}
 				if !_rules[ruleExpression]() {
-					goto l343
+					goto l408
 				}
 				depth--
-				add(ruleLambdaRef, position344)
+				add(ruleLambdaRef, position409)
 			}
 			return true
-		l343:
-			position, tokenIndex, depth = position343, tokenIndex343, depth343
+		l408:
+			position, tokenIndex, depth = position408, tokenIndex408, depth408
 			return false
 		},
-		/* 88 LambdaExpr <- <(ws Params ws ('-' '>') Expression)> */
+		/* 94 LambdaExpr <- <(ws Params ws ('-' '>') Expression)> */
 		func() bool {
-			position345, tokenIndex345, depth345 := position, tokenIndex, depth
+			position410, tokenIndex410, depth410 := position, tokenIndex, depth
 			{
-				position346 := position
+				position411 := position
 				depth++
 				if !_rules[rulews]() {
-					goto l345
+					goto l410
 				}
 				if !_rules[ruleParams]() {
-					goto l345
+					goto l410
 				}
 				if !_rules[rulews]() {
-					goto l345
+					goto l410
 				}
 				if buffer[position] != rune('-') {
-					goto l345
+					goto l410
 				}
 				position++
 				if buffer[position] != rune('>') {
-					goto l345
+					goto l410
 				}
 				position++
 				if !_rules[ruleExpression]() {
-					goto l345
+					goto l410
 				}
 				depth--
-				add(ruleLambdaExpr, position346)
+				add(ruleLambdaExpr, position411)
 			}
 			return true
-		l345:
-			position, tokenIndex, depth = position345, tokenIndex345, depth345
+		l410:
+			position, tokenIndex, depth = position410, tokenIndex410, depth410
 			return false
 		},
-		/* 89 Params <- <('|' StartParams ws Names? '|')> */
+		/* 95 Params <- <('|' StartParams ws Names? '|')> */
 		func() bool {
-			position347, tokenIndex347, depth347 := position, tokenIndex, depth
+			position412, tokenIndex412, depth412 := position, tokenIndex, depth
 			{
-				position348 := position
+				position413 := position
 				depth++
 				if buffer[position] != rune('|') {
-					goto l347
+					goto l412
 				}
 				position++
 				if !_rules[ruleStartParams]() {
-					goto l347
+					goto l412
 				}
 				if !_rules[rulews]() {
-					goto l347
+					goto l412
 				}
 				{
-					position349, tokenIndex349, depth349 := position, tokenIndex, depth
+					position414, tokenIndex414, depth414 := position, tokenIndex, depth
 					if !_rules[ruleNames]() {
-						goto l349
+						goto l414
 					}
-					goto l350
-				l349:
-					position, tokenIndex, depth = position349, tokenIndex349, depth349
+					goto l415
+				l414:
+					position, tokenIndex, depth = position414, tokenIndex414, depth414
 				}
-			l350:
+			l415:
 				if buffer[position] != rune('|') {
-					goto l347
+					goto l412
 				}
 				position++
 				depth--
-				add(ruleParams, position348)
+				add(ruleParams, position413)
 			}
 			return true
-		l347:
-			position, tokenIndex, depth = position347, tokenIndex347, depth347
+		l412:
+			position, tokenIndex, depth = position412, tokenIndex412, depth412
 			return false
 		},
-		/* 90 StartParams <- <Action2> */
+		/* 96 StartParams <- <Action2> */
 		func() bool {
-			position351, tokenIndex351, depth351 := position, tokenIndex, depth
+			position416, tokenIndex416, depth416 := position, tokenIndex, depth
 			{
-				position352 := position
+				position417 := position
 				depth++
 				if !_rules[ruleAction2]() {
-					goto l351
+					goto l416
 				}
 				depth--
-				add(ruleStartParams, position352)
+				add(ruleStartParams, position417)
 			}
 			return true
-		l351:
-			position, tokenIndex, depth = position351, tokenIndex351, depth351
+		l416:
+			position, tokenIndex, depth = position416, tokenIndex416, depth416
 			return false
 		},
-		/* 91 Names <- <(NextName (',' NextName)* DefaultValue? (',' NextName DefaultValue)* VarParams?)> */
+		/* 97 Names <- <(NextName (',' NextName)* DefaultValue? (',' NextName DefaultValue)* VarParams?)> */
 		func() bool {
-			position353, tokenIndex353, depth353 := position, tokenIndex, depth
+			position418, tokenIndex418, depth418 := position, tokenIndex, depth
 			{
-				position354 := position
+				position419 := position
 				depth++
 				if !_rules[ruleNextName]() {
-					goto l353
+					goto l418
 				}
-			l355:
+			l420:
 				{
-					position356, tokenIndex356, depth356 := position, tokenIndex, depth
+					position421, tokenIndex421, depth421 := position, tokenIndex, depth
 					if buffer[position] != rune(',') {
-						goto l356
+						goto l421
 					}
 					position++
 					if !_rules[ruleNextName]() {
-						goto l356
+						goto l421
 					}
-					goto l355
-				l356:
-					position, tokenIndex, depth = position356, tokenIndex356, depth356
+					goto l420
+				l421:
+					position, tokenIndex, depth = position421, tokenIndex421, depth421
 				}
 				{
-					position357, tokenIndex357, depth357 := position, tokenIndex, depth
+					position422, tokenIndex422, depth422 := position, tokenIndex, depth
 					if !_rules[ruleDefaultValue]() {
-						goto l357
+						goto l422
 					}
-					goto l358
-				l357:
-					position, tokenIndex, depth = position357, tokenIndex357, depth357
+					goto l423
+				l422:
+					position, tokenIndex, depth = position422, tokenIndex422, depth422
 				}
-			l358:
-			l359:
+			l423:
+			l424:
 				{
-					position360, tokenIndex360, depth360 := position, tokenIndex, depth
+					position425, tokenIndex425, depth425 := position, tokenIndex, depth
 					if buffer[position] != rune(',') {
-						goto l360
+						goto l425
 					}
 					position++
 					if !_rules[ruleNextName]() {
-						goto l360
+						goto l425
 					}
 					if !_rules[ruleDefaultValue]() {
-						goto l360
+						goto l425
 					}
-					goto l359
-				l360:
-					position, tokenIndex, depth = position360, tokenIndex360, depth360
+					goto l424
+				l425:
+					position, tokenIndex, depth = position425, tokenIndex425, depth425
 				}
 				{
-					position361, tokenIndex361, depth361 := position, tokenIndex, depth
+					position426, tokenIndex426, depth426 := position, tokenIndex, depth
 					if !_rules[ruleVarParams]() {
-						goto l361
+						goto l426
 					}
-					goto l362
-				l361:
-					position, tokenIndex, depth = position361, tokenIndex361, depth361
+					goto l427
+				l426:
+					position, tokenIndex, depth = position426, tokenIndex426, depth426
 				}
-			l362:
+			l427:
 				depth--
-				add(ruleNames, position354)
+				add(ruleNames, position419)
 			}
 			return true
-		l353:
-			position, tokenIndex, depth = position353, tokenIndex353, depth353
+		l418:
+			position, tokenIndex, depth = position418, tokenIndex418, depth418
 			return false
 		},
-		/* 92 NextName <- <(ws Name ws)> */
+		/* 98 NextName <- <(ws Name ws)> */
 		func() bool {
-			position363, tokenIndex363, depth363 := position, tokenIndex, depth
+			position428, tokenIndex428, depth428 := position, tokenIndex, depth
 			{
-				position364 := position
+				position429 := position
 				depth++
 				if !_rules[rulews]() {
-					goto l363
+					goto l428
 				}
 				if !_rules[ruleName]() {
-					goto l363
+					goto l428
 				}
 				if !_rules[rulews]() {
-					goto l363
+					goto l428
 				}
 				depth--
-				add(ruleNextName, position364)
+				add(ruleNextName, position429)
 			}
 			return true
-		l363:
-			position, tokenIndex, depth = position363, tokenIndex363, depth363
+		l428:
+			position, tokenIndex, depth = position428, tokenIndex428, depth428
 			return false
 		},
-		/* 93 Name <- <([a-z] / [A-Z] / [0-9] / '_')+> */
+		/* 99 Name <- <([a-z] / [A-Z] / [0-9] / '_')+> */
 		func() bool {
-			position365, tokenIndex365, depth365 := position, tokenIndex, depth
+			position430, tokenIndex430, depth430 := position, tokenIndex, depth
 			{
-				position366 := position
+				position431 := position
 				depth++
 				{
-					position369, tokenIndex369, depth369 := position, tokenIndex, depth
+					position434, tokenIndex434, depth434 := position, tokenIndex, depth
 					if c := buffer[position]; c < rune('a') || c > rune('z') {
-						goto l370
+						goto l435
 					}
 					position++
-					goto l369
-				l370:
-					position, tokenIndex, depth = position369, tokenIndex369, depth369
+					goto l434
+				l435:
+					position, tokenIndex, depth = position434, tokenIndex434, depth434
 					if c := buffer[position]; c < rune('A') || c > rune('Z') {
-						goto l371
+						goto l436
 					}
 					position++
-					goto l369
-				l371:
-					position, tokenIndex, depth = position369, tokenIndex369, depth369
+					goto l434
+				l436:
+					position, tokenIndex, depth = position434, tokenIndex434, depth434
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l372
+						goto l437
 					}
 					position++
-					goto l369
-				l372:
-					position, tokenIndex, depth = position369, tokenIndex369, depth369
+					goto l434
+				l437:
+					position, tokenIndex, depth = position434, tokenIndex434, depth434
 					if buffer[position] != rune('_') {
-						goto l365
+						goto l430
 					}
 					position++
 				}
-			l369:
-			l367:
+			l434:
+			l432:
 				{
-					position368, tokenIndex368, depth368 := position, tokenIndex, depth
+					position433, tokenIndex433, depth433 := position, tokenIndex, depth
 					{
-						position373, tokenIndex373, depth373 := position, tokenIndex, depth
+						position438, tokenIndex438, depth438 := position, tokenIndex, depth
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l374
+							goto l439
 						}
 						position++
-						goto l373
-					l374:
-						position, tokenIndex, depth = position373, tokenIndex373, depth373
+						goto l438
+					l439:
+						position, tokenIndex, depth = position438, tokenIndex438, depth438
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l375
+							goto l440
 						}
 						position++
-						goto l373
-					l375:
-						position, tokenIndex, depth = position373, tokenIndex373, depth373
+						goto l438
+					l440:
+						position, tokenIndex, depth = position438, tokenIndex438, depth438
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l376
+							goto l441
 						}
 						position++
-						goto l373
-					l376:
-						position, tokenIndex, depth = position373, tokenIndex373, depth373
+						goto l438
+					l441:
+						position, tokenIndex, depth = position438, tokenIndex438, depth438
 						if buffer[position] != rune('_') {
-							goto l368
+							goto l433
 						}
 						position++
 					}
-				l373:
-					goto l367
-				l368:
-					position, tokenIndex, depth = position368, tokenIndex368, depth368
+				l438:
+					goto l432
+				l433:
+					position, tokenIndex, depth = position433, tokenIndex433, depth433
 				}
 				depth--
-				add(ruleName, position366)
+				add(ruleName, position431)
 			}
 			return true
-		l365:
-			position, tokenIndex, depth = position365, tokenIndex365, depth365
+		l430:
+			position, tokenIndex, depth = position430, tokenIndex430, depth430
 			return false
 		},
-		/* 94 DefaultValue <- <('=' Expression)> */
+		/* 100 DefaultValue <- <('=' Expression)> */
 		func() bool {
-			position377, tokenIndex377, depth377 := position, tokenIndex, depth
+			position442, tokenIndex442, depth442 := position, tokenIndex, depth
 			{
-				position378 := position
+				position443 := position
 				depth++
 				if buffer[position] != rune('=') {
-					goto l377
+					goto l442
 				}
 				position++
 				if !_rules[ruleExpression]() {
-					goto l377
+					goto l442
 				}
 				depth--
-				add(ruleDefaultValue, position378)
+				add(ruleDefaultValue, position443)
 			}
 			return true
-		l377:
-			position, tokenIndex, depth = position377, tokenIndex377, depth377
+		l442:
+			position, tokenIndex, depth = position442, tokenIndex442, depth442
 			return false
 		},
-		/* 95 VarParams <- <('.' '.' '.' ws)> */
+		/* 101 VarParams <- <('.' '.' '.' ws)> */
 		func() bool {
-			position379, tokenIndex379, depth379 := position, tokenIndex, depth
+			position444, tokenIndex444, depth444 := position, tokenIndex, depth
 			{
-				position380 := position
+				position445 := position
 				depth++
 				if buffer[position] != rune('.') {
-					goto l379
+					goto l444
 				}
 				position++
 				if buffer[position] != rune('.') {
-					goto l379
+					goto l444
 				}
 				position++
 				if buffer[position] != rune('.') {
-					goto l379
+					goto l444
 				}
 				position++
 				if !_rules[rulews]() {
-					goto l379
+					goto l444
 				}
 				depth--
-				add(ruleVarParams, position380)
+				add(ruleVarParams, position445)
 			}
 			return true
-		l379:
-			position, tokenIndex, depth = position379, tokenIndex379, depth379
+		l444:
+			position, tokenIndex, depth = position444, tokenIndex444, depth444
 			return false
 		},
-		/* 96 Reference <- <(((TagPrefix ('.' / Key)) / ('.'? Key)) FollowUpRef)> */
+		/* 102 Reference <- <(((TagPrefix ('.' / Key)) / ('.'? Key)) FollowUpRef)> */
 		func() bool {
-			position381, tokenIndex381, depth381 := position, tokenIndex, depth
+			position446, tokenIndex446, depth446 := position, tokenIndex, depth
 			{
-				position382 := position
+				position447 := position
 				depth++
 				{
-					position383, tokenIndex383, depth383 := position, tokenIndex, depth
+					position448, tokenIndex448, depth448 := position, tokenIndex, depth
 					if !_rules[ruleTagPrefix]() {
-						goto l384
+						goto l449
 					}
 					{
-						position385, tokenIndex385, depth385 := position, tokenIndex, depth
+						position450, tokenIndex450, depth450 := position, tokenIndex, depth
 						if buffer[position] != rune('.') {
-							goto l386
+							goto l451
 						}
 						position++
-						goto l385
-					l386:
-						position, tokenIndex, depth = position385, tokenIndex385, depth385
+						goto l450
+					l451:
+						position, tokenIndex, depth = position450, tokenIndex450, depth450
 						if !_rules[ruleKey]() {
-							goto l384
+							goto l449
 						}
 					}
-				l385:
-					goto l383
-				l384:
-					position, tokenIndex, depth = position383, tokenIndex383, depth383
+				l450:
+					goto l448
+				l449:
+					position, tokenIndex, depth = position448, tokenIndex448, depth448
 					{
-						position387, tokenIndex387, depth387 := position, tokenIndex, depth
+						position452, tokenIndex452, depth452 := position, tokenIndex, depth
 						if buffer[position] != rune('.') {
-							goto l387
+							goto l452
 						}
 						position++
-						goto l388
-					l387:
-						position, tokenIndex, depth = position387, tokenIndex387, depth387
+						goto l453
+					l452:
+						position, tokenIndex, depth = position452, tokenIndex452, depth452
 					}
-				l388:
+				l453:
 					if !_rules[ruleKey]() {
-						goto l381
+						goto l446
 					}
 				}
-			l383:
+			l448:
 				if !_rules[ruleFollowUpRef]() {
-					goto l381
+					goto l446
 				}
 				depth--
-				add(ruleReference, position382)
+				add(ruleReference, position447)
 			}
 			return true
-		l381:
-			position, tokenIndex, depth = position381, tokenIndex381, depth381
+		l446:
+			position, tokenIndex, depth = position446, tokenIndex446, depth446
 			return false
 		},
-		/* 97 TagPrefix <- <((('d' 'o' 'c' ('.' / ':') '-'? [0-9]+) / Tag) (':' ':'))> */
+		/* 103 TagPrefix <- <((('d' 'o' 'c' ('.' / ':') '-'? [0-9]+) / Tag) (':' ':'))> */
 		func() bool {
-			position389, tokenIndex389, depth389 := position, tokenIndex, depth
+			position454, tokenIndex454, depth454 := position, tokenIndex, depth
 			{
-				position390 := position
+				position455 := position
 				depth++
 				{
-					position391, tokenIndex391, depth391 := position, tokenIndex, depth
+					position456, tokenIndex456, depth456 := position, tokenIndex, depth
 					if buffer[position] != rune('d') {
-						goto l392
+						goto l457
 					}
 					position++
 					if buffer[position] != rune('o') {
-						goto l392
+						goto l457
 					}
 					position++
 					if buffer[position] != rune('c') {
-						goto l392
+						goto l457
 					}
 					position++
 					{
-						position393, tokenIndex393, depth393 := position, tokenIndex, depth
+						position458, tokenIndex458, depth458 := position, tokenIndex, depth
 						if buffer[position] != rune('.') {
-							goto l394
+							goto l459
 						}
 						position++
-						goto l393
-					l394:
-						position, tokenIndex, depth = position393, tokenIndex393, depth393
+						goto l458
+					l459:
+						position, tokenIndex, depth = position458, tokenIndex458, depth458
 						if buffer[position] != rune(':') {
-							goto l392
+							goto l457
 						}
 						position++
 					}
-				l393:
+				l458:
 					{
-						position395, tokenIndex395, depth395 := position, tokenIndex, depth
+						position460, tokenIndex460, depth460 := position, tokenIndex, depth
 						if buffer[position] != rune('-') {
-							goto l395
+							goto l460
 						}
 						position++
-						goto l396
-					l395:
-						position, tokenIndex, depth = position395, tokenIndex395, depth395
+						goto l461
+					l460:
+						position, tokenIndex, depth = position460, tokenIndex460, depth460
 					}
-				l396:
+				l461:
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l392
+						goto l457
 					}
 					position++
-				l397:
+				l462:
 					{
-						position398, tokenIndex398, depth398 := position, tokenIndex, depth
+						position463, tokenIndex463, depth463 := position, tokenIndex, depth
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l398
+							goto l463
 						}
 						position++
-						goto l397
-					l398:
-						position, tokenIndex, depth = position398, tokenIndex398, depth398
+						goto l462
+					l463:
+						position, tokenIndex, depth = position463, tokenIndex463, depth463
 					}
-					goto l391
-				l392:
-					position, tokenIndex, depth = position391, tokenIndex391, depth391
+					goto l456
+				l457:
+					position, tokenIndex, depth = position456, tokenIndex456, depth456
 					if !_rules[ruleTag]() {
-						goto l389
+						goto l454
 					}
 				}
-			l391:
+			l456:
 				if buffer[position] != rune(':') {
-					goto l389
+					goto l454
 				}
 				position++
 				if buffer[position] != rune(':') {
-					goto l389
+					goto l454
 				}
 				position++
 				depth--
-				add(ruleTagPrefix, position390)
+				add(ruleTagPrefix, position455)
 			}
 			return true
-		l389:
-			position, tokenIndex, depth = position389, tokenIndex389, depth389
+		l454:
+			position, tokenIndex, depth = position454, tokenIndex454, depth454
 			return false
 		},
-		/* 98 Tag <- <(TagComponent (('.' / ':') TagComponent)*)> */
+		/* 104 Tag <- <(TagComponent (('.' / ':') TagComponent)*)> */
 		func() bool {
-			position399, tokenIndex399, depth399 := position, tokenIndex, depth
+			position464, tokenIndex464, depth464 := position, tokenIndex, depth
 			{
-				position400 := position
+				position465 := position
 				depth++
 				if !_rules[ruleTagComponent]() {
-					goto l399
+					goto l464
 				}
-			l401:
+			l466:
 				{
-					position402, tokenIndex402, depth402 := position, tokenIndex, depth
+					position467, tokenIndex467, depth467 := position, tokenIndex, depth
 					{
-						position403, tokenIndex403, depth403 := position, tokenIndex, depth
+						position468, tokenIndex468, depth468 := position, tokenIndex, depth
 						if buffer[position] != rune('.') {
-							goto l404
+							goto l469
 						}
 						position++
-						goto l403
-					l404:
-						position, tokenIndex, depth = position403, tokenIndex403, depth403
+						goto l468
+					l469:
+						position, tokenIndex, depth = position468, tokenIndex468, depth468
 						if buffer[position] != rune(':') {
-							goto l402
+							goto l467
 						}
 						position++
 					}
-				l403:
+				l468:
 					if !_rules[ruleTagComponent]() {
-						goto l402
+						goto l467
 					}
-					goto l401
-				l402:
-					position, tokenIndex, depth = position402, tokenIndex402, depth402
+					goto l466
+				l467:
+					position, tokenIndex, depth = position467, tokenIndex467, depth467
 				}
 				depth--
-				add(ruleTag, position400)
+				add(ruleTag, position465)
 			}
 			return true
-		l399:
-			position, tokenIndex, depth = position399, tokenIndex399, depth399
+		l464:
+			position, tokenIndex, depth = position464, tokenIndex464, depth464
 			return false
 		},
-		/* 99 TagComponent <- <(([a-z] / [A-Z] / '_') ([a-z] / [A-Z] / [0-9] / '_')*)> */
+		/* 105 TagComponent <- <(([a-z] / [A-Z] / '_') ([a-z] / [A-Z] / [0-9] / '_')*)> */
 		func() bool {
-			position405, tokenIndex405, depth405 := position, tokenIndex, depth
+			position470, tokenIndex470, depth470 := position, tokenIndex, depth
 			{
-				position406 := position
+				position471 := position
 				depth++
 				{
-					position407, tokenIndex407, depth407 := position, tokenIndex, depth
+					position472, tokenIndex472, depth472 := position, tokenIndex, depth
 					if c := buffer[position]; c < rune('a') || c > rune('z') {
-						goto l408
+						goto l473
 					}
 					position++
-					goto l407
-				l408:
-					position, tokenIndex, depth = position407, tokenIndex407, depth407
+					goto l472
+				l473:
+					position, tokenIndex, depth = position472, tokenIndex472, depth472
 					if c := buffer[position]; c < rune('A') || c > rune('Z') {
-						goto l409
+						goto l474
 					}
 					position++
-					goto l407
-				l409:
-					position, tokenIndex, depth = position407, tokenIndex407, depth407
+					goto l472
+				l474:
+					position, tokenIndex, depth = position472, tokenIndex472, depth472
 					if buffer[position] != rune('_') {
-						goto l405
+						goto l470
 					}
 					position++
 				}
-			l407:
-			l410:
+			l472:
+			l475:
 				{
-					position411, tokenIndex411, depth411 := position, tokenIndex, depth
+					position476, tokenIndex476, depth476 := position, tokenIndex, depth
 					{
-						position412, tokenIndex412, depth412 := position, tokenIndex, depth
+						position477, tokenIndex477, depth477 := position, tokenIndex, depth
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l413
+							goto l478
 						}
 						position++
-						goto l412
-					l413:
-						position, tokenIndex, depth = position412, tokenIndex412, depth412
+						goto l477
+					l478:
+						position, tokenIndex, depth = position477, tokenIndex477, depth477
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l414
+							goto l479
 						}
 						position++
-						goto l412
-					l414:
-						position, tokenIndex, depth = position412, tokenIndex412, depth412
+						goto l477
+					l479:
+						position, tokenIndex, depth = position477, tokenIndex477, depth477
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l415
+							goto l480
 						}
 						position++
-						goto l412
-					l415:
-						position, tokenIndex, depth = position412, tokenIndex412, depth412
+						goto l477
+					l480:
+						position, tokenIndex, depth = position477, tokenIndex477, depth477
 						if buffer[position] != rune('_') {
-							goto l411
+							goto l476
 						}
 						position++
 					}
-				l412:
-					goto l410
-				l411:
-					position, tokenIndex, depth = position411, tokenIndex411, depth411
+				l477:
+					goto l475
+				l476:
+					position, tokenIndex, depth = position476, tokenIndex476, depth476
 				}
 				depth--
-				add(ruleTagComponent, position406)
+				add(ruleTagComponent, position471)
 			}
 			return true
-		l405:
-			position, tokenIndex, depth = position405, tokenIndex405, depth405
+		l470:
+			position, tokenIndex, depth = position470, tokenIndex470, depth470
 			return false
 		},
-		/* 100 FollowUpRef <- <PathComponent*> */
+		/* 106 FollowUpRef <- <PathComponent*> */
 		func() bool {
 			{
-				position417 := position
+				position482 := position
 				depth++
-			l418:
+			l483:
 				{
-					position419, tokenIndex419, depth419 := position, tokenIndex, depth
+					position484, tokenIndex484, depth484 := position, tokenIndex, depth
 					if !_rules[rulePathComponent]() {
-						goto l419
+						goto l484
 					}
-					goto l418
-				l419:
-					position, tokenIndex, depth = position419, tokenIndex419, depth419
+					goto l483
+				l484:
+					position, tokenIndex, depth = position484, tokenIndex484, depth484
 				}
 				depth--
-				add(ruleFollowUpRef, position417)
+				add(ruleFollowUpRef, position482)
 			}
 			return true
 		},
-		/* 101 PathComponent <- <(('.' Key) / ('.'? Index))> */
+		/* 107 PathComponent <- <(('.' Key) / ('.'? Index))> */
 		func() bool {
-			position420, tokenIndex420, depth420 := position, tokenIndex, depth
+			position485, tokenIndex485, depth485 := position, tokenIndex, depth
 			{
-				position421 := position
+				position486 := position
 				depth++
 				{
-					position422, tokenIndex422, depth422 := position, tokenIndex, depth
+					position487, tokenIndex487, depth487 := position, tokenIndex, depth
 					if buffer[position] != rune('.') {
-						goto l423
+						goto l488
 					}
 					position++
 					if !_rules[ruleKey]() {
-						goto l423
+						goto l488
 					}
-					goto l422
-				l423:
-					position, tokenIndex, depth = position422, tokenIndex422, depth422
+					goto l487
+				l488:
+					position, tokenIndex, depth = position487, tokenIndex487, depth487
 					{
-						position424, tokenIndex424, depth424 := position, tokenIndex, depth
+						position489, tokenIndex489, depth489 := position, tokenIndex, depth
 						if buffer[position] != rune('.') {
-							goto l424
+							goto l489
 						}
 						position++
-						goto l425
-					l424:
-						position, tokenIndex, depth = position424, tokenIndex424, depth424
+						goto l490
+					l489:
+						position, tokenIndex, depth = position489, tokenIndex489, depth489
 					}
-				l425:
+				l490:
 					if !_rules[ruleIndex]() {
-						goto l420
+						goto l485
 					}
 				}
-			l422:
+			l487:
 				depth--
-				add(rulePathComponent, position421)
+				add(rulePathComponent, position486)
 			}
 			return true
-		l420:
-			position, tokenIndex, depth = position420, tokenIndex420, depth420
+		l485:
+			position, tokenIndex, depth = position485, tokenIndex485, depth485
 			return false
 		},
-		/* 102 Key <- <(([a-z] / [A-Z] / [0-9] / '_') ([a-z] / [A-Z] / [0-9] / '_' / '-')* (':' ([a-z] / [A-Z] / [0-9] / '_') ([a-z] / [A-Z] / [0-9] / '_' / '-')*)?)> */
+		/* 108 Key <- <(([a-z] / [A-Z] / [0-9] / '_') ([a-z] / [A-Z] / [0-9] / '_' / '-')* (':' ([a-z] / [A-Z] / [0-9] / '_') ([a-z] / [A-Z] / [0-9] / '_' / '-')*)?)> */
 		func() bool {
-			position426, tokenIndex426, depth426 := position, tokenIndex, depth
+			position491, tokenIndex491, depth491 := position, tokenIndex, depth
 			{
-				position427 := position
+				position492 := position
 				depth++
 				{
-					position428, tokenIndex428, depth428 := position, tokenIndex, depth
+					position493, tokenIndex493, depth493 := position, tokenIndex, depth
 					if c := buffer[position]; c < rune('a') || c > rune('z') {
-						goto l429
+						goto l494
 					}
 					position++
-					goto l428
-				l429:
-					position, tokenIndex, depth = position428, tokenIndex428, depth428
+					goto l493
+				l494:
+					position, tokenIndex, depth = position493, tokenIndex493, depth493
 					if c := buffer[position]; c < rune('A') || c > rune('Z') {
-						goto l430
+						goto l495
 					}
 					position++
-					goto l428
-				l430:
-					position, tokenIndex, depth = position428, tokenIndex428, depth428
+					goto l493
+				l495:
+					position, tokenIndex, depth = position493, tokenIndex493, depth493
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l431
+						goto l496
 					}
 					position++
-					goto l428
-				l431:
-					position, tokenIndex, depth = position428, tokenIndex428, depth428
+					goto l493
+				l496:
+					position, tokenIndex, depth = position493, tokenIndex493, depth493
 					if buffer[position] != rune('_') {
-						goto l426
+						goto l491
 					}
 					position++
 				}
-			l428:
-			l432:
+			l493:
+			l497:
 				{
-					position433, tokenIndex433, depth433 := position, tokenIndex, depth
+					position498, tokenIndex498, depth498 := position, tokenIndex, depth
 					{
-						position434, tokenIndex434, depth434 := position, tokenIndex, depth
+						position499, tokenIndex499, depth499 := position, tokenIndex, depth
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l435
+							goto l500
 						}
 						position++
-						goto l434
-					l435:
-						position, tokenIndex, depth = position434, tokenIndex434, depth434
+						goto l499
+					l500:
+						position, tokenIndex, depth = position499, tokenIndex499, depth499
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l436
+							goto l501
 						}
 						position++
-						goto l434
-					l436:
-						position, tokenIndex, depth = position434, tokenIndex434, depth434
+						goto l499
+					l501:
+						position, tokenIndex, depth = position499, tokenIndex499, depth499
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l437
+							goto l502
 						}
 						position++
-						goto l434
-					l437:
-						position, tokenIndex, depth = position434, tokenIndex434, depth434
+						goto l499
+					l502:
+						position, tokenIndex, depth = position499, tokenIndex499, depth499
 						if buffer[position] != rune('_') {
-							goto l438
+							goto l503
 						}
 						position++
-						goto l434
-					l438:
-						position, tokenIndex, depth = position434, tokenIndex434, depth434
+						goto l499
+					l503:
+						position, tokenIndex, depth = position499, tokenIndex499, depth499
 						if buffer[position] != rune('-') {
-							goto l433
+							goto l498
 						}
 						position++
 					}
-				l434:
-					goto l432
-				l433:
-					position, tokenIndex, depth = position433, tokenIndex433, depth433
+				l499:
+					goto l497
+				l498:
+					position, tokenIndex, depth = position498, tokenIndex498, depth498
 				}
 				{
-					position439, tokenIndex439, depth439 := position, tokenIndex, depth
+					position504, tokenIndex504, depth504 := position, tokenIndex, depth
 					if buffer[position] != rune(':') {
-						goto l439
+						goto l504
 					}
 					position++
 					{
-						position441, tokenIndex441, depth441 := position, tokenIndex, depth
+						position506, tokenIndex506, depth506 := position, tokenIndex, depth
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l442
+							goto l507
 						}
 						position++
-						goto l441
-					l442:
-						position, tokenIndex, depth = position441, tokenIndex441, depth441
+						goto l506
+					l507:
+						position, tokenIndex, depth = position506, tokenIndex506, depth506
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l443
+							goto l508
 						}
 						position++
-						goto l441
-					l443:
-						position, tokenIndex, depth = position441, tokenIndex441, depth441
+						goto l506
+					l508:
+						position, tokenIndex, depth = position506, tokenIndex506, depth506
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l444
+							goto l509
 						}
 						position++
-						goto l441
-					l444:
-						position, tokenIndex, depth = position441, tokenIndex441, depth441
+						goto l506
+					l509:
+						position, tokenIndex, depth = position506, tokenIndex506, depth506
 						if buffer[position] != rune('_') {
-							goto l439
+							goto l504
 						}
 						position++
 					}
-				l441:
-				l445:
+				l506:
+				l510:
 					{
-						position446, tokenIndex446, depth446 := position, tokenIndex, depth
+						position511, tokenIndex511, depth511 := position, tokenIndex, depth
 						{
-							position447, tokenIndex447, depth447 := position, tokenIndex, depth
+							position512, tokenIndex512, depth512 := position, tokenIndex, depth
 							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l448
+								goto l513
 							}
 							position++
-							goto l447
-						l448:
-							position, tokenIndex, depth = position447, tokenIndex447, depth447
+							goto l512
+						l513:
+							position, tokenIndex, depth = position512, tokenIndex512, depth512
 							if c := buffer[position]; c < rune('A') || c > rune('Z') {
-								goto l449
+								goto l514
 							}
 							position++
-							goto l447
-						l449:
-							position, tokenIndex, depth = position447, tokenIndex447, depth447
+							goto l512
+						l514:
+							position, tokenIndex, depth = position512, tokenIndex512, depth512
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l450
+								goto l515
 							}
 							position++
-							goto l447
-						l450:
-							position, tokenIndex, depth = position447, tokenIndex447, depth447
+							goto l512
+						l515:
+							position, tokenIndex, depth = position512, tokenIndex512, depth512
 							if buffer[position] != rune('_') {
-								goto l451
+								goto l516
 							}
 							position++
-							goto l447
-						l451:
-							position, tokenIndex, depth = position447, tokenIndex447, depth447
+							goto l512
+						l516:
+							position, tokenIndex, depth = position512, tokenIndex512, depth512
 							if buffer[position] != rune('-') {
-								goto l446
+								goto l511
 							}
 							position++
 						}
-					l447:
-						goto l445
-					l446:
-						position, tokenIndex, depth = position446, tokenIndex446, depth446
+					l512:
+						goto l510
+					l511:
+						position, tokenIndex, depth = position511, tokenIndex511, depth511
 					}
-					goto l440
-				l439:
-					position, tokenIndex, depth = position439, tokenIndex439, depth439
+					goto l505
+				l504:
+					position, tokenIndex, depth = position504, tokenIndex504, depth504
 				}
-			l440:
+			l505:
 				depth--
-				add(ruleKey, position427)
+				add(ruleKey, position492)
 			}
 			return true
-		l426:
-			position, tokenIndex, depth = position426, tokenIndex426, depth426
+		l491:
+			position, tokenIndex, depth = position491, tokenIndex491, depth491
 			return false
 		},
-		/* 103 Index <- <('[' '-'? [0-9]+ ']')> */
+		/* 109 Index <- <('[' '-'? [0-9]+ ']')> */
 		func() bool {
-			position452, tokenIndex452, depth452 := position, tokenIndex, depth
+			position517, tokenIndex517, depth517 := position, tokenIndex, depth
 			{
-				position453 := position
+				position518 := position
 				depth++
 				if buffer[position] != rune('[') {
-					goto l452
+					goto l517
 				}
 				position++
 				{
-					position454, tokenIndex454, depth454 := position, tokenIndex, depth
+					position519, tokenIndex519, depth519 := position, tokenIndex, depth
 					if buffer[position] != rune('-') {
-						goto l454
+						goto l519
 					}
 					position++
-					goto l455
-				l454:
-					position, tokenIndex, depth = position454, tokenIndex454, depth454
+					goto l520
+				l519:
+					position, tokenIndex, depth = position519, tokenIndex519, depth519
 				}
-			l455:
+			l520:
 				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l452
+					goto l517
 				}
 				position++
-			l456:
+			l521:
 				{
-					position457, tokenIndex457, depth457 := position, tokenIndex, depth
+					position522, tokenIndex522, depth522 := position, tokenIndex, depth
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l457
+						goto l522
 					}
 					position++
-					goto l456
-				l457:
-					position, tokenIndex, depth = position457, tokenIndex457, depth457
+					goto l521
+				l522:
+					position, tokenIndex, depth = position522, tokenIndex522, depth522
 				}
 				if buffer[position] != rune(']') {
-					goto l452
+					goto l517
 				}
 				position++
 				depth--
-				add(ruleIndex, position453)
+				add(ruleIndex, position518)
 			}
 			return true
-		l452:
-			position, tokenIndex, depth = position452, tokenIndex452, depth452
+		l517:
+			position, tokenIndex, depth = position517, tokenIndex517, depth517
 			return false
 		},
-		/* 104 IP <- <([0-9]+ '.' [0-9]+ '.' [0-9]+ '.' [0-9]+)> */
+		/* 110 IP <- <([0-9]+ '.' [0-9]+ '.' [0-9]+ '.' [0-9]+)> */
 		func() bool {
-			position458, tokenIndex458, depth458 := position, tokenIndex, depth
+			position523, tokenIndex523, depth523 := position, tokenIndex, depth
 			{
-				position459 := position
+				position524 := position
 				depth++
 				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l458
+					goto l523
 				}
 				position++
-			l460:
+			l525:
 				{
-					position461, tokenIndex461, depth461 := position, tokenIndex, depth
+					position526, tokenIndex526, depth526 := position, tokenIndex, depth
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l461
+						goto l526
 					}
 					position++
-					goto l460
-				l461:
-					position, tokenIndex, depth = position461, tokenIndex461, depth461
+					goto l525
+				l526:
+					position, tokenIndex, depth = position526, tokenIndex526, depth526
 				}
 				if buffer[position] != rune('.') {
-					goto l458
+					goto l523
 				}
 				position++
 				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l458
+					goto l523
 				}
 				position++
-			l462:
+			l527:
 				{
-					position463, tokenIndex463, depth463 := position, tokenIndex, depth
+					position528, tokenIndex528, depth528 := position, tokenIndex, depth
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l463
+						goto l528
 					}
 					position++
-					goto l462
-				l463:
-					position, tokenIndex, depth = position463, tokenIndex463, depth463
+					goto l527
+				l528:
+					position, tokenIndex, depth = position528, tokenIndex528, depth528
 				}
 				if buffer[position] != rune('.') {
-					goto l458
+					goto l523
 				}
 				position++
 				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l458
+					goto l523
 				}
 				position++
-			l464:
+			l529:
 				{
-					position465, tokenIndex465, depth465 := position, tokenIndex, depth
+					position530, tokenIndex530, depth530 := position, tokenIndex, depth
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l465
+						goto l530
 					}
 					position++
-					goto l464
-				l465:
-					position, tokenIndex, depth = position465, tokenIndex465, depth465
+					goto l529
+				l530:
+					position, tokenIndex, depth = position530, tokenIndex530, depth530
 				}
 				if buffer[position] != rune('.') {
-					goto l458
+					goto l523
 				}
 				position++
 				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l458
+					goto l523
 				}
 				position++
-			l466:
+			l531:
 				{
-					position467, tokenIndex467, depth467 := position, tokenIndex, depth
+					position532, tokenIndex532, depth532 := position, tokenIndex, depth
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l467
+						goto l532
 					}
 					position++
-					goto l466
-				l467:
-					position, tokenIndex, depth = position467, tokenIndex467, depth467
+					goto l531
+				l532:
+					position, tokenIndex, depth = position532, tokenIndex532, depth532
 				}
 				depth--
-				add(ruleIP, position459)
+				add(ruleIP, position524)
 			}
 			return true
-		l458:
-			position, tokenIndex, depth = position458, tokenIndex458, depth458
+		l523:
+			position, tokenIndex, depth = position523, tokenIndex523, depth523
 			return false
 		},
-		/* 105 ws <- <(' ' / '\t' / '\n' / '\r')*> */
+		/* 111 ws <- <(' ' / '\t' / '\n' / '\r')*> */
 		func() bool {
 			{
-				position469 := position
+				position534 := position
 				depth++
-			l470:
+			l535:
 				{
-					position471, tokenIndex471, depth471 := position, tokenIndex, depth
+					position536, tokenIndex536, depth536 := position, tokenIndex, depth
 					{
-						position472, tokenIndex472, depth472 := position, tokenIndex, depth
+						position537, tokenIndex537, depth537 := position, tokenIndex, depth
 						if buffer[position] != rune(' ') {
-							goto l473
+							goto l538
 						}
 						position++
-						goto l472
-					l473:
-						position, tokenIndex, depth = position472, tokenIndex472, depth472
+						goto l537
+					l538:
+						position, tokenIndex, depth = position537, tokenIndex537, depth537
 						if buffer[position] != rune('\t') {
-							goto l474
+							goto l539
 						}
 						position++
-						goto l472
-					l474:
-						position, tokenIndex, depth = position472, tokenIndex472, depth472
+						goto l537
+					l539:
+						position, tokenIndex, depth = position537, tokenIndex537, depth537
 						if buffer[position] != rune('\n') {
-							goto l475
+							goto l540
 						}
 						position++
-						goto l472
-					l475:
-						position, tokenIndex, depth = position472, tokenIndex472, depth472
+						goto l537
+					l540:
+						position, tokenIndex, depth = position537, tokenIndex537, depth537
 						if buffer[position] != rune('\r') {
-							goto l471
+							goto l536
 						}
 						position++
 					}
-				l472:
-					goto l470
-				l471:
-					position, tokenIndex, depth = position471, tokenIndex471, depth471
+				l537:
+					goto l535
+				l536:
+					position, tokenIndex, depth = position536, tokenIndex536, depth536
 				}
 				depth--
-				add(rulews, position469)
+				add(rulews, position534)
 			}
 			return true
 		},
-		/* 106 req_ws <- <(' ' / '\t' / '\n' / '\r')+> */
+		/* 112 req_ws <- <(' ' / '\t' / '\n' / '\r')+> */
 		func() bool {
-			position476, tokenIndex476, depth476 := position, tokenIndex, depth
+			position541, tokenIndex541, depth541 := position, tokenIndex, depth
 			{
-				position477 := position
+				position542 := position
 				depth++
 				{
-					position480, tokenIndex480, depth480 := position, tokenIndex, depth
+					position545, tokenIndex545, depth545 := position, tokenIndex, depth
 					if buffer[position] != rune(' ') {
-						goto l481
+						goto l546
 					}
 					position++
-					goto l480
-				l481:
-					position, tokenIndex, depth = position480, tokenIndex480, depth480
+					goto l545
+				l546:
+					position, tokenIndex, depth = position545, tokenIndex545, depth545
 					if buffer[position] != rune('\t') {
-						goto l482
+						goto l547
 					}
 					position++
-					goto l480
-				l482:
-					position, tokenIndex, depth = position480, tokenIndex480, depth480
+					goto l545
+				l547:
+					position, tokenIndex, depth = position545, tokenIndex545, depth545
 					if buffer[position] != rune('\n') {
-						goto l483
+						goto l548
 					}
 					position++
-					goto l480
-				l483:
-					position, tokenIndex, depth = position480, tokenIndex480, depth480
+					goto l545
+				l548:
+					position, tokenIndex, depth = position545, tokenIndex545, depth545
 					if buffer[position] != rune('\r') {
-						goto l476
+						goto l541
 					}
 					position++
 				}
-			l480:
-			l478:
+			l545:
+			l543:
 				{
-					position479, tokenIndex479, depth479 := position, tokenIndex, depth
+					position544, tokenIndex544, depth544 := position, tokenIndex, depth
 					{
-						position484, tokenIndex484, depth484 := position, tokenIndex, depth
+						position549, tokenIndex549, depth549 := position, tokenIndex, depth
 						if buffer[position] != rune(' ') {
-							goto l485
+							goto l550
 						}
 						position++
-						goto l484
-					l485:
-						position, tokenIndex, depth = position484, tokenIndex484, depth484
+						goto l549
+					l550:
+						position, tokenIndex, depth = position549, tokenIndex549, depth549
 						if buffer[position] != rune('\t') {
-							goto l486
+							goto l551
 						}
 						position++
-						goto l484
-					l486:
-						position, tokenIndex, depth = position484, tokenIndex484, depth484
+						goto l549
+					l551:
+						position, tokenIndex, depth = position549, tokenIndex549, depth549
 						if buffer[position] != rune('\n') {
-							goto l487
+							goto l552
 						}
 						position++
-						goto l484
-					l487:
-						position, tokenIndex, depth = position484, tokenIndex484, depth484
+						goto l549
+					l552:
+						position, tokenIndex, depth = position549, tokenIndex549, depth549
 						if buffer[position] != rune('\r') {
-							goto l479
+							goto l544
 						}
 						position++
 					}
-				l484:
-					goto l478
-				l479:
-					position, tokenIndex, depth = position479, tokenIndex479, depth479
+				l549:
+					goto l543
+				l544:
+					position, tokenIndex, depth = position544, tokenIndex544, depth544
 				}
 				depth--
-				add(rulereq_ws, position477)
+				add(rulereq_ws, position542)
 			}
 			return true
-		l476:
-			position, tokenIndex, depth = position476, tokenIndex476, depth476
+		l541:
+			position, tokenIndex, depth = position541, tokenIndex541, depth541
 			return false
 		},
-		/* 108 Action0 <- <{}> */
+		/* 114 Action0 <- <{}> */
 		func() bool {
 			{
 				add(ruleAction0, position)
 			}
 			return true
 		},
-		/* 109 Action1 <- <{}> */
+		/* 115 Action1 <- <{}> */
 		func() bool {
 			{
 				add(ruleAction1, position)
 			}
 			return true
 		},
-		/* 110 Action2 <- <{}> */
+		/* 116 Action2 <- <{}> */
 		func() bool {
 			{
 				add(ruleAction2, position)
@@ -59,6 +59,12 @@ func func_env(arguments []interface{}, binding Binding) (interface{}, Evaluation
 		}
 	}
 
+	for _, n := range args {
+		if err := policyOf(binding).CheckEnv(n); err != nil {
+			return info.Error("%s", err)
+		}
+	}
+
 	if len(args) == 1 {
 		s, ok := getenv(args[0])
 		if ok {
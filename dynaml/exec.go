@@ -1,11 +1,8 @@
 package dynaml
 
 import (
-	"bytes"
-	"crypto/md5"
 	"fmt"
 	"os"
-	"os/exec"
 	"strconv"
 	"strings"
 
@@ -26,6 +23,12 @@ func func_exec(cached bool, arguments []interface{}, binding Binding) (interface
 	if !binding.GetState().OSAccessAllowed() {
 		return info.DenyOSOperation("exec")
 	}
+	if !cached && IsDeterministic(binding) {
+		return info.Error("exec_uncached not possible in deterministic mode")
+	}
+	if opts, ok := arguments[0].(map[string]yaml.Node); ok && len(arguments) == 1 {
+		return execOptionsCall(cached, "exec", opts, nil, binding)
+	}
 	if cached {
 		cache = binding.GetState().GetExecCache()
 	}
@@ -56,9 +59,12 @@ func func_exec(cached bool, arguments []interface{}, binding Binding) (interface
 			args = append(args, v)
 		}
 	}
-	result, err := cachedExecute(cache, nil, args)
+	if err := policyOf(binding).CheckExecutable(args[0]); err != nil {
+		return info.Error("%s", err)
+	}
+	result, err := cachedExecute(cache, journalOf(binding), nil, args)
 	if err != nil {
-		return info.Error("execution '%s' failed", args[0])
+		return info.Error("%s", err)
 	}
 
 	return convertOutput(result)
@@ -130,40 +136,19 @@ type Bytes interface {
 	Bytes() []byte
 }
 
-func cachedExecute(cache ExecCache, content *string, args []string) ([]byte, error) {
-	h := md5.New()
-	if content != nil {
-		h.Write([]byte(*content))
-	}
-	args[0] = FilePath(args[0])
-	for _, arg := range args {
-		h.Write([]byte(arg))
-	}
-	hash := fmt.Sprintf("%x", h.Sum(nil))
-	if cache != nil {
-		cache.Lock()
-		defer cache.Unlock()
-		result := cache.Get(hash)
-		if result != nil {
-			debug.Debug("exec: reusing cache %s for %v\n", hash, args)
-			return result, nil
-		}
+func cachedExecute(cache ExecCache, journal *Journal, content *string, args []string) ([]byte, error) {
+	result, err := execute(cache, journal, &ExecOptions{Args: args, Stdin: content})
+	if err != nil {
+		return nil, fmt.Errorf("execution '%s' failed: %s", args[0], err)
 	}
-	debug.Debug("exec: calling %v\n", args)
-	cmd := exec.Command(args[0], args[1:]...)
-	if content != nil {
-		cmd.Stdin = bytes.NewReader([]byte(*content))
+	if result.RC != 0 {
+		return result.Stdout, fmt.Errorf("%s", execFailure(args[0], result))
 	}
-	result, err := cmd.Output()
-	stderr := string(cmd.Stderr.(Bytes).Bytes())
-	if stderr != "" {
+	if len(result.Stderr) != 0 {
 		fmt.Fprintf(os.Stderr, "exec: calling %v\n", args)
-		fmt.Fprintf(os.Stderr, "  error: %v\n", stderr)
-	}
-	if cache != nil {
-		cache.Set(hash, result)
+		fmt.Fprintf(os.Stderr, "  error: %v\n", string(result.Stderr))
 	}
-	return result, err
+	return result.Stdout, nil
 }
 
 func isMap(n yaml.Node) bool {
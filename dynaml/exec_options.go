@@ -0,0 +1,364 @@
+package dynaml
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mandelsoft/spiff/debug"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+// ExecOptions describes a command execution requested by an option
+// map for exec or pipe.
+type ExecOptions struct {
+	Args      []string
+	Env       map[string]string
+	Dir       string
+	Stdin     *string
+	Timeout   time.Duration
+	ExitCodes []int
+	Output    string
+	Result    bool
+}
+
+// ExecResult is the outcome of a command execution.
+type ExecResult struct {
+	Stdout []byte `json:"stdout"`
+	Stderr []byte `json:"stderr"`
+	RC     int    `json:"rc"`
+}
+
+var execOutputModes = map[string]bool{"auto": true, "text": true, "yaml": true, "json": true, "lines": true}
+
+// getExecOptions parses an option map. Supported fields are
+//
+//	command: the command as string or list with command and arguments
+//	args:    list of additional arguments
+//	env:     map of additional environment variables
+//	dir:     working directory
+//	stdin:   data fed to standard input
+//	timeout: duration string or number of seconds
+//	rc:      allowed exit code or list of exit codes (default 0)
+//	output:  output mode: auto, text, yaml, json or lines (default auto)
+//	result:  provide the structured result {stdout, stderr, rc}
+func getExecOptions(opts map[string]yaml.Node) (*ExecOptions, error) {
+	var err error
+
+	result := &ExecOptions{Output: "auto", ExitCodes: []int{0}}
+	wopt := WriteOpts{}
+
+	keys := getSortedKeys(opts)
+	for _, k := range keys {
+		field := opts[k]
+		var value interface{}
+		if field != nil {
+			value = field.Value()
+		}
+		switch k {
+		case "command":
+			switch v := value.(type) {
+			case []yaml.Node:
+				if len(v) == 0 {
+					return nil, fmt.Errorf("command must not be empty")
+				}
+				for i, e := range v {
+					a, _, err := getArg(i, e.Value(), wopt, i != 0)
+					if err != nil {
+						return nil, fmt.Errorf("invalid command argument %d: %s", i, err)
+					}
+					result.Args = append(result.Args, a)
+				}
+			case string:
+				result.Args = []string{v}
+			default:
+				return nil, fmt.Errorf("command must be string or list, found %s", ExpressionType(value))
+			}
+		case "env":
+			m, ok := value.(map[string]yaml.Node)
+			if !ok {
+				return nil, fmt.Errorf("env must be a map, found %s", ExpressionType(value))
+			}
+			result.Env = map[string]string{}
+			for n, e := range m {
+				result.Env[n], _, err = getArg(n, e.Value(), wopt, false)
+				if err != nil {
+					return nil, fmt.Errorf("invalid value for environment variable %q: %s", n, err)
+				}
+			}
+		case "dir":
+			s, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("dir must be a string, found %s", ExpressionType(value))
+			}
+			result.Dir = s
+		case "stdin":
+			s, _, err := getArg(k, value, wopt, true)
+			if err != nil {
+				return nil, fmt.Errorf("invalid stdin: %s", err)
+			}
+			result.Stdin = &s
+		case "timeout":
+			switch v := value.(type) {
+			case int64:
+				result.Timeout = time.Duration(v) * time.Second
+			case string:
+				result.Timeout, err = time.ParseDuration(v)
+				if err != nil {
+					return nil, fmt.Errorf("invalid timeout %q: %s", v, err)
+				}
+			default:
+				return nil, fmt.Errorf("timeout must be a duration string or integer, found %s", ExpressionType(value))
+			}
+			if result.Timeout <= 0 {
+				return nil, fmt.Errorf("timeout must be positive")
+			}
+		case "rc":
+			result.ExitCodes = nil
+			switch v := value.(type) {
+			case int64:
+				result.ExitCodes = append(result.ExitCodes, int(v))
+			case []yaml.Node:
+				for _, e := range v {
+					rc, ok := e.Value().(int64)
+					if !ok {
+						return nil, fmt.Errorf("rc list entries must be integers, found %s", ExpressionType(e))
+					}
+					result.ExitCodes = append(result.ExitCodes, int(rc))
+				}
+			default:
+				return nil, fmt.Errorf("rc must be integer or list of integers, found %s", ExpressionType(value))
+			}
+		case "output":
+			s, ok := value.(string)
+			if !ok || !execOutputModes[s] {
+				return nil, fmt.Errorf("output must be one of auto, text, yaml, json or lines")
+			}
+			result.Output = s
+		case "result":
+			b, ok := value.(bool)
+			if !ok {
+				return nil, fmt.Errorf("result must be boolean, found %s", ExpressionType(value))
+			}
+			result.Result = b
+		default:
+			if k != "args" {
+				return nil, fmt.Errorf("unknown option %q", k)
+			}
+		}
+	}
+
+	if field, ok := opts["args"]; ok {
+		list, ok := field.Value().([]yaml.Node)
+		if !ok {
+			return nil, fmt.Errorf("args must be a list, found %s", ExpressionType(field))
+		}
+		for i, e := range list {
+			a, _, err := getArg(i, e.Value(), wopt, true)
+			if err != nil {
+				return nil, fmt.Errorf("invalid argument %d: %s", i, err)
+			}
+			result.Args = append(result.Args, a)
+		}
+	}
+	if len(result.Args) == 0 {
+		return nil, fmt.Errorf("command required")
+	}
+	return result, nil
+}
+
+func (o *ExecOptions) hash() string {
+	h := md5.New()
+	fmt.Fprintf(h, "options\x00%s\x00%s\x00", o.Dir, o.Timeout)
+	for _, a := range o.Args {
+		fmt.Fprintf(h, "%s\x00", a)
+	}
+	keys := make([]string, 0, len(o.Env))
+	for k := range o.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(h, "%s=%s\x00", k, o.Env[k])
+	}
+	if o.Stdin != nil {
+		fmt.Fprintf(h, "stdin\x00%s", *o.Stdin)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// allowed checks whether an exit code is accepted.
+func (o *ExecOptions) allowed(rc int) bool {
+	for _, c := range o.ExitCodes {
+		if c == rc {
+			return true
+		}
+	}
+	return false
+}
+
+// execute runs a command described by the options. Exit codes are
+// reported by the result, an error is only returned if the command
+// could not be executed or did not finish in time.
+// In dry-run mode (given by a journal) the execution is only recorded
+// and an empty output is provided.
+func execute(cache ExecCache, journal *Journal, o *ExecOptions) (*ExecResult, error) {
+	args := append([]string{FilePath(o.Args[0])}, o.Args[1:]...)
+	if journal != nil {
+		journal.Record(JournalEntry{Operation: JOURNAL_EXEC, Command: o.Args, Dir: o.Dir})
+		return &ExecResult{}, nil
+	}
+	hash := o.hash()
+	if cache != nil {
+		cache.Lock()
+		defer cache.Unlock()
+		if data := cache.Get(hash); data != nil {
+			result := &ExecResult{}
+			if err := json.Unmarshal(data, result); err == nil {
+				debug.Debug("exec: reusing cache %s for %v\n", hash, args)
+				return result, nil
+			}
+		}
+	}
+
+	ctx := context.Background()
+	if o.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+	}
+	debug.Debug("exec: calling %v\n", args)
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	if o.Dir != "" {
+		cmd.Dir = FilePath(o.Dir)
+	}
+	if len(o.Env) > 0 {
+		cmd.Env = os.Environ()
+		for k, v := range o.Env {
+			cmd.Env = append(cmd.Env, k+"="+v)
+		}
+	}
+	if o.Stdin != nil {
+		cmd.Stdin = strings.NewReader(*o.Stdin)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// don't wait for sub processes keeping the output open after a timeout
+	cmd.WaitDelay = time.Second
+
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("timed out after %s", o.Timeout)
+	}
+	result := &ExecResult{Stdout: stdout.Bytes(), Stderr: stderr.Bytes()}
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return nil, err
+		}
+		result.RC = exitErr.ExitCode()
+	}
+	if cache != nil {
+		if data, err := json.Marshal(result); err == nil {
+			cache.Set(hash, data)
+		}
+	}
+	return result, nil
+}
+
+// execOptionsCall executes a command described by an option map and
+// provides the result according to the requested output mode.
+func execOptionsCall(cached bool, name string, opts map[string]yaml.Node, stdin *string, binding Binding) (interface{}, EvaluationInfo, bool) {
+	var cache ExecCache
+
+	info := DefaultInfo()
+
+	o, err := getExecOptions(opts)
+	if err != nil {
+		return info.Error("%s: %s", name, err)
+	}
+	if stdin != nil {
+		if o.Stdin != nil {
+			return info.Error("%s: stdin option not possible for pipe", name)
+		}
+		o.Stdin = stdin
+	}
+	if err := policyOf(binding).CheckExecutable(o.Args[0]); err != nil {
+		return info.Error("%s", err)
+	}
+	if cached {
+		cache = binding.GetState().GetExecCache()
+	}
+
+	result, err := execute(cache, journalOf(binding), o)
+	if err != nil {
+		return info.Error("execution '%s' failed: %s", o.Args[0], err)
+	}
+	if !o.Result && !o.allowed(result.RC) {
+		return info.Error("%s", execFailure(o.Args[0], result))
+	}
+	out, err := convertOutputMode(o.Output, result.Stdout)
+	if err != nil {
+		return info.Error("execution '%s': %s", o.Args[0], err)
+	}
+	if !o.Result {
+		return out, info, true
+	}
+	return map[string]yaml.Node{
+		"stdout": NewNode(out, binding),
+		"stderr": NewNode(strings.TrimRight(string(result.Stderr), "\n"), binding),
+		"rc":     NewNode(int64(result.RC), binding),
+	}, info, true
+}
+
+// execFailure describes a failed execution including its exit code
+// and error output.
+func execFailure(cmd string, result *ExecResult) string {
+	msg := fmt.Sprintf("execution '%s' failed with exit code %d", cmd, result.RC)
+	if stderr := strings.TrimSpace(string(result.Stderr)); stderr != "" {
+		msg += ": " + stderr
+	}
+	return msg
+}
+
+func convertOutputMode(mode string, data []byte) (interface{}, error) {
+	switch mode {
+	case "text":
+		return strings.TrimRight(string(data), "\n"), nil
+	case "lines":
+		lines := []yaml.Node{}
+		str := strings.TrimRight(string(data), "\n")
+		if str != "" {
+			for _, l := range strings.Split(str, "\n") {
+				lines = append(lines, NewNode(l, nil))
+			}
+		}
+		return lines, nil
+	case "json":
+		if !json.Valid(data) {
+			return nil, fmt.Errorf("output is no valid json")
+		}
+		fallthrough
+	case "yaml":
+		node, err := yaml.Parse("exec", data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s output: %s", mode, err)
+		}
+		if node == nil {
+			return nil, nil
+		}
+		return node.Value(), nil
+	default:
+		v, _, _ := convertOutput(data)
+		return v, nil
+	}
+}
@@ -1,6 +1,9 @@
 package dynaml
 
 import (
+	"fmt"
+	"io"
+
 	"github.com/mandelsoft/vfs/pkg/vfs"
 
 	"github.com/mandelsoft/spiff/features"
@@ -28,13 +31,21 @@ type ExecCache interface {
 type State interface {
 	GetTempName(data []byte) (string, error)
 	GetFileContent(file string, cached bool) ([]byte, error)
+	ProbeFile(file string)
+	Fetch(url string, opts *FetchOptions) ([]byte, error)
 	GetEncryptionKey() string
 	OSAccessAllowed() bool
+	GetPolicy() *Policy
 	FileAccessAllowed() bool
 	FileSystem() vfs.VFS
 	GetRegistry() Registry
 	GetFeatures() features.FeatureFlags
 	GetExecCache() ExecCache
+	GetRandomSource(key string) io.Reader
+	Deterministic() bool
+	GetJournal() *Journal
+	GetProfiler() *Profiler
+	GetWarnings() *Warnings
 	InterpolationEnabled() bool
 	ControlEnabled() bool
 	SetTag(name string, node yaml.Node, path []string, scope TagScope) error
@@ -83,12 +94,15 @@ type EvaluationInfo struct {
 	Merged       bool
 	Preferred    bool
 	KeyName      string
+	KeyOrder     []string
 	Source       string
+	Origin       *yaml.Origin
 	LocalError   bool
 	Failed       bool
 	Undefined    bool
 	Raw          bool
 	Issue        yaml.Issue
+	Warnings     []string
 	Cleanups     []Cleanup
 	yaml.NodeFlags
 }
@@ -139,9 +153,9 @@ func (e EvaluationInfo) SourceName() string {
 
 func DefaultInfo() EvaluationInfo {
 	return EvaluationInfo{nil, false, false,
-		false, "", "",
+		false, "", nil, "", nil,
 		false, false, false, false,
-		yaml.Issue{}, nil, 0}
+		yaml.Issue{}, nil, nil, 0}
 }
 
 type Expression interface {
@@ -203,6 +217,11 @@ func (i *EvaluationInfo) SetError(msgfmt interface{}, args ...interface{}) {
 	}
 }
 
+// AddWarning adds a warning for the evaluated node.
+func (i *EvaluationInfo) AddWarning(msgfmt string, args ...interface{}) {
+	i.Warnings = append(i.Warnings, fmt.Sprintf(msgfmt, args...))
+}
+
 func (i *EvaluationInfo) PropagateError(value interface{}, state Status, msgfmt string, args ...interface{}) (interface{}, EvaluationInfo, bool) {
 	i.Issue, i.LocalError, i.Failed = state.Issue(msgfmt, args...)
 	if i.LocalError {
@@ -247,6 +266,7 @@ func (i EvaluationInfo) Join(o EvaluationInfo) EvaluationInfo {
 	}
 	i.NodeFlags |= o.NodeFlags
 
+	i.Warnings = append(i.Warnings, o.Warnings...)
 	i.Cleanups = append(i.Cleanups, o.Cleanups...)
 	return i
 }
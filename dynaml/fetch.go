@@ -0,0 +1,101 @@
+package dynaml
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+// FetchOptions describes the options for reading remote content.
+type FetchOptions struct {
+	// Headers are additional request headers.
+	Headers map[string]string
+	// TokenEnv is the name of an environment variable providing
+	// a bearer token used for the Authorization header.
+	TokenEnv string
+	// Timeout overrides the default timeout of the request.
+	Timeout time.Duration
+	// SHA256 is the expected hex encoded sha256 digest of the content.
+	SHA256 string
+}
+
+var sha256Pattern = regexp.MustCompile("^[0-9a-f]{64}$")
+
+// getFetchOptions parses the option map of a read call. Supported
+// fields are headers, token_env, timeout and sha256.
+func getFetchOptions(opts map[string]yaml.Node) (*FetchOptions, error) {
+	result := &FetchOptions{}
+	for _, k := range getSortedKeys(opts) {
+		var value interface{}
+		if opts[k] != nil {
+			value = opts[k].Value()
+		}
+		switch k {
+		case "headers":
+			m, ok := value.(map[string]yaml.Node)
+			if !ok {
+				return nil, fmt.Errorf("headers must be a map, found %s", ExpressionType(value))
+			}
+			result.Headers = map[string]string{}
+			for n, v := range m {
+				s, _, err := getArg(n, v.Value(), WriteOpts{}, false)
+				if err != nil {
+					return nil, fmt.Errorf("invalid value for header %q: %s", n, err)
+				}
+				result.Headers[n] = s
+			}
+		case "token_env":
+			s, ok := value.(string)
+			if !ok || s == "" {
+				return nil, fmt.Errorf("token_env must be a non-empty string")
+			}
+			result.TokenEnv = s
+		case "timeout":
+			switch v := value.(type) {
+			case int64:
+				result.Timeout = time.Duration(v) * time.Second
+			case string:
+				d, err := time.ParseDuration(v)
+				if err != nil {
+					return nil, fmt.Errorf("invalid timeout %q: %s", v, err)
+				}
+				result.Timeout = d
+			default:
+				return nil, fmt.Errorf("timeout must be a duration string or integer, found %s", ExpressionType(value))
+			}
+			if result.Timeout <= 0 {
+				return nil, fmt.Errorf("timeout must be positive")
+			}
+		case "sha256":
+			s, ok := value.(string)
+			if ok {
+				s = strings.ToLower(strings.TrimPrefix(s, "sha256:"))
+			}
+			if !ok || !sha256Pattern.MatchString(s) {
+				return nil, fmt.Errorf("sha256 must be a hex encoded sha256 digest")
+			}
+			result.SHA256 = s
+		default:
+			return nil, fmt.Errorf("unknown option %q", k)
+		}
+	}
+	return result, nil
+}
+
+// Token provides the bearer token configured by TokenEnv.
+func (o *FetchOptions) Token(state State) (string, error) {
+	if o == nil || o.TokenEnv == "" {
+		return "", nil
+	}
+	if err := state.GetPolicy().CheckEnv(o.TokenEnv); err != nil {
+		return "", err
+	}
+	token, ok := getenv(o.TokenEnv)
+	if !ok {
+		return "", fmt.Errorf("environment variable '%s' for token not set", o.TokenEnv)
+	}
+	return token, nil
+}
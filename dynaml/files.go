@@ -24,6 +24,10 @@ func func_listFiles(directory bool, arguments []interface{}, binding Binding) (i
 		return info.Error("list: argument is empty string")
 	}
 
+	if err := policyOf(binding).CheckRead(name); err != nil {
+		return info.Error("list: %s", err)
+	}
+
 	if !checkExistence(binding, name, true) {
 		return info.Error("list: %q is no directory or does not exist", name)
 	}
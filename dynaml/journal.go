@@ -0,0 +1,96 @@
+package dynaml
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	JOURNAL_WRITE    = "write"
+	JOURNAL_MKDIR    = "mkdir"
+	JOURNAL_TEMPFILE = "tempfile"
+	JOURNAL_EXEC     = "exec"
+)
+
+// Journal records the side effects of a processing in dry-run mode
+// instead of performing them.
+type Journal struct {
+	lock    sync.Mutex
+	entries []JournalEntry
+}
+
+// JournalEntry describes a recorded side effect.
+type JournalEntry struct {
+	// Operation is one of write, mkdir, tempfile or exec.
+	Operation string `json:"operation"`
+	// Path is the affected file or directory of file system operations.
+	Path string `json:"path,omitempty"`
+	// Permissions are the permissions of created files and directories.
+	Permissions os.FileMode `json:"permissions,omitempty"`
+	// Command is the command line of an execution.
+	Command []string `json:"command,omitempty"`
+	// Dir is the working directory of an execution.
+	Dir string `json:"dir,omitempty"`
+}
+
+// String provides a single line description of the entry.
+func (e JournalEntry) String() string {
+	switch e.Operation {
+	case JOURNAL_EXEC:
+		args := make([]string, len(e.Command))
+		for i, a := range e.Command {
+			if a == "" || strings.ContainsAny(a, " \t\n\"'\\$") {
+				a = strconv.Quote(a)
+			}
+			args[i] = a
+		}
+		s := fmt.Sprintf("%-8s %s", e.Operation, strings.Join(args, " "))
+		if e.Dir != "" {
+			s += " (in " + e.Dir + ")"
+		}
+		return s
+	default:
+		if e.Permissions != 0 {
+			return fmt.Sprintf("%-8s %s (%04o)", e.Operation, e.Path, uint32(e.Permissions))
+		}
+		return fmt.Sprintf("%-8s %s", e.Operation, e.Path)
+	}
+}
+
+// Record adds an entry. Repeated identical entries are recorded once.
+func (j *Journal) Record(e JournalEntry) {
+	if j == nil {
+		return
+	}
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	for _, o := range j.entries {
+		if reflect.DeepEqual(o, e) {
+			return
+		}
+	}
+	j.entries = append(j.entries, e)
+}
+
+// Entries provides the recorded entries in the order of their occurrence.
+func (j *Journal) Entries() []JournalEntry {
+	if j == nil {
+		return nil
+	}
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	return append([]JournalEntry{}, j.entries...)
+}
+
+// journalOf provides the journal of a binding used in dry-run mode,
+// if there is any.
+func journalOf(binding Binding) *Journal {
+	if binding == nil || binding.GetState() == nil {
+		return nil
+	}
+	return binding.GetState().GetJournal()
+}
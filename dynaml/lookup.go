@@ -52,7 +52,7 @@ func func_lookup(directory bool, arguments []interface{}, binding Binding) (inte
 	}
 
 	result := []yaml.Node{}
-	if filepath.IsAbs(name) {
+	if filepath.IsAbs(name) || LookupResolver(binding.GetState().GetRegistry(), name) != nil {
 		if checkExistence(binding, name, directory) {
 			result = append(result, NewNode(name, binding))
 		}
@@ -74,6 +74,18 @@ func checkExistence(binding Binding, path string, directory bool) bool {
 	if !binding.GetState().FileAccessAllowed() {
 		return false
 	}
+	binding.GetState().ProbeFile(path)
+	if LookupResolver(binding.GetState().GetRegistry(), path) != nil {
+		// content provided by resolvers is never a directory
+		if directory {
+			return false
+		}
+		_, err := binding.GetState().GetFileContent(path, true)
+		return err == nil
+	}
+	if policyOf(binding).CheckRead(path) != nil {
+		return false
+	}
 	s, err := binding.GetState().FileSystem().Stat(path)
 	if vfs.IsErrNotExist(err) || err != nil {
 		return false
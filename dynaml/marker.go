@@ -2,6 +2,7 @@ package dynaml
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/mandelsoft/spiff/debug"
@@ -16,6 +17,13 @@ const (
 	DEFAULT   = "&default"
 	STATE     = "&state"
 	DYNAMIC   = "&dynamic" // POC
+	DELETE    = "&delete"
+	FINAL     = "&final"
+
+	DEPRECATED = "&deprecated"
+
+	BEFORE = "&before:"
+	AFTER  = "&after:"
 )
 
 type MarkerExpr struct {
@@ -57,6 +65,35 @@ func (e MarkerExpr) GetTag() string {
 	return ""
 }
 
+// GetInsertion provides the requested position of a list entry
+// in the form before:<key> or after:<key>.
+func (e MarkerExpr) GetInsertion() string {
+	for _, m := range e.list {
+		if strings.HasPrefix(m, BEFORE) || strings.HasPrefix(m, AFTER) {
+			return m[1:]
+		}
+	}
+	return ""
+}
+
+// GetDeprecation provides the message of a deprecated marker and
+// whether there is such a marker.
+func (e MarkerExpr) GetDeprecation() (string, bool) {
+	for _, m := range e.list {
+		if strings.HasPrefix(m, DEPRECATED) {
+			msg := strings.TrimSpace(m[len(DEPRECATED):])
+			if strings.HasPrefix(msg, "(") {
+				msg = strings.TrimSpace(msg[1 : len(msg)-1])
+				if s, err := strconv.Unquote(msg); err == nil {
+					msg = s
+				}
+			}
+			return msg, true
+		}
+	}
+	return "", false
+}
+
 func (e MarkerExpr) GetFlags() yaml.NodeFlags {
 	var flags yaml.NodeFlags
 	for _, m := range e.list {
@@ -73,6 +110,14 @@ func (e MarkerExpr) GetFlags() yaml.NodeFlags {
 			flags.SetState()
 		case DYNAMIC:
 			flags.SetDynamic()
+		case DELETE:
+			flags.SetDeleted()
+		case FINAL:
+			flags.SetFinal()
+		default:
+			if strings.HasPrefix(m, DEPRECATED) {
+				flags.SetDeprecated()
+			}
 		}
 	}
 	return flags
@@ -18,7 +18,15 @@ func func_md5crypt(arguments []interface{}, binding Binding) (interface{}, Evalu
 		return info.Error("first argument for md5crypt must be a string")
 	}
 
-	result := crypt.MD5Crypt([]byte(passwd), crypt.GenerateSALT(8), []byte(crypt.MD5_MAGIC))
+	salt := crypt.GenerateSALT(8)
+	if IsDeterministic(binding) {
+		var err error
+		salt, err = crypt.GenerateSALTFrom(RandomSource(binding, "md5crypt", passwd), 8)
+		if err != nil {
+			return info.Error("md5crypt: %s", err)
+		}
+	}
+	result := crypt.MD5Crypt([]byte(passwd), salt, []byte(crypt.MD5_MAGIC))
 
 	return fmt.Sprintf("%s", result), info, true
 }
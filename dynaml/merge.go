@@ -36,6 +36,8 @@ func (e MergeExpr) Evaluate(binding Binding, locally bool) (interface{}, Evaluat
 		info.Replace = e.Replace
 		info.Merged = true
 		info.Source = node.SourceName()
+		info.Origin = origin(node)
+		info.KeyOrder = node.KeyOrder()
 		info.NodeFlags = node.Flags()
 		return node.Value(), info, ok
 	} else {
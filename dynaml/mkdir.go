@@ -44,12 +44,17 @@ func func_mkdir(arguments []interface{}, binding Binding) (interface{}, Evaluati
 		return info.Error("path argument must not be empty")
 	}
 
+	if err := policyOf(binding).CheckWrite(path); err != nil {
+		return info.Error("%s", err)
+	}
+
 	if len(arguments) == 2 {
 		wopt, err = getWriteOptions(arguments[1], wopt, true)
 	}
 	if err == nil {
 		err = binding.GetState().FileSystem().MkdirAll(path, os.FileMode(wopt.Permissions))
 		if err == nil {
+			journalOf(binding).Record(JournalEntry{Operation: JOURNAL_MKDIR, Path: path, Permissions: os.FileMode(wopt.Permissions)})
 			return path, info, true
 		}
 	}
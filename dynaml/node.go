@@ -13,6 +13,12 @@ func value(node yaml.Node) interface{} {
 	return node.Value()
 }
 
+// origin determines the origin of the value of a node.
+func origin(node yaml.Node) *yaml.Origin {
+	o := yaml.NodeOrigin(node)
+	return &o
+}
+
 func NewNode(val interface{}, src SourceProvider) yaml.Node {
 	source := ""
 
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/mandelsoft/spiff/debug"
+	"github.com/mandelsoft/spiff/yaml"
 )
 
 type helperNode struct {
@@ -156,6 +157,10 @@ func buildExpression(grammar *DynamlGrammar, path []string, stubPath []string) (
 			return tokens.Pop(), nil
 
 		case ruleTagMarker:
+		case ruleDeprecatedMarker:
+		case ruleDeprecationMessage:
+		case ruleInsertionMarker:
+		case ruleInsertionKey:
 		case ruleMarker:
 			tokens.Push(newMarkerExpr(contents))
 		case ruleSubsequentMarker:
@@ -198,7 +203,9 @@ func buildExpression(grammar *DynamlGrammar, path []string, stubPath []string) (
 		case ruleRequired:
 			required = true
 		case ruleOn:
-			keyName = tokens.Pop().(nameHelper).name
+		case ruleMergeKeys:
+			keyName = yaml.NormalizeKey(contents)
+		case ruleMergeKey:
 		case ruleFollowUpRef:
 		case ruleReference:
 			tag := ""
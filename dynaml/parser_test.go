@@ -45,6 +45,12 @@ var _ = Describe("parsing", func() {
 			"&local",
 			"&default",
 			"&tag:test",
+			"&delete",
+			"&before:alice",
+			"&after:Deployment,foo",
+			"&final",
+			"&deprecated",
+			`&deprecated("use \"bar\"")`,
 		}
 		var entries []TableEntry
 		for _, m := range markers {
@@ -59,6 +65,14 @@ var _ = Describe("parsing", func() {
 			parsesAs("&template &temporary", MarkerExpr{[]string{"&template", "&temporary"}, nil})
 		})
 
+		It("parses deprecation messages", func() {
+			msg, ok := MarkerExpr{[]string{`&deprecated("use \"bar\"")`}, nil}.GetDeprecation()
+			Expect(ok).To(BeTrue())
+			Expect(msg).To(Equal(`use "bar"`))
+			parsesAs(`&deprecated("old") ("value")`, MarkerExpr{[]string{`&deprecated("old")`},
+				MarkerExpressionExpr{`("value")`, GroupedExpr{StringExpr{"value"}}}})
+		})
+
 		It("parses marked expression", func() {
 			parsesAs("&template &temporary(5)", MarkerExpr{[]string{"&template", "&temporary"},
 				MarkerExpressionExpr{"(5)", GroupedExpr{IntegerExpr{5}}}})
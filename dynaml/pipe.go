@@ -9,12 +9,27 @@ func func_pipe(cached bool, arguments []interface{}, binding Binding) (interface
 	var cache ExecCache
 	info := DefaultInfo()
 
-	if len(arguments) <= 2 {
+	if len(arguments) < 2 {
 		return info.Error("pipe requires at least two arguments")
 	}
 	if !binding.GetState().OSAccessAllowed() {
 		return info.DenyOSOperation("pipe")
 	}
+	if !cached && IsDeterministic(binding) {
+		return info.Error("pipe_uncached not possible in deterministic mode")
+	}
+	if opts, ok := arguments[1].(map[string]yaml.Node); ok && len(arguments) == 2 {
+		data, _, err := getArg(0, arguments[0], WriteOpts{}, true)
+		if err != nil {
+			return info.Error("pipe: invalid data: %s", err)
+		}
+		return execOptionsCall(cached, "pipe", opts, &data, binding)
+	}
+	if len(arguments) == 2 {
+		if _, ok := arguments[1].([]yaml.Node); !ok {
+			return info.Error("pipe requires a command")
+		}
+	}
 	if cached {
 		cache = binding.GetState().GetExecCache()
 	}
@@ -53,9 +68,12 @@ func func_pipe(cached bool, arguments []interface{}, binding Binding) (interface
 			args = append(args, v)
 		}
 	}
-	result, err := cachedExecute(cache, &args[0], args[1:])
+	if err := policyOf(binding).CheckExecutable(args[1]); err != nil {
+		return info.Error("%s", err)
+	}
+	result, err := cachedExecute(cache, journalOf(binding), &args[0], args[1:])
 	if err != nil {
-		return info.Error("execution '%s' failed", args[1])
+		return info.Error("%s", err)
 	}
 
 	return convertOutput(result)
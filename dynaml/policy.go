@@ -0,0 +1,200 @@
+package dynaml
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+// Policy restricts the access to external resources granted by the
+// processing mode. A nil list does not restrict the access, an empty
+// list denies any access of the dedicated kind.
+type Policy struct {
+	// Executables lists the commands allowed for exec and pipe.
+	// Entries without a slash match commands called by name (searched
+	// in the PATH), other entries match the path of the command.
+	Executables []string `json:"executables,omitempty"`
+	// Read lists the path roots allowed for reading files.
+	Read []string `json:"read,omitempty"`
+	// Write lists the path roots allowed for writing files and
+	// creating directories.
+	Write []string `json:"write,omitempty"`
+	// Hosts lists the hosts allowed for reading http(s) URLs. Entries
+	// may use glob patterns (like *.example.com) and may contain a port.
+	Hosts []string `json:"hosts,omitempty"`
+	// Env lists the environment variables accessible by the env function.
+	// Entries may use glob patterns (like SPIFF_*).
+	Env []string `json:"env,omitempty"`
+}
+
+// ParsePolicy parses a yaml or json policy document.
+func ParsePolicy(name string, data []byte) (*Policy, error) {
+	node, err := yaml.Parse(name, data)
+	if err != nil {
+		return nil, err
+	}
+	if node == nil || node.Value() == nil {
+		return &Policy{}, nil
+	}
+	if _, ok := node.Value().(map[string]yaml.Node); !ok {
+		return nil, fmt.Errorf("policy must be a map")
+	}
+	data, err = yaml.ToJSON(node)
+	if err != nil {
+		return nil, err
+	}
+	policy := &Policy{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(policy); err != nil {
+		return nil, fmt.Errorf("invalid policy: %s", err)
+	}
+	return policy, nil
+}
+
+// PolicyError is the error reported for a denied access.
+type PolicyError struct {
+	Kind     string
+	Resource string
+}
+
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("policy violation: %s %q not allowed", e.Kind, e.Resource)
+}
+
+// CheckExecutable checks whether a command may be executed.
+func (p *Policy) CheckExecutable(cmd string) error {
+	if p == nil || p.Executables == nil {
+		return nil
+	}
+	for _, e := range p.Executables {
+		if !strings.Contains(e, "/") {
+			if e == cmd {
+				return nil
+			}
+			continue
+		}
+		if strings.Contains(cmd, "/") && absPath(e) == absPath(cmd) {
+			return nil
+		}
+	}
+	return &PolicyError{"executable", cmd}
+}
+
+// CheckRead checks whether a file may be read.
+func (p *Policy) CheckRead(file string) error {
+	if p == nil || p.Read == nil || underRoots(p.Read, file) {
+		return nil
+	}
+	return &PolicyError{"read access to", file}
+}
+
+// CheckWrite checks whether a file may be written.
+func (p *Policy) CheckWrite(file string) error {
+	if p == nil || p.Write == nil || underRoots(p.Write, file) {
+		return nil
+	}
+	return &PolicyError{"write access to", file}
+}
+
+// CheckURL checks whether a URL may be read.
+func (p *Policy) CheckURL(u string) error {
+	if p == nil || p.Hosts == nil {
+		return nil
+	}
+	parsed, err := url.Parse(u)
+	if err == nil {
+		for _, h := range p.Hosts {
+			pattern := strings.ToLower(h)
+			if matchPattern(pattern, strings.ToLower(parsed.Hostname())) || matchPattern(pattern, strings.ToLower(parsed.Host)) {
+				return nil
+			}
+		}
+	}
+	return &PolicyError{"access to URL", u}
+}
+
+// CheckEnv checks whether an environment variable may be read.
+func (p *Policy) CheckEnv(name string) error {
+	if p == nil || p.Env == nil {
+		return nil
+	}
+	for _, e := range p.Env {
+		if matchPattern(e, name) {
+			return nil
+		}
+	}
+	return &PolicyError{"environment variable", name}
+}
+
+func matchPattern(pattern, s string) bool {
+	ok, err := path.Match(pattern, s)
+	return err == nil && ok
+}
+
+func absPath(p string) string {
+	a, err := filepath.Abs(p)
+	if err != nil {
+		return filepath.Clean(p)
+	}
+	return a
+}
+
+// realPath provides the absolute path of a file with all symbolic links
+// resolved. For files not existing yet the longest existing parent
+// directory is resolved.
+func realPath(p string) string {
+	return resolvePath(p, 0)
+}
+
+func resolvePath(p string, depth int) string {
+	p = absPath(p)
+	rest := ""
+	for {
+		if r, err := filepath.EvalSymlinks(p); err == nil {
+			return filepath.Join(r, rest)
+		}
+		dir, base := filepath.Split(p)
+		dir = filepath.Clean(dir)
+		if dir == p {
+			return filepath.Join(p, rest)
+		}
+		if link, err := os.Readlink(p); err == nil && depth < 255 {
+			// dangling link: follow the link target
+			if !filepath.IsAbs(link) {
+				link = filepath.Join(dir, link)
+			}
+			return resolvePath(filepath.Join(link, rest), depth+1)
+		}
+		p, rest = dir, filepath.Join(base, rest)
+	}
+}
+
+// underRoots checks whether a file is located under one of the given
+// roots. Symbolic links are resolved for the file and the roots, so
+// links cannot be used to escape a root.
+func underRoots(roots []string, file string) bool {
+	file = realPath(file)
+	for _, r := range roots {
+		r = realPath(r)
+		if file == r || strings.HasPrefix(file, strings.TrimSuffix(r, string(filepath.Separator))+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// policyOf provides the policy of a binding, if there is any.
+func policyOf(binding Binding) *Policy {
+	if binding == nil || binding.GetState() == nil {
+		return nil
+	}
+	return binding.GetState().GetPolicy()
+}
@@ -0,0 +1,202 @@
+package dynaml
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Profiler records the effort spent for a processing: the iterations
+// of the flow loops, the evaluations of nodes and the time spent in
+// functions and for processing the documents (template and stubs).
+type Profiler struct {
+	lock       sync.Mutex
+	iterations map[string]int
+	nodes      map[string]int
+	functions  map[string]*ProfileEntry
+	documents  map[string]*ProfileEntry
+}
+
+// ProfileEntry describes the effort spent for a function, a node or
+// a document.
+type ProfileEntry struct {
+	// Name is the function name, the node path or the document name.
+	Name string `json:"name"`
+	// Count is the number of calls, evaluations or flow iterations.
+	Count int `json:"count"`
+	// Duration is the cumulative time.
+	Duration time.Duration `json:"duration,omitempty"`
+}
+
+// ProfileReport is the result of a profiled processing.
+type ProfileReport struct {
+	// Iterations is the total number of flow loop iterations.
+	Iterations int `json:"iterations"`
+	// Documents describes the processing of the template and stubs,
+	// the count is the number of flow loop iterations.
+	Documents []ProfileEntry `json:"documents"`
+	// Functions describes the function calls. Lambda functions are
+	// reported with the location of their definition.
+	Functions []ProfileEntry `json:"functions"`
+	// Nodes lists the number of evaluations of nodes with expressions.
+	Nodes []ProfileEntry `json:"nodes"`
+}
+
+// NewProfiler creates a new profiler. The zero value is usable, also.
+func NewProfiler() *Profiler {
+	p := &Profiler{}
+	p.init()
+	return p
+}
+
+func (p *Profiler) init() {
+	if p.iterations == nil {
+		p.iterations = map[string]int{}
+		p.nodes = map[string]int{}
+		p.functions = map[string]*ProfileEntry{}
+		p.documents = map[string]*ProfileEntry{}
+	}
+}
+
+// Iteration records a flow loop iteration for a document.
+func (p *Profiler) Iteration(document string) {
+	if p == nil {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.init()
+	p.iterations[document]++
+}
+
+// Node records the evaluation of the expression of a node.
+func (p *Profiler) Node(document string, path []string) {
+	if p == nil {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.init()
+	p.nodes[document+":"+strings.Join(path, ".")]++
+}
+
+// Function records a function call.
+func (p *Profiler) Function(name string, d time.Duration) {
+	if p == nil {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.init()
+	addProfileEntry(p.functions, name, d)
+}
+
+// Document records the processing of a document.
+func (p *Profiler) Document(name string, d time.Duration) {
+	if p == nil {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.init()
+	addProfileEntry(p.documents, name, d)
+}
+
+func addProfileEntry(entries map[string]*ProfileEntry, name string, d time.Duration) {
+	e := entries[name]
+	if e == nil {
+		e = &ProfileEntry{Name: name}
+		entries[name] = e
+	}
+	e.Count++
+	e.Duration += d
+}
+
+// Report provides the recorded data. Entries are sorted by duration
+// or count, starting with the most expensive ones.
+func (p *Profiler) Report() *ProfileReport {
+	r := &ProfileReport{Documents: []ProfileEntry{}, Functions: []ProfileEntry{}, Nodes: []ProfileEntry{}}
+	if p == nil {
+		return r
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.init()
+
+	for n, c := range p.iterations {
+		r.Iterations += c
+		if p.documents[n] == nil {
+			r.Documents = append(r.Documents, ProfileEntry{Name: n, Count: c})
+		}
+	}
+	for n, e := range p.documents {
+		r.Documents = append(r.Documents, ProfileEntry{Name: n, Count: p.iterations[n], Duration: e.Duration})
+	}
+	for _, e := range p.functions {
+		r.Functions = append(r.Functions, *e)
+	}
+	for n, c := range p.nodes {
+		r.Nodes = append(r.Nodes, ProfileEntry{Name: n, Count: c})
+	}
+	sortEntries(r.Documents)
+	sortEntries(r.Functions)
+	sortEntries(r.Nodes)
+	return r
+}
+
+func sortEntries(list []ProfileEntry) {
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Duration != list[j].Duration {
+			return list[i].Duration > list[j].Duration
+		}
+		if list[i].Count != list[j].Count {
+			return list[i].Count > list[j].Count
+		}
+		return list[i].Name < list[j].Name
+	})
+}
+
+// Write prints the report as text. The node list is limited to the
+// given number of entries, if it is positive.
+func (r *ProfileReport) Write(w io.Writer, nodes int) {
+	fmt.Fprintf(w, "flow iterations: %d\n", r.Iterations)
+	fmt.Fprintf(w, "documents:\n")
+	for _, e := range r.Documents {
+		fmt.Fprintf(w, "  %12s %6d iterations  %s\n", e.Duration, e.Count, e.Name)
+	}
+	fmt.Fprintf(w, "functions:\n")
+	for _, e := range r.Functions {
+		fmt.Fprintf(w, "  %12s %6d calls       %s\n", e.Duration, e.Count, e.Name)
+	}
+	fmt.Fprintf(w, "node evaluations:\n")
+	for i, e := range r.Nodes {
+		if nodes > 0 && i >= nodes {
+			fmt.Fprintf(w, "  ... %d more\n", len(r.Nodes)-i)
+			break
+		}
+		fmt.Fprintf(w, "  %6d  %s\n", e.Count, e.Name)
+	}
+}
+
+// ProfilerOf provides the profiler of a binding, if there is any.
+func ProfilerOf(binding Binding) *Profiler {
+	if binding == nil || binding.GetState() == nil {
+		return nil
+	}
+	return binding.GetState().GetProfiler()
+}
+
+// profileCall records a function call started at the given time.
+// Lambda functions are named by the location of their definition.
+func profileCall(p *Profiler, name string, value interface{}, start time.Time) {
+	if name == "" {
+		name = "lambda"
+		if l, ok := value.(LambdaValue); ok && l.resolver != nil {
+			name = fmt.Sprintf("lambda %s:%s", l.resolver.SourceName(), strings.Join(l.resolver.Path(), "."))
+		}
+	}
+	p.Function(name, time.Since(start))
+}
@@ -2,6 +2,7 @@ package dynaml
 
 import (
 	"crypto/rand"
+	"io"
 	"math/big"
 	"regexp"
 )
@@ -9,9 +10,9 @@ import (
 const MaxUint = ^uint64(0)
 const MaxInt = int64(MaxUint >> 1)
 
-func randNumber(max int64) int64 {
+func randNumber(random io.Reader, max int64) int64 {
 	big := big.NewInt(max)
-	v, _ := rand.Int(rand.Reader, big)
+	v, _ := rand.Int(random, big)
 	return v.Int64()
 }
 
@@ -22,9 +23,10 @@ func func_rand(arguments []interface{}, binding Binding) (interface{}, Evaluatio
 	if len(arguments) > 2 {
 		return info.Error("rand takes a maximum of 2 arguments")
 	}
+	random := RandomSource(binding, "rand", arguments...)
 
 	if len(arguments) == 0 {
-		result = randNumber(MaxInt)
+		result = randNumber(random, MaxInt)
 	} else {
 		switch v := arguments[0].(type) {
 		case int64:
@@ -32,10 +34,10 @@ func func_rand(arguments []interface{}, binding Binding) (interface{}, Evaluatio
 				return info.Error("rand int takes only one argument")
 			}
 			if v < 0 {
-				result = -randNumber(-v)
+				result = -randNumber(random, -v)
 			} else {
 				if v > 0 {
-					result = randNumber(v)
+					result = randNumber(random, v)
 				} else {
 					return info.Error("zero range not possible for integer random values")
 				}
@@ -44,7 +46,7 @@ func func_rand(arguments []interface{}, binding Binding) (interface{}, Evaluatio
 			if len(arguments) > 1 {
 				return info.Error("rand bool takes only one argument")
 			}
-			result = randNumber(2) == 1
+			result = randNumber(random, 2) == 1
 		case string:
 			exp, err := regexp.Compile("^[" + v + "]")
 			if err != nil {
@@ -64,7 +66,7 @@ func func_rand(arguments []interface{}, binding Binding) (interface{}, Evaluatio
 			r := []byte{}
 			var buf [4]byte
 			for i := 0; i < length; {
-				rand.Read(buf[:])
+				io.ReadFull(random, buf[:])
 				if found := exp.Find(buf[:]); found != nil {
 					r = append(r, found...)
 					i++
@@ -0,0 +1,28 @@
+package dynaml
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// RandomSource provides the source of random data used by a function
+// evaluated for the actual node of a binding. In deterministic mode the
+// data is derived from the seed of the processing state, the node path,
+// the function name and the given arguments. Repeated identical calls
+// during the evaluation of a node expression are distinguished by the
+// processing state, so every rendering yields identical data.
+func RandomSource(binding Binding, function string, args ...interface{}) io.Reader {
+	if binding == nil || binding.GetState() == nil {
+		return rand.Reader
+	}
+	key := fmt.Sprintf("%s:%s%v", strings.Join(binding.Path(), "."), function, args)
+	return binding.GetState().GetRandomSource(key)
+}
+
+// IsDeterministic reports whether the processing of a binding is done
+// in deterministic mode.
+func IsDeterministic(binding Binding) bool {
+	return binding != nil && binding.GetState() != nil && binding.GetState().Deterministic()
+}
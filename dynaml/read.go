@@ -14,8 +14,8 @@ var templ_pattern = regexp.MustCompile(".*\\s+&template(\\(?|\\s+).*")
 func func_read(cached bool, arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
 	info := DefaultInfo()
 
-	if len(arguments) > 2 {
-		return info.Error("read takes a maximum of two arguments")
+	if len(arguments) > 3 {
+		return info.Error("read takes a maximum of three arguments")
 	}
 	if !binding.GetState().FileAccessAllowed() {
 		return info.DenyOSOperation("read")
@@ -30,6 +30,23 @@ func func_read(cached bool, arguments []interface{}, binding Binding) (interface
 	if strings.HasSuffix(file, ".yml") || strings.HasSuffix(file, ".yaml") || strings.HasSuffix(file, ".json") {
 		t = "yaml"
 	}
+	var opts *FetchOptions
+	if len(arguments) > 1 {
+		if m, ok := arguments[len(arguments)-1].(map[string]yaml.Node); ok {
+			var err error
+			opts, err = getFetchOptions(m)
+			if err != nil {
+				return info.Error("read: %s", err)
+			}
+			if !strings.HasPrefix(file, "http:") && !strings.HasPrefix(file, "https:") {
+				return info.Error("read: options are only supported for http(s) locations")
+			}
+			arguments = arguments[:len(arguments)-1]
+		}
+	}
+	if len(arguments) > 2 {
+		return info.Error("read: third argument must be an option map")
+	}
 	if len(arguments) > 1 {
 		t, ok = arguments[1].(string)
 		if !ok {
@@ -38,7 +55,13 @@ func func_read(cached bool, arguments []interface{}, binding Binding) (interface
 
 	}
 
-	data, err := binding.GetFileContent(file, cached)
+	var data []byte
+	var err error
+	if opts != nil {
+		data, err = binding.GetState().Fetch(file, opts)
+	} else {
+		data, err = binding.GetFileContent(file, cached)
+	}
 	if err != nil {
 		return info.Error("read: %s", err)
 	}
@@ -84,6 +107,7 @@ func ParseData(file string, data []byte, mode string, binding Binding) (interfac
 			return info.PropagateError(nil, state, "resolution of yaml file '%s' failed", file)
 		}
 		debug.Debug("resolving yaml file succeeded")
+		info.KeyOrder = result.KeyOrder()
 		return result.Value(), info, true
 	case "multiyaml":
 		nodes, err := yaml.ParseMulti(file, data)
@@ -107,6 +131,7 @@ func ParseData(file string, data []byte, mode string, binding Binding) (interfac
 			return info.Error("error parsing file [%s]: %s", path.Clean(file), err)
 		}
 		info.Raw = true
+		info.KeyOrder = node.KeyOrder()
 		debug.Debug("import yaml file succeeded")
 		return node.Value(), info, true
 	case "importmulti":
@@ -49,6 +49,7 @@ func (e ReferenceExpr) Evaluate(binding Binding, locally bool) (interface{}, Eva
 			}
 			if len(e.Path) == 1 && e.Path[0] == "" {
 				if len(tags) == 1 || tags[0].Name() == e.Tag {
+					info.Origin = origin(tags[0].Node())
 					return tags[0].Node().Value(), info, true
 				}
 				return info.Error("found multiple tags for '%s': %s", e.Tag, tagList(tags))
@@ -134,6 +135,8 @@ func (e ReferenceExpr) find(f func(int, []string) (node yaml.Node, x bool), bind
 
 	debug.Debug("reference %v -> %+v\n", e.Path, step)
 	info.KeyName = step.KeyName()
+	info.KeyOrder = step.KeyOrder()
+	info.Origin = origin(step)
 	return value(yaml.ReferencedNode(step)), info, true
 }
 
@@ -6,13 +6,17 @@ type Registry interface {
 	LookupControl(name string) (*Control, bool)
 	IsTemplateControlOption(name string) bool
 
+	LookupResolver(scheme string) Resolver
+
 	WithFunctions(Functions) Registry
 	WithControls(Controls) Registry
+	WithResolvers(Resolvers) Registry
 }
 
 type registry struct {
 	functions Functions
 	controls  Controls
+	resolvers Resolvers
 }
 
 func (r *registry) WithFunctions(f Functions) Registry {
@@ -22,6 +26,7 @@ func (r *registry) WithFunctions(f Functions) Registry {
 	return &registry{
 		functions: f,
 		controls:  r.controls,
+		resolvers: r.resolvers,
 	}
 }
 
@@ -32,6 +37,18 @@ func (r *registry) WithControls(c Controls) Registry {
 	return &registry{
 		functions: r.functions,
 		controls:  c,
+		resolvers: r.resolvers,
+	}
+}
+
+func (r *registry) WithResolvers(res Resolvers) Registry {
+	if r == nil {
+		return &registry{resolvers: res}
+	}
+	return &registry{
+		functions: r.functions,
+		controls:  r.controls,
+		resolvers: res,
 	}
 }
 
@@ -56,6 +73,13 @@ func (r *registry) IsTemplateControlOption(name string) bool {
 	return r.controls.IsTemplateControlOption(name)
 }
 
+func (r *registry) LookupResolver(scheme string) Resolver {
+	if r == nil || r.resolvers == nil {
+		return resolver_registry.LookupResolver(scheme)
+	}
+	return r.resolvers.LookupResolver(scheme)
+}
+
 func DefaultRegistry() Registry {
 	var r *registry
 	return r // standard behaviour support on nil pointer inter
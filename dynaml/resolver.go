@@ -0,0 +1,81 @@
+package dynaml
+
+import (
+	"strings"
+)
+
+// Resolver provides the content for locations of a dedicated
+// URL scheme. The location is passed including the scheme.
+type Resolver interface {
+	GetContent(state State, location string) ([]byte, error)
+}
+
+// ResolverFunc is a function used as Resolver.
+type ResolverFunc func(state State, location string) ([]byte, error)
+
+func (f ResolverFunc) GetContent(state State, location string) ([]byte, error) {
+	return f(state, location)
+}
+
+type Resolvers interface {
+	RegisterResolver(scheme string, r Resolver)
+	LookupResolver(scheme string) Resolver
+}
+
+type resolverRegistry struct {
+	resolvers map[string]Resolver
+}
+
+func NewResolvers() Resolvers {
+	return &resolverRegistry{map[string]Resolver{}}
+}
+
+func (r *resolverRegistry) RegisterResolver(scheme string, res Resolver) {
+	r.resolvers[strings.ToLower(scheme)] = res
+}
+
+func (r *resolverRegistry) LookupResolver(scheme string) Resolver {
+	scheme = strings.ToLower(scheme)
+	res := r.resolvers[scheme]
+	if res != nil || r == resolver_registry {
+		return res
+	}
+	return resolver_registry.(*resolverRegistry).resolvers[scheme]
+}
+
+func RegisterResolver(scheme string, r Resolver) {
+	resolver_registry.RegisterResolver(scheme, r)
+}
+
+var resolver_registry = NewResolvers()
+
+// Scheme provides the URL scheme of a location. Single letter schemes
+// are not considered to keep windows drive letters as regular paths.
+func Scheme(location string) string {
+	i := strings.Index(location, ":")
+	if i < 2 {
+		return ""
+	}
+	for j, c := range location[:i] {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case j > 0 && (c >= '0' && c <= '9' || c == '+' || c == '-' || c == '.'):
+		default:
+			return ""
+		}
+	}
+	return location[:i]
+}
+
+// LookupResolver provides the resolver responsible for a location, if
+// the location uses a registered scheme.
+func LookupResolver(registry Registry, location string) Resolver {
+	scheme := Scheme(location)
+	if scheme == "" {
+		return nil
+	}
+	if registry == nil {
+		return resolver_registry.LookupResolver(scheme)
+	}
+	return registry.LookupResolver(scheme)
+}
@@ -0,0 +1,180 @@
+package dynaml
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+func init() {
+	RegisterResolver("file", ResolverFunc(resolveFile))
+	RegisterResolver("http", ResolverFunc(resolveHTTP))
+	RegisterResolver("https", ResolverFunc(resolveHTTP))
+	RegisterResolver("env", ResolverFunc(resolveEnv))
+	RegisterResolver("data", ResolverFunc(resolveData))
+	RegisterResolver("stdin", ResolverFunc(resolveStdin))
+	RegisterResolver("archive", ResolverFunc(resolveArchive))
+}
+
+// resolveFile reads files given by file:<path> or file://<absolute path>.
+func resolveFile(state State, location string) ([]byte, error) {
+	path := strings.TrimPrefix(location[len("file:"):], "//")
+	if path == "" {
+		return nil, fmt.Errorf("file path required")
+	}
+	if err := state.GetPolicy().CheckRead(path); err != nil {
+		return nil, err
+	}
+	return state.FileSystem().ReadFile(path)
+}
+
+func resolveHTTP(state State, location string) ([]byte, error) {
+	return state.Fetch(location, nil)
+}
+
+// resolveEnv provides the value of an environment variable given
+// by env:<name>.
+func resolveEnv(state State, location string) ([]byte, error) {
+	name := location[len("env:"):]
+	if err := state.GetPolicy().CheckEnv(name); err != nil {
+		return nil, err
+	}
+	value, ok := getenv(name)
+	if !ok {
+		return nil, fmt.Errorf("environment variable '%s' not set", name)
+	}
+	return []byte(value), nil
+}
+
+// resolveData decodes data URLs (RFC 2397):
+// data:[<mediatype>][;base64],<data>
+func resolveData(state State, location string) ([]byte, error) {
+	i := strings.Index(location, ",")
+	if i < 0 {
+		return nil, fmt.Errorf("invalid data URL: missing ','")
+	}
+	header := location[len("data:"):i]
+	data := location[i+1:]
+	if strings.HasSuffix(header, ";base64") {
+		return base64.StdEncoding.DecodeString(data)
+	}
+	s, err := url.PathUnescape(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data URL: %s", err)
+	}
+	return []byte(s), nil
+}
+
+var stdin struct {
+	lock        sync.Mutex
+	once        sync.Once
+	data        []byte
+	err         error
+	unavailable string
+}
+
+// DisableStdin marks the standard input as not available for stdin:
+// locations, because it is already consumed or used otherwise by the
+// calling program. The reason is reported for such locations.
+func DisableStdin(reason string) {
+	stdin.lock.Lock()
+	defer stdin.lock.Unlock()
+	stdin.unavailable = reason
+}
+
+func stdinUnavailable() string {
+	stdin.lock.Lock()
+	defer stdin.lock.Unlock()
+	return stdin.unavailable
+}
+
+// resolveStdin provides the content of the standard input.
+// It is read once and shared by all stdin: locations.
+// It requires OS access and is refused if the standard input
+// has been disabled as data source.
+func resolveStdin(state State, location string) ([]byte, error) {
+	if !state.OSAccessAllowed() {
+		return nil, fmt.Errorf("standard input not available: os access not allowed")
+	}
+	if reason := stdinUnavailable(); reason != "" {
+		return nil, fmt.Errorf("standard input not available: %s", reason)
+	}
+	stdin.once.Do(func() {
+		stdin.data, stdin.err = ioutil.ReadAll(os.Stdin)
+	})
+	return stdin.data, stdin.err
+}
+
+// resolveArchive provides a file of a tar (optionally gzipped) or
+// zip archive given by archive:<archive location>#<path>.
+// The archive location may use any supported scheme.
+func resolveArchive(state State, location string) ([]byte, error) {
+	spec := location[len("archive:"):]
+	i := strings.LastIndex(spec, "#")
+	if i < 0 {
+		return nil, fmt.Errorf("invalid archive location: expected archive:<archive>#<path>")
+	}
+	file := normalizeArchivePath(spec[i+1:])
+	if file == "" {
+		return nil, fmt.Errorf("invalid archive location: path required")
+	}
+	data, err := state.GetFileContent(spec[:i], true)
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range r.File {
+			if normalizeArchivePath(f.Name) == file && !f.FileInfo().IsDir() {
+				rc, err := f.Open()
+				if err != nil {
+					return nil, err
+				}
+				defer rc.Close()
+				return ioutil.ReadAll(rc)
+			}
+		}
+		return nil, fmt.Errorf("%q not found in archive", file)
+	}
+
+	var reader io.Reader = bytes.NewReader(data)
+	if bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		reader, err = gzip.NewReader(reader)
+		if err != nil {
+			return nil, err
+		}
+	}
+	tr := tar.NewReader(reader)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("%q not found in archive", file)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if normalizeArchivePath(h.Name) == file && h.Typeflag != tar.TypeDir {
+			return ioutil.ReadAll(tr)
+		}
+	}
+}
+
+func normalizeArchivePath(p string) string {
+	for strings.HasPrefix(p, "./") {
+		p = p[2:]
+	}
+	return strings.Trim(p, "/")
+}
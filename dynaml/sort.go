@@ -16,6 +16,8 @@ func func_sort(arguments []interface{}, binding Binding) (result interface{}, in
 	if !ok {
 		return info.Error("argument for sort must be a list")
 	}
+	// never modify the argument, it might be shared with the document
+	list = append([]yaml.Node{}, list...)
 
 	var less Less
 
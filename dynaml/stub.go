@@ -56,5 +56,6 @@ func (e CallExpr) stub(binding Binding) (interface{}, EvaluationInfo, bool) {
 	if !ok {
 		return info.Error("'%s' not found in any stub", strings.Join(arg, "."))
 	}
+	info.KeyOrder = stub.KeyOrder()
 	return stub.Value(), info, ok
 }
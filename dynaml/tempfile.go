@@ -32,11 +32,15 @@ func func_tempfile(arguments []interface{}, binding Binding) (interface{}, Evalu
 	if err != nil {
 		return info.Error("cannot create temporary file: %s", err)
 	}
+	if err := policyOf(binding).CheckWrite(name); err != nil {
+		return info.Error("%s", err)
+	}
 
 	err = binding.GetState().FileSystem().WriteFile(name, []byte(data), os.FileMode(wopt.Permissions))
 	if err != nil {
 		return info.Error("cannot write file: %s", err)
 	}
+	journalOf(binding).Record(JournalEntry{Operation: JOURNAL_TEMPFILE, Path: name, Permissions: os.FileMode(wopt.Permissions)})
 
 	return name, info, true
 }
@@ -17,6 +17,7 @@ type UnresolvedNode struct {
 
 	Context []string
 	Path    []string
+	Cycle   []string // paths of the reference cycle the node is involved in
 }
 
 func PrintableNodeValue(node yaml.Node) interface{} {
@@ -58,7 +59,7 @@ func (e UnresolvedNodes) Issue(msgfmt string, args ...interface{}) (result yaml.
 		message := fmt.Sprintf(
 			format,
 			nv,
-			node.SourceName(),
+			yaml.SourceLocation(node.Node),
 			strings.Join(node.Context, "."),
 			strings.Join(node.Path, "."),
 			msg,
@@ -102,7 +103,7 @@ func (e UnresolvedNodes) Error() string {
 			format,
 			message,
 			val,
-			node.SourceName(),
+			yaml.SourceLocation(node.Node),
 			strings.Join(node.Context, "."),
 			strings.Join(node.Path, "."),
 			msg,
@@ -113,8 +114,11 @@ func (e UnresolvedNodes) Error() string {
 	return message
 }
 
-func tag(node yaml.Node) string {
+func tag(node UnresolvedNode) string {
 	tag := " "
+	if node.Cycle != nil {
+		return "!"
+	}
 	if !node.Failed() {
 		tag = "@"
 	} else {
@@ -144,7 +148,32 @@ func nestedIssues(issue yaml.Issue) string {
 	return strings.Replace(message, "\n", "\n"+gap, -1)
 }
 
+// FindUnresolvedNodes determines the unresolved nodes of a document.
+// Nodes with local errors are reported first, followed by nodes involved
+// in reference cycles, nodes depending on other unresolved nodes, and
+// finally nodes failing because of referring to failed nodes.
 func FindUnresolvedNodes(root yaml.Node, context ...string) (result []UnresolvedNode) {
+	nodes := findUnresolvedNodes(root, context...)
+	markCycles(root, nodes)
+	for _, n := range nodes {
+		if n.HasError() {
+			result = append(result, n)
+		}
+	}
+	for _, n := range nodes {
+		if !n.HasError() && n.Cycle != nil {
+			result = append(result, n)
+		}
+	}
+	for _, n := range nodes {
+		if !n.HasError() && n.Cycle == nil {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+func findUnresolvedNodes(root yaml.Node, context ...string) (result []UnresolvedNode) {
 	if root == nil {
 		return result
 	}
@@ -158,7 +187,7 @@ func FindUnresolvedNodes(root yaml.Node, context ...string) (result []Unresolved
 		for key, val := range val {
 			nodes = append(
 				nodes,
-				FindUnresolvedNodes(val, addContext(context, key)...)...,
+				findUnresolvedNodes(val, addContext(context, key)...)...,
 			)
 		}
 
@@ -168,7 +197,7 @@ func FindUnresolvedNodes(root yaml.Node, context ...string) (result []Unresolved
 
 			nodes = append(
 				nodes,
-				FindUnresolvedNodes(val, context...)...,
+				findUnresolvedNodes(val, context...)...,
 			)
 		}
 
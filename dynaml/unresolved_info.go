@@ -0,0 +1,109 @@
+package dynaml
+
+import (
+	"fmt"
+
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+// Classifications of unresolved nodes. They correspond to the tags used
+// by the textual error representation.
+const (
+	CLASS_ERROR      = "error"      // *: error in local dynaml expression
+	CLASS_CYCLE      = "cycle"      // !: involved in a reference cycle
+	CLASS_UNRESOLVED = "unresolved" // @: dependent on unresolved nodes
+	CLASS_FAILED     = "failed"     // -: depending on a node with an error
+)
+
+// UnresolvedNodeInfo is the structured description of an unresolved node
+// intended for machine processing.
+type UnresolvedNodeInfo struct {
+	Expression     string      `json:"expression,omitempty"`
+	Value          string      `json:"value,omitempty"`
+	Source         string      `json:"source,omitempty"`
+	Line           int         `json:"line,omitempty"`
+	Column         int         `json:"column,omitempty"`
+	Context        []string    `json:"context"`
+	Path           []string    `json:"path"`
+	Tag            string      `json:"tag"`
+	Classification string      `json:"classification"`
+	Issue          string      `json:"issue,omitempty"`
+	Nested         []IssueInfo `json:"nested,omitempty"`
+	CallStack      []string    `json:"callStack,omitempty"`
+	Cycle          []string    `json:"cycle,omitempty"`
+}
+
+// IssueInfo is the structured description of a nested issue.
+// A sequence issue describes the chain of evaluation steps
+// (for example lambda calls) by its nested issues.
+type IssueInfo struct {
+	Issue    string      `json:"issue"`
+	Sequence bool        `json:"sequence,omitempty"`
+	Nested   []IssueInfo `json:"nested,omitempty"`
+}
+
+// Info provides the structured description of all unresolved nodes.
+func (e UnresolvedNodes) Info() []UnresolvedNodeInfo {
+	result := []UnresolvedNodeInfo{}
+	for _, n := range e.Nodes {
+		result = append(result, n.Info())
+	}
+	return result
+}
+
+// Info provides the structured description of an unresolved node.
+func (n UnresolvedNode) Info() UnresolvedNodeInfo {
+	info := UnresolvedNodeInfo{
+		Source:  n.SourceName(),
+		Context: n.Context,
+		Path:    n.Path,
+		Tag:     tag(n),
+		Cycle:   n.Cycle,
+	}
+	if info.Context == nil {
+		info.Context = []string{}
+	}
+	if info.Path == nil {
+		info.Path = []string{}
+	}
+	if pos := n.SourcePosition(); pos.IsValid() {
+		info.Line = pos.Line
+		info.Column = pos.Column
+	}
+	switch v := PrintableNodeValue(n).(type) {
+	case Expression:
+		info.Expression = fmt.Sprintf("%s", v)
+	default:
+		info.Value = fmt.Sprintf("%v", v)
+	}
+	switch info.Tag {
+	case "*":
+		info.Classification = CLASS_ERROR
+	case "!":
+		info.Classification = CLASS_CYCLE
+	case "@":
+		info.Classification = CLASS_UNRESOLVED
+	default:
+		info.Classification = CLASS_FAILED
+	}
+
+	issue := n.Issue()
+	info.Issue = issue.Issue
+	info.Nested = nestedIssueInfos(issue)
+	for issue.Sequence && len(issue.Nested) > 0 {
+		if len(info.CallStack) == 0 {
+			info.CallStack = append(info.CallStack, issue.Issue)
+		}
+		issue = issue.Nested[0]
+		info.CallStack = append(info.CallStack, issue.Issue)
+	}
+	return info
+}
+
+func nestedIssueInfos(issue yaml.Issue) []IssueInfo {
+	var result []IssueInfo
+	for _, sub := range issue.Nested {
+		result = append(result, IssueInfo{sub.Issue, sub.Sequence, nestedIssueInfos(sub)})
+	}
+	return result
+}
@@ -36,6 +36,10 @@ func func_validate(arguments []interface{}, binding Binding) (bool, interface{},
 			return false, nil, info, true
 		}
 		if !r {
+			if binding.GetFeatures().LenientValidationEnabled() {
+				info.AddWarning("condition %d failed: %s", i+1, m)
+				continue
+			}
 			info.SetError("condition %d failed: %s", i+1, m)
 			return true, nil, info, false
 		}
@@ -0,0 +1,106 @@
+package dynaml
+
+// VisitExpressions calls the given function for an expression and all
+// nested expressions in pre-order. The nested expressions of an expression
+// are skipped if the function returns false. Values embedded into an
+// expression, like lambda values or nodes, are not visited.
+func VisitExpressions(e Expression, f func(Expression) bool) {
+	if e == nil || !f(e) {
+		return
+	}
+	for _, n := range NestedExpressions(e) {
+		VisitExpressions(n, f)
+	}
+}
+
+// NestedExpressions provides the direct sub expressions of an expression.
+// Missing optional sub expressions are returned as nil.
+func NestedExpressions(e Expression) []Expression {
+	switch v := e.(type) {
+	case AdditionExpr:
+		return []Expression{v.A, v.B}
+	case SubtractionExpr:
+		return []Expression{v.A, v.B}
+	case MultiplicationExpr:
+		return []Expression{v.A, v.B}
+	case DivisionExpr:
+		return []Expression{v.A, v.B}
+	case ModuloExpr:
+		return []Expression{v.A, v.B}
+	case ConcatenationExpr:
+		return []Expression{v.A, v.B}
+	case ComparisonExpr:
+		return []Expression{v.A, v.B}
+	case LogAndExpr:
+		return []Expression{v.A, v.B}
+	case LogOrExpr:
+		return []Expression{v.A, v.B}
+	case OrExpr:
+		return []Expression{v.A, v.B}
+	case ValidOrExpr:
+		return []Expression{v.A, v.B}
+	case NotExpr:
+		return []Expression{v.Expr}
+	case GroupedExpr:
+		return []Expression{v.Expr}
+	case CondExpr:
+		return []Expression{v.C, v.T, v.F}
+	case DynamicExpr:
+		return []Expression{v.Root, v.Index}
+	case QualifiedExpr:
+		return []Expression{v.Expression, v.Reference}
+	case SliceExpr:
+		return []Expression{v.Expression, v.Range}
+	case RangeExpr:
+		return []Expression{v.Start, v.End}
+	case ListExpr:
+		return v.Contents
+	case ListExpansionExpr:
+		return []Expression{v.Expression}
+	case NameArgument:
+		return []Expression{v.Expression}
+	case CallExpr:
+		return append([]Expression{v.Function}, v.Arguments...)
+	case CatchExpr:
+		return []Expression{v.A, v.Lambda}
+	case MappingExpr:
+		return []Expression{v.A, v.Lambda}
+	case SumExpr:
+		return []Expression{v.A, v.I, v.Lambda}
+	case ProjectionExpr:
+		return []Expression{v.Expression, v.Projection}
+	case SyncExpr:
+		return []Expression{v.A, v.Cond, v.Value, v.Timeout}
+	case *SyncExpr:
+		return []Expression{v.A, v.Cond, v.Value, v.Timeout}
+	case LambdaExpr:
+		var nested []Expression
+		for _, p := range v.Parameters {
+			nested = append(nested, p.Default)
+		}
+		return append(nested, v.E)
+	case LambdaRefExpr:
+		return []Expression{v.Source}
+	case CreateMapExpr:
+		return assignedExpressions(v.Assignments)
+	case ScopeExpr:
+		return append(assignedExpressions(v.Assignments), v.E)
+	case SubstitutionExpr:
+		return []Expression{v.Template}
+	case MarkerExpr:
+		return []Expression{v.expr}
+	case MarkerExpressionExpr:
+		return []Expression{v.expr}
+	case PreferExpr:
+		return []Expression{v.expression}
+	}
+	return nil
+}
+
+func assignedExpressions(assignments []Assignment) []Expression {
+	var nested []Expression
+	for _, a := range assignments {
+		nested = append(nested, a.Key, a.Value)
+	}
+	return nested
+}
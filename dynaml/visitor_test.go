@@ -0,0 +1,52 @@
+package dynaml
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("expression visitor", func() {
+	refs := func(source string) []string {
+		expr, err := Parse(source, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		var found []string
+		VisitExpressions(expr, func(e Expression) bool {
+			if r, ok := e.(ReferenceExpr); ok {
+				found = append(found, r.String())
+			}
+			return true
+		})
+		return found
+	}
+
+	It("visits nested expressions in pre-order", func() {
+		Expect(refs(`a + f(b, [c, d.e]) || (g ? h : i)`)).To(Equal([]string{"a", "f", "b", "c", "d.e", "g", "h", "i"}))
+	})
+
+	It("visits scopes, lambdas and markers", func() {
+		Expect(refs(`( $x=a ) x + (|y,z=b|->y + c)(1)`)).To(Equal([]string{"a", "x", "b", "y", "c"}))
+		Expect(refs(`&temporary(a.b)`)).To(Equal([]string{"a.b"}))
+	})
+
+	It("skips nested expressions on request", func() {
+		expr, err := Parse(`a + (|x|->b)(c)`, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		var found []string
+		VisitExpressions(expr, func(e Expression) bool {
+			switch v := e.(type) {
+			case ReferenceExpr:
+				found = append(found, v.String())
+			case LambdaExpr:
+				return false
+			}
+			return true
+		})
+		Expect(found).To(Equal([]string{"a", "c"}))
+	})
+
+	It("determines the references used for cycles", func() {
+		expr, err := Parse(`a.b + f(c).d + (|x|->x)(e)`, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(references(expr)).To(Equal([][]string{{"a", "b"}, {"f"}, {"c"}, {"e"}}))
+	})
+})
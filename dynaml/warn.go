@@ -0,0 +1,18 @@
+package dynaml
+
+func func_warn(arguments []interface{}, binding Binding) (interface{}, EvaluationInfo, bool) {
+	info := DefaultInfo()
+
+	if len(arguments) < 1 || len(arguments) > 2 {
+		return info.Error("warn takes one or two arguments")
+	}
+	msg, ok := arguments[0].(string)
+	if !ok {
+		return info.Error("first argument for warn must be a string")
+	}
+	info.AddWarning("%s", msg)
+	if len(arguments) == 2 {
+		return arguments[1], info, true
+	}
+	return msg, info, true
+}
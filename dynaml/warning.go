@@ -0,0 +1,58 @@
+package dynaml
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Warning describes a suspicious, but valid situation found
+// during a processing.
+type Warning struct {
+	// Path is the path of the node the warning is reported for.
+	Path []string `json:"path,omitempty"`
+	// Message describes the situation.
+	Message string `json:"message"`
+}
+
+// String provides a single line description of the warning.
+func (w Warning) String() string {
+	if len(w.Path) == 0 {
+		return w.Message
+	}
+	return fmt.Sprintf("%s: %s", strings.Join(w.Path, "."), w.Message)
+}
+
+// Warnings collects the warnings of a processing. Nodes are
+// evaluated several times, therefore identical warnings are
+// recorded only once. The zero value is ready to use.
+type Warnings struct {
+	lock     sync.Mutex
+	warnings []Warning
+}
+
+// Add records a warning for a node path.
+func (w *Warnings) Add(path []string, msg string, args ...interface{}) {
+	if w == nil {
+		return
+	}
+	n := Warning{Path: append([]string{}, path...), Message: fmt.Sprintf(msg, args...)}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	for _, o := range w.warnings {
+		if o.String() == n.String() {
+			return
+		}
+	}
+	w.warnings = append(w.warnings, n)
+}
+
+// List provides the recorded warnings in the order of their occurrence.
+func (w *Warnings) List() []Warning {
+	if w == nil {
+		return nil
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return append([]Warning{}, w.warnings...)
+}
@@ -26,11 +26,12 @@ func func_genkey(arguments []interface{}, binding Binding) (interface{}, Evaluat
 	}
 	var key Key
 	var err error
+	random := RandomSource(binding, F_GenKey, ktype)
 	switch ktype {
 	case "private":
-		key, err = GeneratePrivateKey()
+		key, err = GeneratePrivateKeyFrom(random)
 	case "preshared":
-		key, err = GenerateKey()
+		key, err = GenerateKeyFrom(random)
 	default:
 		return info.Error("invalid key type %q, use private or preshared", ktype)
 	}
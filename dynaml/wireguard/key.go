@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"fmt"
+	"io"
 
 	"golang.org/x/crypto/curve25519"
 )
@@ -22,8 +23,14 @@ type Key [KeyLen]byte
 // The output Key should not be used as a private key; use GeneratePrivateKey
 // instead.
 func GenerateKey() (Key, error) {
+	return GenerateKeyFrom(rand.Reader)
+}
+
+// GenerateKeyFrom generates a Key suitable for use as a pre-shared secret key
+// from the given source of random data.
+func GenerateKeyFrom(random io.Reader) (Key, error) {
 	b := make([]byte, KeyLen)
-	if _, err := rand.Read(b); err != nil {
+	if _, err := io.ReadFull(random, b); err != nil {
 		return Key{}, fmt.Errorf("wgtypes: failed to read random bytes: %v", err)
 	}
 
@@ -33,7 +40,13 @@ func GenerateKey() (Key, error) {
 // GeneratePrivateKey generates a Key suitable for use as a private key from a
 // cryptographically safe source.
 func GeneratePrivateKey() (Key, error) {
-	key, err := GenerateKey()
+	return GeneratePrivateKeyFrom(rand.Reader)
+}
+
+// GeneratePrivateKeyFrom generates a Key suitable for use as a private key
+// from the given source of random data.
+func GeneratePrivateKeyFrom(random io.Reader) (Key, error) {
+	key, err := GenerateKeyFrom(random)
 	if err != nil {
 		return Key{}, err
 	}
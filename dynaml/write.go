@@ -34,8 +34,12 @@ func func_write(arguments []interface{}, binding Binding) (interface{}, Evaluati
 	if err == nil {
 		file, raw, data, _ := getData(file, wopt, 1, arguments[1], true)
 
+		if err := policyOf(binding).CheckWrite(file); err != nil {
+			return info.Error("%s", err)
+		}
 		err = binding.GetState().FileSystem().WriteFile(file, data, os.FileMode(wopt.Permissions))
 		if err == nil {
+			journalOf(binding).Record(JournalEntry{Operation: JOURNAL_WRITE, Path: file, Permissions: os.FileMode(wopt.Permissions)})
 			return raw, info, true
 		}
 	}
@@ -154,6 +154,9 @@ func func_x509cert(arguments []interface{}, binding Binding) (interface{}, Evalu
 		return info.Error(err)
 	}
 	if validFrom == "" {
+		if IsDeterministic(binding) {
+			return info.Error("validFrom required in deterministic mode")
+		}
 		notBefore = time.Now()
 	} else {
 		notBefore, err = time.Parse("Jan 2 15:04:05 2006", validFrom)
@@ -165,7 +168,7 @@ func func_x509cert(arguments []interface{}, binding Binding) (interface{}, Evalu
 	notAfter := notBefore.Add(time.Duration(validity) * time.Hour)
 
 	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
-	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	serialNumber, err := rand.Int(RandomSource(binding, F_Cert), serialNumberLimit)
 	if err != nil {
 		return info.Error("failed to generate serial number: %s", err)
 	}
@@ -0,0 +1,90 @@
+package x509
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// The key generation of the crypto packages does not guarantee to produce
+// the same key for the same random input, therefore keys for the
+// deterministic mode are generated here.
+
+var one = big.NewInt(1)
+
+// deterministicRSAKey generates an RSA key with public exponent 65537
+// using the primes found for the given source of random data.
+func deterministicRSAKey(random io.Reader, bits int) (*rsa.PrivateKey, error) {
+	if bits < 64 {
+		return nil, fmt.Errorf("rsa key size %d too small", bits)
+	}
+	e := big.NewInt(65537)
+	for {
+		p, err := deterministicPrime(random, bits-bits/2)
+		if err != nil {
+			return nil, err
+		}
+		q, err := deterministicPrime(random, bits/2)
+		if err != nil {
+			return nil, err
+		}
+		if p.Cmp(q) == 0 {
+			continue
+		}
+		pm := new(big.Int).Sub(p, one)
+		qm := new(big.Int).Sub(q, one)
+		d := new(big.Int).ModInverse(e, new(big.Int).Mul(pm, qm))
+		if d == nil {
+			continue
+		}
+		key := &rsa.PrivateKey{
+			PublicKey: rsa.PublicKey{N: new(big.Int).Mul(p, q), E: int(e.Int64())},
+			D:         d,
+			Primes:    []*big.Int{p, q},
+		}
+		key.Precompute()
+		return key, key.Validate()
+	}
+}
+
+// deterministicPrime provides the first prime of the given bit size with
+// the two topmost bits set found in the given source of random data.
+func deterministicPrime(random io.Reader, bits int) (*big.Int, error) {
+	buf := make([]byte, (bits+7)/8)
+	p := new(big.Int)
+	for {
+		if _, err := io.ReadFull(random, buf); err != nil {
+			return nil, err
+		}
+		buf[0] &= byte(0xff >> uint(len(buf)*8-bits))
+		p.SetBytes(buf)
+		p.SetBit(p, bits-1, 1)
+		p.SetBit(p, bits-2, 1)
+		p.SetBit(p, 0, 1)
+		if p.ProbablyPrime(20) {
+			return p, nil
+		}
+	}
+}
+
+// deterministicECDSAKey generates an ECDSA key following FIPS 186-3,
+// section B.4.1 with the given source of random data.
+func deterministicECDSAKey(random io.Reader, curve elliptic.Curve) (*ecdsa.PrivateKey, error) {
+	params := curve.Params()
+	buf := make([]byte, params.BitSize/8+8)
+	if _, err := io.ReadFull(random, buf); err != nil {
+		return nil, err
+	}
+	k := new(big.Int).SetBytes(buf)
+	n := new(big.Int).Sub(params.N, one)
+	k.Mod(k, n)
+	k.Add(k, one)
+
+	priv := &ecdsa.PrivateKey{D: k}
+	priv.PublicKey.Curve = curve
+	priv.PublicKey.X, priv.PublicKey.Y = curve.ScalarBaseMult(k.Bytes())
+	return priv, nil
+}
@@ -49,20 +49,34 @@ func func_x509genkey(arguments []interface{}, binding Binding) (interface{}, Eva
 	}
 
 	var priv interface{}
+	var curve elliptic.Curve
 	switch ecdsaCurve {
 	case "":
-		priv, err = rsa.GenerateKey(rand.Reader, int(rsaBits))
 	case "P224":
-		priv, err = ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+		curve = elliptic.P224()
 	case "P256":
-		priv, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		curve = elliptic.P256()
 	case "P384":
-		priv, err = ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		curve = elliptic.P384()
 	case "P521":
-		priv, err = ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+		curve = elliptic.P521()
 	default:
 		return info.Error("Unrecognized elliptic curve: %q", ecdsaCurve)
 	}
+	if IsDeterministic(binding) {
+		random := RandomSource(binding, F_GenKey, rsaBits, ecdsaCurve)
+		if curve == nil {
+			priv, err = deterministicRSAKey(random, int(rsaBits))
+		} else {
+			priv, err = deterministicECDSAKey(random, curve)
+		}
+	} else {
+		if curve == nil {
+			priv, err = rsa.GenerateKey(rand.Reader, int(rsaBits))
+		} else {
+			priv, err = ecdsa.GenerateKey(curve, rand.Reader)
+		}
+	}
 	if err != nil {
 		return info.Error("failed to generate private key: %s", err)
 	}
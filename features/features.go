@@ -8,6 +8,7 @@ import (
 
 const INTERPOLATION = "interpolation"
 const CONTROL = "control"
+const LENIENT_VALIDATION = "lenient-validation"
 
 type FeatureFlags map[string]struct{}
 
@@ -27,6 +28,7 @@ func (this FeatureFlags) Set(name string, active bool) error {
 	switch name {
 	case INTERPOLATION:
 	case CONTROL:
+	case LENIENT_VALIDATION:
 	default:
 		return fmt.Errorf("unknown feature flag %q", name)
 	}
@@ -56,6 +58,13 @@ func (this FeatureFlags) SetControl(active bool) {
 	this.Set(CONTROL, active)
 }
 
+func (this FeatureFlags) LenientValidationEnabled() bool {
+	return this.Enabled(LENIENT_VALIDATION)
+}
+func (this FeatureFlags) SetLenientValidation(active bool) {
+	this.Set(LENIENT_VALIDATION, active)
+}
+
 func Features() FeatureFlags {
 	features := FeatureFlags{}
 	// setup defaults
@@ -1,6 +1,8 @@
 package flow
 
 import (
+	"time"
+
 	"github.com/mandelsoft/spiff/dynaml"
 	"github.com/mandelsoft/spiff/yaml"
 )
@@ -18,7 +20,9 @@ type Options struct {
 func PrepareStubs(outer dynaml.Binding, partial bool, stubs ...yaml.Node) ([]yaml.Node, error) {
 	for i := len(stubs) - 1; i >= 0; i-- {
 		ResetStream(outer)
+		start := time.Now()
 		flowed, err := NestedFlow(outer, stubs[i], stubs[i+1:]...)
+		dynaml.ProfilerOf(outer).Document(stubs[i].SourceName(), time.Since(start))
 		if !partial && err != nil {
 			return nil, err
 		}
@@ -30,8 +34,38 @@ func PrepareStubs(outer dynaml.Binding, partial bool, stubs ...yaml.Node) ([]yam
 }
 
 func Apply(outer dynaml.Binding, template yaml.Node, prepared []yaml.Node, opts Options) (yaml.Node, error) {
+	params, err := Parameters(template)
+	if err != nil {
+		return nil, err
+	}
+	var perrs ParameterErrors
+	if len(params) > 0 {
+		if outer == nil {
+			outer = NewEnvironment(nil, template.SourceName(), NewDefaultState())
+		}
+		var defaults yaml.Node
+		template = withoutParameters(template)
+		defaults, perrs = parameterDefaults(outer, params, prepared, template.SourceName())
+		if defaults != nil {
+			prepared = append([]yaml.Node{defaults}, prepared...)
+		}
+	}
+
+	start := time.Now()
 	result, err := NestedFlow(outer, template, prepared...)
+	dynaml.ProfilerOf(outer).Document(template.SourceName(), time.Since(start))
+	if err == nil && len(params) > 0 {
+		perrs = append(perrs, checkParameters(outer, params, result)...)
+	}
+	if len(perrs) > 0 {
+		if err != nil {
+			err = InvalidParameters{perrs, err}
+		} else {
+			err = perrs
+		}
+	}
 	if err == nil {
+		result = Cleanup(result, discardDeleted)
 		if !opts.PreserveTemporary {
 			result = Cleanup(result, discardTemporary)
 		}
@@ -59,6 +93,13 @@ func discardTemporary(node yaml.Node) (yaml.Node, CleanupFunction) {
 	return node, discardTemporary
 }
 
+func discardDeleted(node yaml.Node) (yaml.Node, CleanupFunction) {
+	if node.Flags().Deleted() {
+		return nil, discardDeleted
+	}
+	return node, discardDeleted
+}
+
 func discardTags(node yaml.Node) (yaml.Node, CleanupFunction) {
 	if node.GetAnnotation().Tag() != "" {
 		return yaml.SetTag(node, ""), discardTags
@@ -122,6 +163,28 @@ func Cleanup(node yaml.Node, test CleanupFunction) yaml.Node {
 	return yaml.ReplaceValue(value, node)
 }
 
+// Visit calls the given function for all nodes of a tree
+// without modifying it.
+func Visit(node yaml.Node, test CleanupFunction) {
+	if node == nil {
+		return
+	}
+	switch v := node.Value().(type) {
+	case []yaml.Node:
+		for _, e := range v {
+			if n, t := test(e); n != nil {
+				Visit(n, t)
+			}
+		}
+	case map[string]yaml.Node:
+		for _, e := range v {
+			if n, t := test(e); n != nil {
+				Visit(n, t)
+			}
+		}
+	}
+}
+
 func DetermineState(node yaml.Node) yaml.Node {
 	return Cleanup(node, DiscardNonState)
 }
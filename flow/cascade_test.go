@@ -1,10 +1,14 @@
 package flow
 
 import (
+	"errors"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/mandelsoft/spiff/dynaml"
 	"github.com/mandelsoft/spiff/features"
+	"github.com/mandelsoft/spiff/yaml"
 )
 
 var _ = Describe("Cascading YAML templates", func() {
@@ -181,6 +185,178 @@ list:
     attr: b
   - address: c
     attr: stub
+`)
+				Expect(source).To(CascadeAs(resolved, stub))
+			})
+		})
+
+		Context("deleting and positioning entries", func() {
+			It("deletes entries marked in stubs", func() {
+				source := parseYAML(`
+---
+list:
+  - name: alice
+    age: 25
+  - name: bob
+    age: 24
+  - name: peter
+    age: 13
+`)
+				stub := parseYAML(`
+---
+list:
+  - name: bob
+    <<: (( &delete ))
+  - name: peter
+    age: 14
+`)
+				resolved := parseYAML(`
+---
+list:
+  - name: alice
+    age: 25
+  - name: peter
+    age: 14
+`)
+				Expect(source).To(CascadeAs(resolved, stub))
+			})
+
+			It("deletes entries with key tags", func() {
+				source := parseYAML(`
+---
+list:
+  - key:address: a
+    attr: b
+  - address: c
+    attr: d
+`)
+				stub := parseYAML(`
+---
+list:
+  - address: a
+    <<: (( &delete ))
+`)
+				resolved := parseYAML(`
+---
+list:
+  - address: c
+    attr: d
+`)
+				Expect(source).To(CascadeAs(resolved, stub))
+			})
+
+			It("does not insert deleted entries", func() {
+				source := parseYAML(`
+---
+list:
+  - <<: (( merge ))
+  - name: alice
+    age: 25
+`)
+				stub := parseYAML(`
+---
+list:
+  - name: alice
+    <<: (( &delete ))
+  - name: bob
+    <<: (( &delete ))
+  - name: peter
+    age: 13
+`)
+				resolved := parseYAML(`
+---
+list:
+  - name: peter
+    age: 13
+`)
+				Expect(source).To(CascadeAs(resolved, stub))
+			})
+
+			It("deletes entries in multiple stubs", func() {
+				source := parseYAML(`
+---
+list:
+  - name: alice
+    age: 25
+  - name: bob
+    age: 24
+`)
+				stub1 := parseYAML(`
+---
+list:
+  - name: bob
+    <<: (( &delete ))
+`)
+				stub2 := parseYAML(`
+---
+list:
+  - name: alice
+    age: 26
+`)
+				resolved := parseYAML(`
+---
+list:
+  - name: alice
+    age: 26
+`)
+				Expect(source).To(CascadeAs(resolved, stub1, stub2))
+			})
+
+			It("positions inserted entries", func() {
+				source := parseYAML(`
+---
+list:
+  - name: alice
+  - name: bob
+  - <<: (( merge ))
+`)
+				stub := parseYAML(`
+---
+list:
+  - name: first
+    <<: (( &before:alice ))
+  - name: second
+    <<: (( &after:alice ))
+  - name: third
+    <<: (( &after:alice ))
+  - name: last
+  - name: unknown
+    <<: (( &before:peter ))
+`)
+				resolved := parseYAML(`
+---
+list:
+  - name: first
+  - name: alice
+  - name: second
+  - name: third
+  - name: bob
+  - name: last
+  - name: unknown
+`)
+				Expect(source).To(CascadeAs(resolved, stub))
+			})
+
+			It("positions inserted entries with other keys", func() {
+				source := parseYAML(`
+---
+list:
+  - <<: (( merge on key ))
+  - key: alice
+  - key: bob
+`)
+				stub := parseYAML(`
+---
+list:
+  - key: peter
+    <<: (( &before:bob ))
+`)
+				resolved := parseYAML(`
+---
+list:
+  - key: alice
+  - key: peter
+  - key: bob
 `)
 				Expect(source).To(CascadeAs(resolved, stub))
 			})
@@ -1007,6 +1183,205 @@ alice: paul
 		})
 	})
 
+	Describe("using final and deprecated nodes", func() {
+		It("keeps final nodes without override", func() {
+			source := parseYAML(`
+---
+version: (( &final("v1") ))
+security:
+  <<: (( &final ))
+  tls: true
+other: alice
+`)
+			stub := parseYAML(`
+---
+other: bob
+`)
+			resolved := parseYAML(`
+---
+version: v1
+security:
+  tls: true
+other: bob
+`)
+			Expect(source).To(CascadeAs(resolved, stub))
+		})
+
+		It("applies final markers of stubs to downstream stubs only", func() {
+			source := parseYAML(`
+---
+version: v1
+`)
+			stub := parseYAML(`
+---
+version: (( &final("v2") ))
+`)
+			resolved := parseYAML(`
+---
+version: v2
+`)
+			Expect(source).To(CascadeAs(resolved, stub))
+		})
+
+		It("reports overridden deprecated nodes as warnings", func() {
+			source := parseYAML(`
+---
+old: (( &deprecated("use new instead") ("alice") ))
+oldmap:
+  <<: (( &deprecated ))
+  value: alice
+unused: (( &deprecated("not set") ("alice") ))
+`)
+			stub := parseYAML(`
+---
+old: bob
+oldmap:
+  value: bob
+`, "stub")
+			resolved := parseYAML(`
+---
+old: bob
+oldmap:
+  value: bob
+unused: alice
+`)
+			state := NewDefaultState()
+			result, err := Cascade(NewEnvironment(nil, "", state), source, Options{}, stub)
+			Expect(err).To(Succeed())
+			Expect(result.EquivalentToNode(resolved)).To(BeTrue())
+			Expect(state.GetWarnings().List()).To(ConsistOf(
+				dynaml.Warning{Path: []string{"old"}, Message: "deprecated node overridden by stub:3:6: use new instead"},
+				dynaml.Warning{Path: []string{"oldmap"}, Message: "deprecated node overridden by stub:5:3"},
+			))
+		})
+
+		It("keeps the warnings of different states apart", func() {
+			source := parseYAML(`
+---
+value: (( warn("outdated", 1) ))
+`)
+			first := NewDefaultState()
+			_, err := Cascade(NewEnvironment(nil, "", first), source, Options{})
+			Expect(err).To(Succeed())
+			second := NewDefaultState()
+			_, err = Cascade(NewEnvironment(nil, "", second), source, Options{})
+			Expect(err).To(Succeed())
+			Expect(first.GetWarnings().List()).To(HaveLen(1))
+			Expect(second.GetWarnings().List()).To(HaveLen(1))
+		})
+	})
+
+	Describe("using parameter declarations", func() {
+		var source yaml.Node
+
+		BeforeEach(func() {
+			source = parseYAML(`
+---
+__params:
+  host:
+    description: host name
+    type: string
+  network.port:
+    type: int
+    default: 8080
+    validate:
+      - [ "gt", 0 ]
+host: (( merge ))
+network:
+  port: (( merge ))
+  url: (( "http://" host ":" port ))
+`)
+		})
+
+		It("uses defaults for parameters not set by stubs", func() {
+			stub := parseYAML(`
+---
+host: example.org
+`)
+			resolved := parseYAML(`
+---
+host: example.org
+network:
+  port: 8080
+  url: http://example.org:8080
+`)
+			Expect(source).To(CascadeAs(resolved, stub))
+		})
+
+		It("uses parameters set by stubs", func() {
+			stub1 := parseYAML(`
+---
+host: example.org
+`)
+			stub2 := parseYAML(`
+---
+network:
+  port: 80
+`)
+			resolved := parseYAML(`
+---
+host: example.org
+network:
+  port: 80
+  url: http://example.org:80
+`)
+			Expect(source).To(CascadeAs(resolved, stub1, stub2))
+		})
+
+		It("fails for missing required parameters", func() {
+			_, err := Cascade(NewEnvironment(nil, ""), source, Options{})
+			var perrs ParameterErrors
+			Expect(errors.As(err, &perrs)).To(BeTrue())
+			Expect(perrs).To(Equal(ParameterErrors{{"host", "parameter must be set by a stub"}}))
+			var unresolved dynaml.UnresolvedNodes
+			Expect(errors.As(err, &unresolved)).To(BeTrue())
+			Expect(err.Error()).To(HavePrefix("invalid template parameters:\n\thost: parameter must be set by a stub\n"))
+			Expect(err.Error()).To(ContainSubstring("'host' not found in any stub"))
+		})
+
+		It("fails for invalid parameter values", func() {
+			stub := parseYAML(`
+---
+host: 1
+network:
+  port: 0
+`)
+			_, err := Cascade(NewEnvironment(nil, ""), source, Options{}, stub)
+			Expect(err).To(Equal(ParameterErrors{
+				{"host", "type string expected, but found int"},
+				{"network.port", "condition 1 failed: less or equal to 0"},
+			}))
+		})
+
+		It("reports failed conditions as warnings in lenient mode", func() {
+			stub := parseYAML(`
+---
+host: example.org
+network:
+  port: 0
+`)
+			flags := features.FeatureFlags{}
+			flags.SetLenientValidation(true)
+			state := NewDefaultState().SetFeatures(flags)
+			_, err := Cascade(NewEnvironment(nil, "", state), source, Options{}, stub)
+			Expect(err).To(Succeed())
+			Expect(state.GetWarnings().List()).To(Equal([]dynaml.Warning{
+				{Path: []string{"network", "port"}, Message: "condition 1 failed: less or equal to 0"},
+			}))
+		})
+
+		It("rejects invalid declarations", func() {
+			source := parseYAML(`
+---
+__params:
+  host:
+    kind: string
+`)
+			_, err := Cascade(NewEnvironment(nil, ""), source, Options{})
+			Expect(err).To(MatchError(`__params.host: unknown field "kind"`))
+		})
+	})
+
 	Describe("merging undefined values", func() {
 		It("omits merge down of undefined field", func() {
 			source := parseYAML(`
@@ -0,0 +1,72 @@
+package flow
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/spiff/dynaml"
+)
+
+var _ = Describe("Reference Cycles", func() {
+	cycles := func(source string) map[string][]string {
+		_, err := NestedFlow(NewEnvironment(nil, "test"), parseYAML(source))
+		Expect(err).To(HaveOccurred())
+		result := map[string][]string{}
+		for _, n := range err.(dynaml.UnresolvedNodes).Nodes {
+			if n.Cycle != nil {
+				result[strings.Join(n.Context, ".")] = n.Cycle
+			}
+		}
+		return result
+	}
+
+	It("reports the shortest cycle for all participants", func() {
+		Expect(cycles(`
+---
+a: (( b.c ))
+b:
+  c: (( d ))
+d: (( a ))
+e: (( a ))
+`)).To(Equal(map[string][]string{
+			"a":   {"a", "b.c", "d", "a"},
+			"b.c": {"b.c", "d", "a", "b.c"},
+			"d":   {"d", "a", "b.c", "d"},
+		}))
+	})
+
+	It("follows relative references", func() {
+		Expect(cycles(`
+---
+a:
+  b: (( c ))
+  c: (( b ))
+`)).To(Equal(map[string][]string{
+			"a.b": {"a.b", "a.c", "a.b"},
+			"a.c": {"a.c", "a.b", "a.c"},
+		}))
+	})
+
+	It("follows absolute references and list entries", func() {
+		Expect(cycles(`
+---
+list:
+  - name: alice
+    value: (( .a ))
+a: (( list.alice.value ))
+`)).To(Equal(map[string][]string{
+			"list.[0].value": {"list.[0].value", "a", "list.[0].value"},
+			"a":              {"a", "list.[0].value", "a"},
+		}))
+	})
+
+	It("does not report nodes failing for other reasons", func() {
+		Expect(cycles(`
+---
+a: (( b ))
+b: (( c ))
+`)).To(BeEmpty())
+	})
+})
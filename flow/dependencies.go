@@ -0,0 +1,481 @@
+package flow
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/mandelsoft/spiff/debug"
+	"github.com/mandelsoft/spiff/dynaml"
+	"github.com/mandelsoft/spiff/features"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// dependency driven evaluation
+//
+// Every evaluation of an expression node records the document paths
+// looked up during the evaluation. After every pass these inputs of the
+// expression nodes still unresolved are used to build a dependency graph.
+// For every map the graph relates its entries: an entry depends on the
+// entries providing inputs for expressions located below it. The next
+// pass flows the entries of a map in topological order of this graph and
+// makes the flowed entries immediately visible for the following ones,
+// so a chain of references is resolved by a single pass instead of a pass
+// per chain element. Cycles are broken by the natural key order.
+//
+// An expression node, whose evaluation did not change it (blocked by
+// unresolved inputs), is only evaluated again if one of its inputs has been
+// changed since. Completely resolved sub trees not changed by the last pass
+// are not processed again at all.
+//
+// Lists are not tracked element by element, because the paths used to
+// address list entries depend on the actual list content. Therefore, a
+// list is always considered as changed as a whole.
+//
+// Merges, templates, tags and sync expressions change the environment
+// or the inputs of an evaluation in ways not described by document
+// paths. Therefore, the passes are kept until the document does not
+// change anymore, sub trees with merges are always flowed, and
+// expressions using tags are never considered as blocked.
+
+// dependencies describe the inputs of the last evaluation of an expression
+// node.
+type dependencies struct {
+	path     []string
+	node     yaml.Node
+	paths    map[string][]string
+	inputs   map[string]input
+	volatile bool
+}
+
+// input is a node found for a path of a blocked evaluation.
+type input struct {
+	node  yaml.Node
+	found bool
+}
+
+func newDependencies(path []string) *dependencies {
+	return &dependencies{path: path, paths: map[string][]string{}}
+}
+
+func (d *dependencies) add(path []string) {
+	d.paths[pathKey(path)] = path
+}
+
+// inputGraph describes the dependencies among the entries of a map.
+type inputGraph map[string]map[string]bool
+
+func (g inputGraph) add(entry, input string) {
+	inputs := g[entry]
+	if inputs == nil {
+		inputs = map[string]bool{}
+		g[entry] = inputs
+	}
+	inputs[input] = true
+}
+
+// order provides the given keys in topological order. Keys without
+// dependencies among each other keep their order.
+func (g inputGraph) order(keys []string) []string {
+	present := map[string]bool{}
+	for _, k := range keys {
+		present[k] = true
+	}
+	visited := map[string]bool{}
+	result := make([]string, 0, len(keys))
+
+	var visit func(k string)
+	visit = func(k string) {
+		if visited[k] || !present[k] {
+			return
+		}
+		visited[k] = true
+		inputs := make([]string, 0, len(g[k]))
+		for i := range g[k] {
+			inputs = append(inputs, i)
+		}
+		sort.Strings(inputs)
+		for _, i := range inputs {
+			visit(i)
+		}
+		result = append(result, k)
+	}
+	for _, k := range keys {
+		visit(k)
+	}
+	return result
+}
+
+// evaluation keeps the tracking information for a single
+// DefaultEnvironment.Flow
+type evaluation struct {
+	done  bool
+	valid bool // change information available
+
+	path []string  // path of the flowed document
+	root yaml.Node // input of the actual pass
+
+	evaluated []*dependencies          // expression nodes evaluated by the actual pass
+	blocked   map[string]*dependencies // blocked expression nodes
+	graphs    map[string]inputGraph    // dependencies among map entries
+
+	changed    map[string]bool // nodes changed by the last pass
+	containing map[string]bool // nodes containing changed nodes
+	unresolved map[string]bool // nodes containing unresolved nodes
+	lists      map[string]bool // lists containing unresolved nodes
+}
+
+func newEvaluation() *evaluation {
+	return &evaluation{blocked: map[string]*dependencies{}, graphs: map[string]inputGraph{}}
+}
+
+func pathKey(path []string) string {
+	return strings.Join(path, "\000")
+}
+
+// start prepares the tracking for a pass on the given document.
+func (ev *evaluation) start(path []string, root yaml.Node) {
+	ev.path = path
+	ev.root = root
+	ev.evaluated = nil
+}
+
+// lookup finds the node for a path of the document flowed by the
+// actual pass.
+func (ev *evaluation) lookup(path []string, features features.FeatureFlags) (input, bool) {
+	if ev.root == nil || len(path) <= len(ev.path) {
+		return input{}, false
+	}
+	for i, e := range ev.path {
+		if path[i] != e {
+			return input{}, false
+		}
+	}
+	n, found := yaml.FindR(true, ev.root, features, path[len(ev.path):]...)
+	return input{n, found}, true
+}
+
+// affected checks whether a path or any of its sub or super paths
+// has been changed by the last pass.
+func (ev *evaluation) affected(path string) bool {
+	if ev.containing[path] {
+		return true
+	}
+	for {
+		if ev.changed[path] {
+			return true
+		}
+		i := strings.LastIndex(path, "\000")
+		if i < 0 {
+			return path != "" && ev.changed[""]
+		}
+		path = path[:i]
+	}
+}
+
+// insideList checks whether the path is located inside an unresolved list.
+func (ev *evaluation) insideList(path string) bool {
+	for {
+		if ev.lists[path] {
+			return true
+		}
+		i := strings.LastIndex(path, "\000")
+		if i < 0 {
+			return path != "" && ev.lists[""]
+		}
+		path = path[:i]
+	}
+}
+
+// update determines the changes between two passes and reports
+// whether there were any changes at all.
+func (ev *evaluation) update(path []string, old, new yaml.Node) bool {
+	ev.valid = true
+	ev.changed = map[string]bool{}
+	ev.containing = map[string]bool{}
+	ev.unresolved = map[string]bool{}
+	ev.lists = map[string]bool{}
+
+	ev.compare(path, old, new)
+	ev.determineUnresolved(path, new)
+	for k, d := range ev.blocked {
+		if ev.affected(k) {
+			delete(ev.blocked, k)
+		} else {
+			for p := range d.paths {
+				if ev.affected(p) {
+					delete(ev.blocked, k)
+					break
+				}
+			}
+		}
+	}
+	ev.buildGraphs()
+	return len(ev.changed) > 0
+}
+
+// buildGraphs determines the dependency graphs for the next pass
+// from the inputs of the expression nodes still unresolved.
+func (ev *evaluation) buildGraphs() {
+	ev.graphs = map[string]inputGraph{}
+	for _, d := range ev.evaluated {
+		if !ev.unresolved[pathKey(d.path)] {
+			continue
+		}
+		for _, p := range d.paths {
+			n := 0
+			for n < len(d.path) && n < len(p) && d.path[n] == p[n] {
+				n++
+			}
+			if n < len(d.path) && n < len(p) {
+				key := pathKey(d.path[:n])
+				g := ev.graphs[key]
+				if g == nil {
+					g = inputGraph{}
+					ev.graphs[key] = g
+				}
+				g.add(d.path[n], p[n])
+			}
+		}
+	}
+	ev.evaluated = nil
+}
+
+func (ev *evaluation) markChanged(path []string) {
+	key := pathKey(path)
+	ev.changed[key] = true
+	for i := len(path); i >= 0; i-- {
+		key := pathKey(path[:i])
+		if ev.containing[key] {
+			break
+		}
+		ev.containing[key] = true
+	}
+}
+
+func (ev *evaluation) compare(path []string, old, new yaml.Node) {
+	if old == nil || new == nil {
+		if old != new {
+			ev.markChanged(path)
+		}
+		return
+	}
+	if !yaml.EqualAnnotations(old, new) {
+		ev.markChanged(path)
+		return
+	}
+	switch o := old.Value().(type) {
+	case map[string]yaml.Node:
+		n, ok := new.Value().(map[string]yaml.Node)
+		if !ok || len(o) != len(n) {
+			ev.markChanged(path)
+			return
+		}
+		if reflect.ValueOf(o).Pointer() == reflect.ValueOf(n).Pointer() {
+			// kept sub tree
+			return
+		}
+		for k, v := range o {
+			if _, ok := n[k]; !ok {
+				ev.markChanged(path)
+				return
+			}
+			ev.compare(append(path[:len(path):len(path)], k), v, n[k])
+		}
+	default:
+		if !reflect.DeepEqual(old.Value(), new.Value()) {
+			ev.markChanged(path)
+		}
+	}
+}
+
+func (ev *evaluation) determineUnresolved(path []string, node yaml.Node) bool {
+	if node == nil {
+		return false
+	}
+	unresolved := false
+	switch v := node.Value().(type) {
+	case map[string]yaml.Node:
+		for k, e := range v {
+			if ev.determineUnresolved(append(path[:len(path):len(path)], k), e) {
+				unresolved = true
+			}
+		}
+	case []yaml.Node:
+		if containsExpression(node) {
+			ev.lists[pathKey(path)] = true
+			unresolved = true
+		}
+	case dynaml.Expression:
+		unresolved = true
+	}
+	if unresolved {
+		ev.unresolved[pathKey(path)] = true
+	}
+	return unresolved
+}
+
+func containsExpression(node yaml.Node) bool {
+	if node == nil {
+		return false
+	}
+	switch v := node.Value().(type) {
+	case map[string]yaml.Node:
+		for _, e := range v {
+			if containsExpression(e) {
+				return true
+			}
+		}
+	case []yaml.Node:
+		for _, e := range v {
+			if containsExpression(e) {
+				return true
+			}
+		}
+	case dynaml.Expression:
+		return true
+	}
+	return false
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func trackingEnvironment(env dynaml.Binding) (*DefaultEnvironment, *evaluation) {
+	if e, ok := env.(*DefaultEnvironment); ok && e.evaluation != nil && !e.evaluation.done && !e.unstable {
+		return e, e.evaluation
+	}
+	return nil, nil
+}
+
+func recordingEnvironment(env dynaml.Binding) (*DefaultEnvironment, *evaluation) {
+	if e, ok := env.(*DefaultEnvironment); ok && e.evaluation != nil && !e.evaluation.done {
+		return e, e.evaluation
+	}
+	return nil, nil
+}
+
+// unstable disables the shortcuts for a sub tree whose environment
+// may change during the actual pass.
+func unstable(env dynaml.Binding) dynaml.Binding {
+	if e, ok := env.(*DefaultEnvironment); ok && e.evaluation != nil && !e.unstable {
+		n := *e
+		n.unstable = true
+		return &n
+	}
+	return env
+}
+
+// isStable checks whether the node for the actual path is completely
+// resolved and has not been changed by the last pass.
+func isStable(env dynaml.Binding) bool {
+	e, ev := trackingEnvironment(env)
+	if ev == nil || !ev.valid {
+		return false
+	}
+	path := pathKey(e.Path())
+	return !ev.unresolved[path] && !ev.affected(path) && !ev.insideList(path)
+}
+
+// isBlocked checks whether an expression node is known to be blocked
+// by unresolved inputs not changed since its last evaluation.
+func isBlocked(env dynaml.Binding, node yaml.Node) bool {
+	e, ev := trackingEnvironment(env)
+	if ev == nil || !ev.valid {
+		return false
+	}
+	d := ev.blocked[pathKey(e.Path())]
+	if d == nil || !reflect.DeepEqual(d.node, node) {
+		return false
+	}
+	for k, i := range d.inputs {
+		if n, ok := ev.lookup(d.paths[k], e.GetFeatures()); ok && !reflect.DeepEqual(n, i) {
+			return false
+		}
+	}
+	return true
+}
+
+// evaluationOrder provides the keys of the actual map in the order
+// given by the dependency graph of the last pass.
+func evaluationOrder(env dynaml.Binding, keys []string) []string {
+	e, ev := recordingEnvironment(env)
+	if ev == nil {
+		return keys
+	}
+	g := ev.graphs[pathKey(e.Path())]
+	if len(g) == 0 {
+		return keys
+	}
+	return g.order(keys)
+}
+
+// startRecording starts recording the inputs of an expression evaluation.
+func startRecording(env dynaml.Binding) *dependencies {
+	e, ev := recordingEnvironment(env)
+	if ev == nil {
+		return nil
+	}
+	if s, ok := e.GetState().(*State); ok && s != nil {
+		d := newDependencies(e.Path())
+		s.recorders = append(s.recorders, d)
+		ev.evaluated = append(ev.evaluated, d)
+		return d
+	}
+	return nil
+}
+
+// stopRecording stops recording the inputs of an expression evaluation.
+func stopRecording(env dynaml.Binding, d *dependencies) {
+	if d != nil {
+		s := env.GetState().(*State)
+		s.recorders = s.recorders[:len(s.recorders)-1]
+	}
+}
+
+// setBlocked remembers an expression node not changed by its evaluation
+// as blocked as long as the recorded inputs are not changed.
+func setBlocked(env dynaml.Binding, d *dependencies, orig, result yaml.Node) {
+	if d == nil || d.volatile || !reflect.DeepEqual(orig, result) {
+		return
+	}
+	e, ev := trackingEnvironment(env)
+	if ev == nil {
+		return
+	}
+	debug.Debug("blocked expression %v: %d dependencies\n", e.Path(), len(d.paths))
+	d.node = result
+	d.inputs = map[string]input{}
+	for k, p := range d.paths {
+		if i, ok := ev.lookup(p, e.GetFeatures()); ok {
+			d.inputs[k] = i
+		}
+	}
+	ev.blocked[pathKey(e.Path())] = d
+}
+
+// recordDependency records a document path used by the actual
+// expression evaluations.
+func (s *State) recordDependency(path []string) {
+	for _, d := range s.recorders {
+		d.add(path)
+	}
+}
+
+// recordVolatile marks the actual expression evaluations to depend
+// on inputs not described by document paths.
+func (s *State) recordVolatile() {
+	for _, d := range s.recorders {
+		d.volatile = true
+	}
+}
+
+func (e *DefaultEnvironment) recordDependency(path ...[]string) {
+	if s, ok := e.GetState().(*State); ok && s != nil && len(s.recorders) > 0 {
+		var p []string
+		for _, elems := range path {
+			p = append(p, elems...)
+		}
+		s.recordDependency(p)
+	}
+}
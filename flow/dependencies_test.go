@@ -0,0 +1,265 @@
+package flow
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/spiff/dynaml"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+var _ = Describe("Dependency Tracking", func() {
+	var env *DefaultEnvironment
+
+	BeforeEach(func() {
+		env = NewEnvironment(nil, "test").(*DefaultEnvironment)
+		env.evaluation = newEvaluation()
+	})
+
+	pass := func(source yaml.Node) yaml.Node {
+		env.evaluation.start(nil, source)
+		next := flow(source, env, true, false)
+		env.evaluation.update(nil, source, next)
+		return next
+	}
+
+	Context("detecting changes", func() {
+		It("reports changed leaf nodes", func() {
+			old := parseYAML(`
+---
+foo:
+  bar: 1
+  baz: 2
+alice: 3
+`)
+			new := parseYAML(`
+---
+foo:
+  bar: 1
+  baz: 4
+alice: 3
+`)
+			Expect(env.evaluation.update(nil, old, new)).To(BeTrue())
+			Expect(env.evaluation.changed).To(Equal(map[string]bool{"foo\000baz": true}))
+			Expect(env.evaluation.affected("foo")).To(BeTrue())
+			Expect(env.evaluation.affected("foo\000baz\000x")).To(BeTrue())
+			Expect(env.evaluation.affected("foo\000bar")).To(BeFalse())
+			Expect(env.evaluation.affected("alice")).To(BeFalse())
+		})
+
+		It("reports maps with changed keys", func() {
+			old := parseYAML(`
+---
+foo:
+  bar: 1
+`)
+			new := parseYAML(`
+---
+foo:
+  baz: 1
+`)
+			Expect(env.evaluation.update(nil, old, new)).To(BeTrue())
+			Expect(env.evaluation.changed).To(Equal(map[string]bool{"foo": true}))
+		})
+
+		It("reports lists as a whole", func() {
+			old := parseYAML(`
+---
+foo:
+  - name: alice
+    value: 1
+`)
+			new := parseYAML(`
+---
+foo:
+  - name: alice
+    value: 2
+`)
+			Expect(env.evaluation.update(nil, old, new)).To(BeTrue())
+			Expect(env.evaluation.changed).To(Equal(map[string]bool{"foo": true}))
+		})
+
+		It("reports no change for equal documents", func() {
+			old := parseYAML(`
+---
+foo:
+  - bar
+alice: 1
+`)
+			Expect(env.evaluation.update(nil, old, old)).To(BeFalse())
+		})
+	})
+
+	Context("evaluating expressions", func() {
+		It("keeps blocked expressions with unchanged inputs", func() {
+			source := parseYAML(`
+---
+a: (( b ))
+b: (( c ))
+c: (( x ))
+x: (( z ))
+z: (( x ))
+`)
+			next := pass(source)
+			next = pass(next)
+			next = pass(next)
+			Expect(env.evaluation.blocked).To(HaveKey("a"))
+			Expect(env.evaluation.blocked).To(HaveKey("b"))
+			Expect(env.evaluation.blocked).To(HaveKey("c"))
+			env.evaluation.start(nil, next)
+			Expect(isBlocked(env.WithPath("a"), next.Value().(map[string]yaml.Node)["a"])).To(BeTrue())
+		})
+
+		It("re-evaluates blocked expressions with inputs changed outside of the flow", func() {
+			source := parseYAML(`
+---
+a: (( b ))
+b: (( c ))
+c: (( x ))
+x: (( z ))
+z: (( x ))
+`)
+			next := pass(source)
+			next = pass(next)
+			next = pass(next)
+			Expect(env.evaluation.blocked).To(HaveKey("a"))
+
+			next.Value().(map[string]yaml.Node)["b"] = node(42)
+			env.evaluation.start(nil, next)
+			Expect(isBlocked(env.WithPath("a"), next.Value().(map[string]yaml.Node)["a"])).To(BeFalse())
+			next = pass(next)
+			Expect(next.Value().(map[string]yaml.Node)["a"].Value()).To(Equal(int64(42)))
+		})
+
+		It("evaluates map entries in dependency order", func() {
+			source := parseYAML(`
+---
+a: (( b + 1 ))
+b: (( c.value + 1 ))
+c:
+  value: (( d + 1 ))
+d: (( e + 1 ))
+e: (( 1 ))
+`)
+			next := pass(source)
+			next = pass(next)
+			Expect(evaluationOrder(env, []string{"a", "b", "c", "d", "e"})).To(Equal([]string{"e", "d", "c", "b", "a"}))
+			next = pass(next)
+			Expect(dynaml.FindUnresolvedNodes(next)).To(BeEmpty())
+			Expect(next.Value().(map[string]yaml.Node)["a"].Value()).To(Equal(int64(5)))
+		})
+
+		It("breaks dependency cycles by the key order", func() {
+			g := inputGraph{}
+			g.add("a", "c")
+			g.add("c", "b")
+			g.add("b", "a")
+			g.add("d", "b")
+			Expect(g.order([]string{"a", "b", "c", "d"})).To(Equal([]string{"b", "c", "a", "d"}))
+		})
+
+		It("skips stable resolved nodes", func() {
+			source := parseYAML(`
+---
+a: (( b ))
+b: (( c.value ))
+c:
+  value: 1
+`)
+			Expect(isStable(env.WithPath("c"))).To(BeFalse())
+			next := pass(source)
+			Expect(isStable(env.WithPath("c"))).To(BeTrue())
+			Expect(isStable(env.WithPath("a"))).To(BeFalse())
+			Expect(isStable(env.WithPath("b"))).To(BeFalse())
+			next = pass(next)
+			Expect(isStable(env.WithPath("b"))).To(BeFalse())
+			next = pass(next)
+			Expect(isStable(env.WithPath("b"))).To(BeTrue())
+			Expect(isStable(env.WithPath("a"))).To(BeFalse())
+		})
+
+		It("does not track volatile expressions", func() {
+			source := parseYAML(`
+---
+a: (( tag::b ))
+`)
+			next := pass(source)
+			next = pass(next)
+			Expect(env.evaluation.blocked).NotTo(HaveKey("a"))
+		})
+
+		It("resolves long reverse reference chains", func() {
+			source := parseYAML(`
+---
+a: (( b + 1 ))
+b: (( c + 1 ))
+c: (( d + 1 ))
+d: (( e + 1 ))
+e: (( f.value + 1 ))
+f:
+  value: (( g ))
+g: 1
+`)
+			resolved := parseYAML(`
+---
+a: 6
+b: 5
+c: 4
+d: 3
+e: 2
+f:
+  value: 1
+g: 1
+`)
+			Expect(source).To(FlowAs(resolved))
+		})
+
+		It("re-evaluates expressions shadowed by new fields", func() {
+			source := parseYAML(`
+---
+value: outer
+foo:
+  <<: (( bar ))
+  alice: (( value ))
+bar:
+  value: (( "inner" ))
+`)
+			resolved := parseYAML(`
+---
+value: outer
+foo:
+  value: inner
+  alice: inner
+bar:
+  value: inner
+`)
+			Expect(source).To(FlowAs(resolved))
+		})
+
+		It("handles stub merges", func() {
+			source := parseYAML(`
+---
+foo:
+  <<: (( merge ))
+  bar: (( alice ))
+alice: (( bob ))
+bob: template
+`)
+			stub := parseYAML(`
+---
+foo:
+  value: stub
+bob: stub
+`)
+			resolved := parseYAML(`
+---
+foo:
+  value: stub
+  bar: stub
+alice: stub
+bob: stub
+`)
+			Expect(source).To(FlowAs(resolved, stub))
+		})
+	})
+})
@@ -3,7 +3,6 @@ package flow
 import (
 	"fmt"
 	"path/filepath"
-	"reflect"
 	"strings"
 
 	"github.com/mandelsoft/spiff/debug"
@@ -13,20 +12,27 @@ import (
 )
 
 type Scope struct {
-	list   []yaml.Node
-	local  map[string]yaml.Node
-	static map[string]yaml.Node
-	path   []string
-	next   *Scope
-	root   *Scope
+	list     []yaml.Node
+	local    map[string]yaml.Node
+	static   map[string]yaml.Node
+	path     []string
+	next     *Scope
+	root     *Scope
+	document bool // scope describes a document node
 }
 
 func newFakeScope(outer *Scope, path []string, local map[string]yaml.Node) *Scope {
 	return newScope(outer, path, local, nil)
 }
 
+func newDocumentScope(outer *Scope, path []string, local, static map[string]yaml.Node) *Scope {
+	scope := newScope(outer, path, local, static)
+	scope.document = true
+	return scope
+}
+
 func newScope(outer *Scope, path []string, local, static map[string]yaml.Node) *Scope {
-	scope := &Scope{nil, local, static, path, outer, nil}
+	scope := &Scope{nil, local, static, path, outer, nil, false}
 	if outer == nil || outer.root == nil {
 		scope.root = scope
 	} else {
@@ -36,7 +42,7 @@ func newScope(outer *Scope, path []string, local, static map[string]yaml.Node) *
 }
 
 func newListScope(outer *Scope, path []string, local []yaml.Node, static map[string]yaml.Node) *Scope {
-	scope := &Scope{local, nil, static, path, outer, nil}
+	scope := &Scope{local, nil, static, path, outer, nil, false}
 	if outer == nil || outer.root == nil {
 		scope.root = scope
 	} else {
@@ -63,6 +69,9 @@ type DefaultEnvironment struct {
 
 	active  bool
 	binding bool
+
+	evaluation *evaluation // dependency tracking of the actual flow
+	unstable   bool        // disable dependency based shortcuts
 }
 
 func keys(s map[string]yaml.Node) string {
@@ -185,13 +194,18 @@ func (e *DefaultEnvironment) FindFromRoot(path []string) (yaml.Node, bool) {
 		} else {
 			root = e.scope.root.list
 		}
+		e.recordDependency(nil)
 	} else {
 		root = e.scope.root.local
+		e.recordDependency(path)
 	}
 	return yaml.FindR(true, yaml.NewNode(root, "scope"), e.GetFeatures(), path...)
 }
 
 func (e *DefaultEnvironment) FindInScopes(nodescope *Scope, path []string) (yaml.Node, bool) {
+	if nodescope.document {
+		e.recordDependency(nodescope.path, path)
+	}
 	if len(path) > 0 {
 		scope := nodescope
 		for scope != nil {
@@ -210,13 +224,18 @@ func (e *DefaultEnvironment) FindInScopes(nodescope *Scope, path []string) (yaml
 }
 
 func (e *DefaultEnvironment) FindReference(path []string) (yaml.Node, bool) {
-	root, found, nodescope := resolveSymbol(e, path[0], e.scope)
+	root, found, nodescope := resolveSymbol(e, path, e.scope)
 	if !found {
 		if path[0] == yaml.ROOT {
 			var outer dynaml.Binding = e
 			for outer.Outer() != nil {
 				outer = outer.Outer()
 			}
+			if outer == e {
+				e.recordDependency(path[1:])
+			} else {
+				e.recordDependency(nil)
+			}
 			return yaml.FindR(true, node(outer.GetRootBinding()), e.GetFeatures(), path[1:]...)
 		}
 		// fmt.Printf("FIND %s: %s\n", strings.Join(path,"."), e)
@@ -263,7 +282,7 @@ func (e *DefaultEnvironment) WithSource(source string) dynaml.Binding {
 
 func (e *DefaultEnvironment) WithScope(step map[string]yaml.Node) dynaml.Binding {
 	n := *e
-	n.scope = newScope(e.scope, e.path, step, e.static)
+	n.scope = newDocumentScope(e.scope, e.path, step, e.static)
 	return &n
 }
 
@@ -321,11 +340,16 @@ func (e *DefaultEnvironment) RedirectOverwrite(path []string) dynaml.Binding {
 func (e *DefaultEnvironment) Flow(source yaml.Node, shouldOverride bool) (yaml.Node, dynaml.Status) {
 	result := source
 
+	tracking := *e
+	tracking.evaluation = newEvaluation()
+	tracking.unstable = false
 	for {
 		debug.Debug("@@{ loop:  %+v\n", result)
-		var env dynaml.Binding = e
+		dynaml.ProfilerOf(e).Iteration(source.SourceName())
+		tracking.evaluation.start(e.path, result)
+		var env dynaml.Binding = &tracking
 		if list, ok := source.Value().([]yaml.Node); ok {
-			env = e.WithListScope(list)
+			env = tracking.WithListScope(list)
 		}
 		next := flow(result, env, shouldOverride, false)
 		if next.Undefined() {
@@ -334,15 +358,13 @@ func (e *DefaultEnvironment) Flow(source yaml.Node, shouldOverride bool) (yaml.N
 		}
 		debug.Debug("@@} --->   %+v\n", next)
 
-		next = Cleanup(next, updateBinding(next, env))
-		b := reflect.DeepEqual(result, next)
-		// b,r:=yaml.Equals(result, next,[]string{})
-		if b {
+		Visit(next, updateBinding(next, env))
+		if !tracking.evaluation.update(e.path, result, next) {
 			break
 		}
-		// fmt.Printf("****** found diff: %s\n", r)
 		result = next
 	}
+	tracking.evaluation.done = true
 	debug.Debug("@@@ Done\n")
 	result = Cleanup(result, deactivateScopes)
 	unresolved := dynaml.FindUnresolvedNodes(result)
@@ -457,8 +479,9 @@ func deactivateScopes(node yaml.Node) (yaml.Node, CleanupFunction) {
 	return node, deactivateScopes
 }
 
-func resolveSymbol(env *DefaultEnvironment, name string, scope *Scope) (yaml.Node, bool, *Scope) {
+func resolveSymbol(env *DefaultEnvironment, path []string, scope *Scope) (yaml.Node, bool, *Scope) {
 	var nodescope *Scope
+	name := path[0]
 	if name == "__ctx" {
 		return createContext(env), true, nil
 	}
@@ -469,8 +492,15 @@ func resolveSymbol(env *DefaultEnvironment, name string, scope *Scope) (yaml.Nod
 		}
 		val := scope.local[name]
 		if val != nil {
+			if scope.document {
+				env.recordDependency(scope.path, path)
+			}
 			return val, true, nil
 		}
+		if scope.document {
+			// a later definition would shadow outer ones
+			env.recordDependency(scope.path, path[:1])
+		}
 		scope = scope.next
 	}
 
@@ -0,0 +1,261 @@
+package flow
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mandelsoft/spiff/dynaml"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+// DefaultFetchTimeout is the timeout used for http requests without
+// explicitly configured timeout.
+const DefaultFetchTimeout = 60 * time.Second
+
+// Fetcher reads remote content via http(s).
+//
+// With a cache directory, fetched content is stored content-addressed
+// by its sha256 digest (<dir>/sha256/<digest>) together with an index
+// mapping URLs to digests (<dir>/urls/<sha256 of url>). In offline mode
+// content is served from the cache, only.
+//
+// A lock file records the digest for every fetched URL. URLs found in the
+// lock file must provide content with the recorded digest, which is
+// then preferably taken from the cache.
+type Fetcher struct {
+	CacheDir string
+	Offline  bool
+	Timeout  time.Duration
+
+	lock     sync.Mutex
+	lockFile string
+	locked   map[string]string
+	modified bool
+}
+
+// NewFetcher creates a fetcher using an optional cache directory.
+func NewFetcher(cacheDir string, offline bool) *Fetcher {
+	return &Fetcher{CacheDir: cacheDir, Offline: offline, Timeout: DefaultFetchTimeout}
+}
+
+// UseLockFile loads the URL digests from a lock file. A missing file is
+// treated as an empty lock file, which is created by SaveLockFile.
+func (f *Fetcher) UseLockFile(path string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.lockFile = path
+	f.locked = map[string]string{}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	node, err := yaml.Parse(path, data)
+	if err != nil {
+		return fmt.Errorf("invalid lock file %q: %s", path, err)
+	}
+	if node == nil || node.Value() == nil {
+		return nil
+	}
+	urls, ok := yaml.Find(node, nil, "urls")
+	if !ok || urls == nil || urls.Value() == nil {
+		return nil
+	}
+	m, ok := urls.Value().(map[string]yaml.Node)
+	if !ok {
+		return fmt.Errorf("invalid lock file %q: urls must be a map", path)
+	}
+	for u, d := range m {
+		s, ok := d.Value().(string)
+		if !ok || len(s) != len("sha256:")+64 || s[:7] != "sha256:" {
+			return fmt.Errorf("invalid lock file %q: invalid digest for %q", path, u)
+		}
+		f.locked[u] = s[7:]
+	}
+	return nil
+}
+
+// SaveLockFile writes the lock file, if new URLs have been recorded.
+func (f *Fetcher) SaveLockFile() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.lockFile == "" || !f.modified {
+		return nil
+	}
+	keys := make([]string, 0, len(f.locked))
+	for u := range f.locked {
+		keys = append(keys, u)
+	}
+	sort.Strings(keys)
+	urls := yaml.NewNode(map[string]yaml.Node{}, f.lockFile)
+	for _, u := range keys {
+		urls.Value().(map[string]yaml.Node)[u] = yaml.NewNode("sha256:"+f.locked[u], f.lockFile)
+	}
+	data, err := yaml.Marshal(yaml.NewNode(map[string]yaml.Node{"urls": urls}, f.lockFile))
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(f.lockFile, data, 0644); err != nil {
+		return err
+	}
+	f.modified = false
+	return nil
+}
+
+// Fetch provides the content of a URL.
+func (f *Fetcher) Fetch(state *State, url string, opts *dynaml.FetchOptions) ([]byte, error) {
+	if err := state.GetPolicy().CheckURL(url); err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	expected := f.locked[url]
+	f.lock.Unlock()
+	if opts != nil && opts.SHA256 != "" {
+		if expected != "" && expected != opts.SHA256 {
+			return nil, fmt.Errorf("sha256 %s for %s does not match the lock file (%s)", opts.SHA256, url, expected)
+		}
+		expected = opts.SHA256
+	}
+
+	if expected == "" && f.Offline {
+		expected = f.cachedDigest(url)
+	}
+	if expected != "" {
+		if data, err := f.cachedContent(expected); err == nil {
+			return data, nil
+		}
+	}
+	if f.Offline {
+		return nil, fmt.Errorf("offline mode: %s not found in cache", url)
+	}
+
+	data, err := f.get(state, url, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+	if expected != "" && digest != expected {
+		return nil, fmt.Errorf("sha256 mismatch for %s: expected %s, found %s", url, expected, digest)
+	}
+	if err := f.store(url, digest, data); err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	if f.locked != nil && f.locked[url] == "" {
+		f.locked[url] = digest
+		f.modified = true
+	}
+	f.lock.Unlock()
+	return data, nil
+}
+
+func (f *Fetcher) get(state *State, url string, opts *dynaml.FetchOptions) ([]byte, error) {
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	timeout := f.Timeout
+	if opts != nil {
+		for k, v := range opts.Headers {
+			request.Header.Set(k, v)
+		}
+		token, err := opts.Token(state)
+		if err != nil {
+			return nil, err
+		}
+		if token != "" {
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+		if opts.Timeout > 0 {
+			timeout = opts.Timeout
+		}
+	}
+	client := &http.Client{
+		Timeout: timeout,
+		// the policy must be obeyed for every redirect target, too
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return fmt.Errorf("stopped after 10 redirects")
+			}
+			return state.GetPolicy().CheckURL(req.URL.String())
+		},
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	data, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error getting body: %s", err)
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf("[status %d]: %s", response.StatusCode, data)
+	}
+	return data, nil
+}
+
+func (f *Fetcher) cachedDigest(url string) string {
+	if f.CacheDir == "" {
+		return ""
+	}
+	data, err := ioutil.ReadFile(f.indexPath(url))
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func (f *Fetcher) cachedContent(digest string) ([]byte, error) {
+	if f.CacheDir == "" {
+		return nil, os.ErrNotExist
+	}
+	data, err := ioutil.ReadFile(filepath.Join(f.CacheDir, "sha256", digest))
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != digest {
+		return nil, fmt.Errorf("corrupted cache entry %s", digest)
+	}
+	return data, nil
+}
+
+func (f *Fetcher) store(url, digest string, data []byte) error {
+	if f.CacheDir == "" {
+		return nil
+	}
+	for _, d := range []string{"sha256", "urls"} {
+		if err := os.MkdirAll(filepath.Join(f.CacheDir, d), 0755); err != nil {
+			return fmt.Errorf("cannot create cache: %s", err)
+		}
+	}
+	if err := ioutil.WriteFile(filepath.Join(f.CacheDir, "sha256", digest), data, 0644); err != nil {
+		return fmt.Errorf("cannot write cache: %s", err)
+	}
+	if err := ioutil.WriteFile(f.indexPath(url), []byte(digest), 0644); err != nil {
+		return fmt.Errorf("cannot write cache: %s", err)
+	}
+	return nil
+}
+
+func (f *Fetcher) indexPath(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(f.CacheDir, "urls", hex.EncodeToString(sum[:]))
+}
@@ -0,0 +1,167 @@
+package flow
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/spiff/dynaml"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+var _ = Describe("Fetching remote content", func() {
+	var server *httptest.Server
+	var requests int
+	var content string
+	var dir string
+
+	digest := func(s string) string {
+		sum := sha256.Sum256([]byte(s))
+		return hex.EncodeToString(sum[:])
+	}
+
+	BeforeEach(func() {
+		requests = 0
+		content = "alice: 25\n"
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if r.URL.Path == "/auth" {
+				if r.Header.Get("Authorization") != "Bearer "+os.Getenv("PATH") || r.Header.Get("X-Test") != "test" {
+					w.WriteHeader(http.StatusUnauthorized)
+					w.Write([]byte("unauthorized"))
+					return
+				}
+			}
+			if r.URL.Path == "/redirect" {
+				http.Redirect(w, r, r.URL.Query().Get("to"), http.StatusFound)
+				return
+			}
+			w.Write([]byte(content))
+		}))
+		var err error
+		dir, err = ioutil.TempDir("", "spiff-fetch-")
+		Expect(err).To(Succeed())
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(dir)
+	})
+
+	cascade := func(state *State, src string) (yaml.Node, error) {
+		return Cascade(NewEnvironment(nil, "test", state), parseYAML(src), Options{})
+	}
+
+	It("passes headers and bearer tokens", func() {
+		result, err := cascade(NewDefaultState(), `
+---
+data: (( read(url, "yaml", { $headers = { "X-Test" = "test" }, $token_env = "PATH" }) ))
+url: `+server.URL+`/auth
+`)
+		Expect(err).To(Succeed())
+		data, err := yaml.Marshal(yaml.NewNode(result.Value().(map[string]yaml.Node)["data"].Value(), ""))
+		Expect(err).To(Succeed())
+		Expect(string(data)).To(Equal(content))
+	})
+
+	It("fails for failed requests", func() {
+		_, err := cascade(NewDefaultState(), `
+---
+data: (( read(url, {}) ))
+url: `+server.URL+`/auth
+`)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("[status 401]: unauthorized"))
+	})
+
+	It("verifies the sha256 digest", func() {
+		_, err := cascade(NewDefaultState(), `
+---
+ok: (( read(url, { $sha256 = "sha256:`+digest(content)+`" }) ))
+fail: (( catch(read(url, "text", { $sha256 = "`+digest("bob")+`" })).error ))
+url: `+server.URL+`/data.yml
+`)
+		Expect(err).To(Succeed())
+		data, err := NewDefaultState().Fetch(server.URL+"/data.yml", &dynaml.FetchOptions{SHA256: digest("bob")})
+		Expect(data).To(BeNil())
+		Expect(err).To(MatchError("sha256 mismatch for " + server.URL + "/data.yml: expected " + digest("bob") + ", found " + digest(content)))
+	})
+
+	It("rejects options for non-http locations", func() {
+		_, err := cascade(NewDefaultState(), `
+---
+data: (( read("data.yml", { $timeout = 10 }) ))
+`)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("read: options are only supported for http(s) locations"))
+	})
+
+	It("serves cached content in offline mode", func() {
+		url := server.URL + "/data.yml"
+		data, err := NewDefaultState().SetFetcher(NewFetcher(dir, false)).Fetch(url, nil)
+		Expect(err).To(Succeed())
+		Expect(string(data)).To(Equal(content))
+		Expect(requests).To(Equal(1))
+		Expect(filepath.Join(dir, "sha256", digest(content))).To(BeAnExistingFile())
+
+		offline := NewDefaultState().SetFetcher(NewFetcher(dir, true))
+		data, err = offline.Fetch(url, nil)
+		Expect(err).To(Succeed())
+		Expect(string(data)).To(Equal(content))
+		Expect(requests).To(Equal(1))
+
+		_, err = offline.Fetch(server.URL+"/other.yml", nil)
+		Expect(err).To(MatchError("offline mode: " + server.URL + "/other.yml not found in cache"))
+		Expect(requests).To(Equal(1))
+	})
+
+	It("records and enforces digests in lock files", func() {
+		url := server.URL + "/data.yml"
+		lock := filepath.Join(dir, "spiff.lock")
+
+		fetcher := NewFetcher("", false)
+		Expect(fetcher.UseLockFile(lock)).To(Succeed())
+		_, err := NewDefaultState().SetFetcher(fetcher).Fetch(url, nil)
+		Expect(err).To(Succeed())
+		Expect(fetcher.SaveLockFile()).To(Succeed())
+		data, err := ioutil.ReadFile(lock)
+		Expect(err).To(Succeed())
+		Expect(string(data)).To(Equal("urls:\n  " + url + ": sha256:" + digest(content) + "\n"))
+
+		content = "bob: 26\n"
+		fetcher = NewFetcher("", false)
+		Expect(fetcher.UseLockFile(lock)).To(Succeed())
+		_, err = NewDefaultState().SetFetcher(fetcher).Fetch(url, nil)
+		Expect(err).To(MatchError("sha256 mismatch for " + url + ": expected " + digest("alice: 25\n") + ", found " + digest(content)))
+	})
+
+	It("obeys the URL policy", func() {
+		state := NewDefaultState().SetPolicy(&dynaml.Policy{Hosts: []string{"example.com"}})
+		_, err := state.Fetch(server.URL, nil)
+		Expect(err).To(MatchError("policy violation: access to URL \"" + server.URL + "\" not allowed"))
+		Expect(requests).To(Equal(0))
+	})
+
+	It("obeys the URL policy for redirects", func() {
+		host := server.Listener.Addr().String()
+		target := "http://localhost:" + host[strings.LastIndex(host, ":")+1:] + "/data"
+		url := server.URL + "/redirect?to=" + target
+		state := NewDefaultState().SetPolicy(&dynaml.Policy{Hosts: []string{"127.0.0.1"}})
+		_, err := state.Fetch(url, nil)
+		Expect(err).To(MatchError(ContainSubstring("policy violation: access to URL \"" + target + "\" not allowed")))
+		Expect(requests).To(Equal(1))
+
+		data, err := NewDefaultState().SetPolicy(&dynaml.Policy{Hosts: []string{"127.0.0.1", "localhost"}}).Fetch(url, nil)
+		Expect(err).To(Succeed())
+		Expect(string(data)).To(Equal(content))
+		Expect(requests).To(Equal(3))
+	})
+})
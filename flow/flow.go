@@ -104,7 +104,13 @@ func _flow(root yaml.Node, env dynaml.Binding, shouldOverride, enforceTemplate b
 
 		case dynaml.Expression:
 			debug.Debug("??? eval %T: %+v\n", val, val)
+			if isBlocked(env, root) {
+				debug.Debug("??? inputs unchanged ---> KEEP\n")
+				return root
+			}
+			orig := root
 			env := env
+			var deps *dependencies
 			if root.SourceName() != env.SourceName() {
 				env = env.WithSource(root.SourceName())
 			}
@@ -116,6 +122,9 @@ func _flow(root yaml.Node, env dynaml.Binding, shouldOverride, enforceTemplate b
 				if tag := m.GetTag(); tag != "" && root.GetAnnotation().Tag() == "" {
 					root = yaml.SetTag(root, tag)
 				}
+				if msg, ok := m.GetDeprecation(); ok {
+					root = yaml.DeprecatedNode(root, msg)
+				}
 			}
 			if ok && m.Has(dynaml.TEMPLATE) {
 				debug.Debug("found template declaration\n")
@@ -133,12 +142,22 @@ func _flow(root yaml.Node, env dynaml.Binding, shouldOverride, enforceTemplate b
 				}
 				flags |= m.GetFlags()
 			} else {
+				deps = startRecording(env)
+				startRandoms(env)
 				eval, info, ok = val.Evaluate(env, false)
+				stopRandoms(env)
+				stopRecording(env, deps)
+				dynaml.ProfilerOf(env).Node(root.SourceName(), env.Path())
 				if err := info.Cleanup(); err != nil {
 					info.SetError("%s", err)
 					eval = nil
 					ok = false
 				}
+				if ok && !dynaml.IsExpression(eval) {
+					for _, w := range info.Warnings {
+						env.GetState().GetWarnings().Add(env.Path(), "%s", w)
+					}
+				}
 				if info.RedirectPath != nil {
 					debug.Debug("eval found redirect %v, %v", info.RedirectPath, ok)
 				}
@@ -163,6 +182,7 @@ func _flow(root yaml.Node, env dynaml.Binding, shouldOverride, enforceTemplate b
 				root = dynaml.IssueNode(env, true, root, true, false, info.Issue)
 				debug.Debug("??? failed ---> KEEP\n")
 				if !shouldOverride {
+					setBlocked(env, deps, orig, root)
 					return root
 				}
 			} else {
@@ -176,6 +196,13 @@ func _flow(root yaml.Node, env dynaml.Binding, shouldOverride, enforceTemplate b
 				} else {
 					result = yaml.NewNode(eval, source)
 				}
+				if source == root.SourceName() {
+					result = yaml.PositionedNode(result, root.SourcePosition())
+				}
+				result = yaml.OriginNode(result, valueOrigin(root, val, info))
+				if info.KeyOrder != nil {
+					result = yaml.OrderedNode(result, info.KeyOrder)
+				}
 				_, ok = eval.(string)
 				if ok {
 					// map result to potential expression
@@ -220,9 +247,15 @@ func _flow(root yaml.Node, env dynaml.Binding, shouldOverride, enforceTemplate b
 				}
 
 				result = updateNode(result, flags, tag)
+				if root.Flags().Deprecated() {
+					result = yaml.DeprecatedNode(result, root.GetAnnotation().Deprecation())
+				}
 				if expr || result.Merged() || !shouldOverride || result.Preferred() {
 					debug.Debug("   prefer expression over override")
 					debug.Debug("??? ---> %+v\n", result)
+					if expr {
+						setBlocked(env, deps, orig, result)
+					}
 					return result
 				}
 				debug.Debug("???   try override\n")
@@ -246,7 +279,14 @@ func _flow(root yaml.Node, env dynaml.Binding, shouldOverride, enforceTemplate b
 		debug.Debug("/// lookup stub %v -> %v\n", env.Path(), env.StubPath())
 		overridden, found := env.FindInStubs(env.StubPath())
 		if found && !overridden.Flags().Default() && !root.Flags().Injected() {
+			if issue, ok := checkOverride(env, root, overridden); !ok {
+				return issue
+			}
 			root, _ = substituteNode(overridden)
+			if root.Flags().Final() || root.Flags().Deprecated() {
+				// the markers of the stub only apply to its own stubs
+				root = yaml.RemoveFlags(root, yaml.FLAG_FINAL|yaml.FLAG_DEPRECATED)
+			}
 			if keyName != "" {
 				root = yaml.KeyNameNode(root, keyName)
 			}
@@ -269,6 +309,29 @@ func _flow(root yaml.Node, env dynaml.Binding, shouldOverride, enforceTemplate b
 	return root
 }
 
+// mapKeyOrder determines the key order for a flowed map: the keys
+// of the template map in their order, followed by keys only provided
+// by a merged base map.
+func mapKeyOrder(root yaml.Node, mergekey string, baseOrder []string) []string {
+	if root.KeyOrder() == nil && baseOrder == nil {
+		return nil
+	}
+	order := []string{}
+	found := map[string]bool{}
+	for _, k := range yaml.GetOrderedKeys(root) {
+		if k != mergekey {
+			found[k] = true
+			order = append(order, k)
+		}
+	}
+	for _, k := range baseOrder {
+		if !found[k] {
+			order = append(order, k)
+		}
+	}
+	return order
+}
+
 /*
  * compatibility issue. A single merge node was always optional
  * means: <<: (( merge )) == <<: (( merge || nil ))
@@ -287,18 +350,26 @@ func flowMap(root yaml.Node, env dynaml.Binding, shouldOverride, template bool)
 	var err error
 	flags, stub := get_inherited_flags(env)
 	tag := root.GetAnnotation().Tag()
+	insertion := root.GetAnnotation().Insertion()
+	deprecation := root.GetAnnotation().Deprecation()
 	processed := true
 	merged := false
 	issue, failed := root.Issue(), root.Failed()
 	rootMap := root.Value().(map[string]yaml.Node)
 
 	rootEnv := env
-	env = env.WithScope(rootMap)
+	// entries are visible for the following ones as soon as they are flowed
+	scope := make(map[string]yaml.Node, len(rootMap))
+	for k, v := range rootMap {
+		scope[k] = v
+	}
+	env = env.WithScope(scope)
 
 	redirect := root.RedirectPath()
 	replace := root.ReplaceFlag()
 	newMap := make(map[string]yaml.Node)
 	undefined := make(map[string]yaml.Node)
+	var baseOrder []string
 
 	debug.Debug("HANDLE MAP %v (template=%t)\n", env.Path(), template)
 	addEntries := true
@@ -316,6 +387,8 @@ func flowMap(root yaml.Node, env dynaml.Binding, shouldOverride, template bool)
 	}
 
 	if ok {
+		// merging may change the environment for the map entries
+		env = unstable(env)
 		val := mergeval
 		debug.Debug("handle map merge %#v\n", val)
 		_, initial := val.Value().(string)
@@ -333,6 +406,14 @@ func flowMap(root yaml.Node, env dynaml.Binding, shouldOverride, template bool)
 					debug.Debug("found tag %q\n", t)
 					tag = t
 				}
+				if i := marker.GetInsertion(); i != "" {
+					debug.Debug("found insertion %q\n", i)
+					insertion = i
+				}
+				if msg, ok := marker.GetDeprecation(); ok {
+					debug.Debug("found deprecation %q\n", msg)
+					deprecation = msg
+				}
 				flags |= marker.GetFlags()
 				if flags.Temporary() {
 					debug.Debug("found temporary declaration\n")
@@ -379,6 +460,7 @@ func flowMap(root yaml.Node, env dynaml.Binding, shouldOverride, template bool)
 				for k, v := range baseMap {
 					newMap[k] = v
 				}
+				baseOrder = yaml.GetOrderedKeys(base)
 			}
 			// still ignore non dynaml value (might be strange but compatible)
 			replace = base.ReplaceFlag()
@@ -406,7 +488,7 @@ func flowMap(root yaml.Node, env dynaml.Binding, shouldOverride, template bool)
 	}
 
 	if addEntries {
-		sortedKeys := yaml.GetSortedKeys(rootMap)
+		sortedKeys := evaluationOrder(env, yaml.GetSortedKeys(rootMap))
 		for i := range sortedKeys {
 			key := sortedKeys[i]
 			val := rootMap[key]
@@ -418,7 +500,12 @@ func flowMap(root yaml.Node, env dynaml.Binding, shouldOverride, template bool)
 				}
 			} else {
 				if processed {
-					val = flow(val, env.WithPath(key), shouldOverride, dynaml.RequireTemplate(key, env))
+					sub := env.WithPath(key)
+					if isStable(sub) {
+						debug.Debug("skip %q flow for stable node\n", key)
+					} else {
+						val = flow(val, sub, shouldOverride, dynaml.RequireTemplate(key, env))
+					}
 				} else {
 					debug.Debug("skip %q flow for unprocessed indication\n", key)
 				}
@@ -430,6 +517,9 @@ func flowMap(root yaml.Node, env dynaml.Binding, shouldOverride, template bool)
 					val = yaml.AddFlags(val, yaml.FLAG_IMPLIED)
 				}
 				newMap[key] = val
+				if key != mergekey {
+					scope[key] = val
+				}
 			} else {
 				undefined[key] = val
 			}
@@ -452,10 +542,11 @@ func flowMap(root yaml.Node, env dynaml.Binding, shouldOverride, template bool)
 			// flags |= yaml.FLAG_INJECTED
 		}
 	}
+	order := mapKeyOrder(root, mergekey, baseOrder)
 	var result interface{}
 	if template {
 		debug.Debug(" as template\n")
-		result = dynaml.NewTemplateValue(env.Path(), yaml.NewNode(newMap, root.SourceName()), root, rootEnv)
+		result = dynaml.NewTemplateValue(env.Path(), yaml.OrderedNode(yaml.NewNode(newMap, root.SourceName()), order), root, rootEnv)
 	} else {
 		result = newMap
 	}
@@ -465,6 +556,7 @@ func flowMap(root yaml.Node, env dynaml.Binding, shouldOverride, template bool)
 	} else {
 		node = yaml.RedirectNode(result, root, redirect)
 	}
+	node = yaml.OrderedNode(node, order)
 
 	if err != nil || failed {
 		if err != nil {
@@ -475,7 +567,17 @@ func flowMap(root yaml.Node, env dynaml.Binding, shouldOverride, template bool)
 	} else {
 		node, _, _ = flowControl(node, undefined, env)
 	}
-	return updateNode(node, flags, tag)
+	if insertion != node.GetAnnotation().Insertion() {
+		node = yaml.SetInsertion(node, insertion)
+	}
+	node = updateNode(node, flags, tag)
+	if node.Flags().Deprecated() && deprecation != node.GetAnnotation().Deprecation() {
+		node = yaml.DeprecatedNode(node, deprecation)
+	}
+	if stub != nil && !node.Failed() {
+		node, _ = checkOverride(env, node, stub)
+	}
+	return node
 }
 
 func flowList(root yaml.Node, env dynaml.Binding, template bool) yaml.Node {
@@ -486,7 +588,14 @@ func flowList(root yaml.Node, env dynaml.Binding, template bool) yaml.Node {
 	}
 
 	debug.Debug("HANDLE LIST %v\n", env.Path())
-	merged, process, replaced, redirectPath, keyName, ismerged, flags, tag, stub := processMerges(root, rootList, env, template)
+	for _, val := range rootList {
+		if _, _, ok := yaml.UnresolvedListEntryMerge(val); ok {
+			// merging may change the environment for the list entries
+			env = unstable(env)
+			break
+		}
+	}
+	merged, process, replaced, redirectPath, keyName, ismerged, flags, tag, deprecation, stub := processMerges(root, rootList, env, template)
 	nomerge := flags.IsNoMerge()
 
 	if process {
@@ -514,6 +623,7 @@ func flowList(root yaml.Node, env dynaml.Binding, template bool) yaml.Node {
 			}
 		}
 
+		steps := []string{}
 		for idx, val := range merged.([]yaml.Node) {
 			step, keyed, resolved, _ := stepName(idx, val, keyName, env, unique)
 			debug.Debug("  step %s\n", step)
@@ -521,13 +631,21 @@ func flowList(root yaml.Node, env dynaml.Binding, template bool) yaml.Node {
 			if !keyed && nomerge {
 				val = yaml.MergedNode(val)
 			}
+			if keyed && deletedInStubs(env.WithPath(step)) {
+				debug.Debug("  entry %s deleted by stub\n", step)
+				continue
+			}
 			if resolved {
 				val = flow(val, env.WithPath(step), false, false)
 			}
 			if !val.Undefined() {
 				newList = append(newList, val)
+				steps = append(steps, step)
 			}
 		}
+		if unique {
+			newList = insertEntries(newList, steps, effkey)
+		}
 		if ismerged {
 			flags |= yaml.FLAG_INJECTED
 		} else {
@@ -569,6 +687,12 @@ func flowList(root yaml.Node, env dynaml.Binding, template bool) yaml.Node {
 	if tag != "" && tag != root.GetAnnotation().Tag() {
 		root = yaml.SetTag(root, tag)
 	}
+	if deprecation != "" && deprecation != root.GetAnnotation().Deprecation() {
+		root = yaml.DeprecatedNode(root, deprecation)
+	}
+	if stub != nil && !root.Failed() {
+		root, _ = checkOverride(env, root, stub)
+	}
 	return root
 }
 
@@ -593,16 +717,22 @@ func stepName(index int, value yaml.Node, keyName string, env dynaml.Binding, un
 		keyName = "name"
 	}
 	if unique {
-		name, ok := yaml.FindString(value, env.GetFeatures(), keyName)
+		name, ok := yaml.FindKeyValue(value, env.GetFeatures(), keyName)
 		if ok {
 			return keyName + ":" + name, true, true, true
 		}
 	}
 	step := fmt.Sprintf("[%d]", index)
-	v, ok := yaml.FindR(true, value, env.GetFeatures(), keyName)
-	if ok && v.Value() != nil {
-		debug.Debug("found raw %s", keyName)
-		_, ok := v.Value().(dynaml.Expression)
+	names := []string{}
+	paths := yaml.KeyPaths(keyName)
+	for _, path := range paths {
+		v, ok := yaml.FindR(true, value, env.GetFeatures(), path...)
+		if !ok || v.Value() == nil {
+			debug.Debug("raw %s not found", keyName)
+			return step, false, true, false
+		}
+		debug.Debug("found raw %s", strings.Join(path, "."))
+		_, ok = v.Value().(dynaml.Expression)
 		if ok {
 			v = flow(v, env.WithPath(step), false, false)
 			_, ok := v.Value().(dynaml.Expression)
@@ -610,12 +740,14 @@ func stepName(index int, value yaml.Node, keyName string, env dynaml.Binding, un
 				return step, false, false, false
 			}
 		}
-		name, ok := v.Value().(string)
-		if ok && unique {
-			return keyName + ":" + name, true, true, true
+		name, ok := yaml.KeyValue(v, len(paths) > 1)
+		if !ok {
+			return step, false, true, false
 		}
-	} else {
-		debug.Debug("raw %s not found", keyName)
+		names = append(names, name)
+	}
+	if unique {
+		return keyName + ":" + yaml.JoinKeyValues(names), true, true, true
 	}
 	return step, false, true, false
 }
@@ -627,11 +759,12 @@ func useMerge(n yaml.Node) bool {
 	return false
 }
 
-func processMerges(orig yaml.Node, root []yaml.Node, env dynaml.Binding, template bool) (interface{}, bool, bool, []string, string, bool, yaml.NodeFlags, string, yaml.Node) {
+func processMerges(orig yaml.Node, root []yaml.Node, env dynaml.Binding, template bool) (interface{}, bool, bool, []string, string, bool, yaml.NodeFlags, string, string, yaml.Node) {
 	var flags yaml.NodeFlags
 	var stub yaml.Node
 	flags, stub = get_inherited_flags(env)
 	tag := orig.GetAnnotation().Tag()
+	deprecation := orig.GetAnnotation().Deprecation()
 	spliced := []yaml.Node{}
 	process := true
 	merged := orig.Merged()
@@ -682,6 +815,9 @@ func processMerges(orig yaml.Node, root []yaml.Node, env dynaml.Binding, templat
 					if t := m.GetTag(); t != "" {
 						tag = t
 					}
+					if msg, ok := m.GetDeprecation(); ok {
+						deprecation = msg
+					}
 					if ok && m.Has(dynaml.TEMPLATE) {
 						debug.Debug("found template declaration\n")
 						template = true
@@ -761,7 +897,7 @@ func processMerges(orig yaml.Node, root []yaml.Node, env dynaml.Binding, templat
 	}
 
 	debug.Debug("--> %+v  proc=%v replaced=%v redirect=%v key=%s\n", result, process, replaced, redirectPath, keyName)
-	return result, process, replaced, redirectPath, keyName, merged, flags, tag, stub
+	return result, process, replaced, redirectPath, keyName, merged, flags, tag, deprecation, stub
 }
 
 const NO_LIST_KEY = "<<<NO LIST KEY>>"
@@ -778,11 +914,11 @@ func ProcessKeyTag(val yaml.Node) (yaml.Node, string) {
 			if split > 0 {
 				if key[:split] == "key" {
 					if found {
-						if key[split+1:] != keyName {
+						if yaml.NormalizeKey(key[split+1:]) != keyName {
 							keyName = NO_LIST_KEY
 						}
 					} else {
-						keyName = key[split+1:]
+						keyName = yaml.NormalizeKey(key[split+1:])
 						if strings.HasPrefix(keyName, "!") {
 							no = true
 							keyName = keyName[1:]
@@ -794,14 +930,18 @@ func ProcessKeyTag(val yaml.Node) (yaml.Node, string) {
 		}
 		if found {
 			newMap := make(map[string]yaml.Node)
+			renamed := map[string]string{}
 			for key, v := range m {
 				split := strings.Index(key, ":")
 				if split > 0 {
 					if key[:split] == "key" {
+						orig := key
 						key = key[split+1:]
 						if strings.HasPrefix(key, "!") {
 							key = key[1:]
 						}
+						key = yaml.KeyField(key)
+						renamed[orig] = key
 					}
 				}
 				newMap[key] = v
@@ -809,7 +949,19 @@ func ProcessKeyTag(val yaml.Node) (yaml.Node, string) {
 			if no {
 				keyName = NO_LIST_KEY
 			}
-			return yaml.SubstituteNode(newMap, val), keyName
+			result := yaml.SubstituteNode(newMap, val)
+			if order := val.KeyOrder(); order != nil {
+				// the tagged field keeps its position
+				keys := make([]string, len(order))
+				for i, k := range order {
+					if r, ok := renamed[k]; ok {
+						k = r
+					}
+					keys[i] = k
+				}
+				result = yaml.OrderedNode(result, keys)
+			}
+			return result, keyName
 		}
 	}
 	return val, keyName
@@ -823,9 +975,12 @@ func newEntries(a []yaml.Node, b []yaml.Node, keyName string) []yaml.Node {
 	added := []yaml.Node{}
 
 	for _, val := range a {
-		name, ok := yaml.FindStringR(true, val, nil, keyName)
+		if val.Flags().Deleted() {
+			continue
+		}
+		name, ok := yaml.FindKeyValueR(true, val, nil, keyName)
 		if ok {
-			_, found := yaml.FindR(true, old, nil, name) // TODO
+			_, found := yaml.FindR(true, old, nil, keyName+":"+name)
 			if found {
 				continue
 			}
@@ -837,6 +992,78 @@ func newEntries(a []yaml.Node, b []yaml.Node, keyName string) []yaml.Node {
 	return added
 }
 
+// checkOverride checks the override of a node by a stub. Overriding a
+// node marked as final is an error, in this case the node with the
+// issue is returned. Overriding a deprecated node is reported as warning.
+func checkOverride(env dynaml.Binding, node yaml.Node, stub yaml.Node) (yaml.Node, bool) {
+	if stub.Flags().Default() {
+		return node, true
+	}
+	if node.Flags().Final() {
+		issue := yaml.NewIssue("final node must not be overridden by %s", yaml.SourceLocation(stub))
+		return dynaml.IssueNode(env, true, node, true, true, issue), false
+	}
+	if node.Flags().Deprecated() {
+		msg := "deprecated node overridden by " + yaml.SourceLocation(stub)
+		if d := node.GetAnnotation().Deprecation(); d != "" {
+			msg += ": " + d
+		}
+		env.GetState().GetWarnings().Add(env.Path(), "%s", msg)
+	}
+	return node, true
+}
+
+// deletedInStubs checks whether a list entry is marked
+// as deleted by a stub.
+func deletedInStubs(env dynaml.Binding) bool {
+	stub, found := env.FindInStubs(env.StubPath())
+	return found && stub.Flags().Deleted()
+}
+
+// insertEntries moves entries with a requested insertion position
+// (before:<key> or after:<key>) to the entry with the given key value.
+// Entries requesting the same position keep their order. If there
+// is no such entry, the entry stays at its actual position.
+func insertEntries(list []yaml.Node, steps []string, keyName string) []yaml.Node {
+	index := map[string]bool{}
+	for i, val := range list {
+		if val.GetAnnotation().Insertion() == "" {
+			index[steps[i]] = true
+		}
+	}
+
+	before := map[string][]yaml.Node{}
+	after := map[string][]yaml.Node{}
+	base := []int{}
+	for i, val := range list {
+		insertion := val.GetAnnotation().Insertion()
+		if insertion != "" {
+			split := strings.Index(insertion, ":")
+			target := keyName + ":" + insertion[split+1:]
+			if index[target] {
+				if insertion[:split] == "before" {
+					before[target] = append(before[target], val)
+				} else {
+					after[target] = append(after[target], val)
+				}
+				continue
+			}
+		}
+		base = append(base, i)
+	}
+	if len(base) == len(list) {
+		return list
+	}
+
+	result := []yaml.Node{}
+	for _, i := range base {
+		result = append(result, before[steps[i]]...)
+		result = append(result, list[i])
+		result = append(result, after[steps[i]]...)
+	}
+	return result
+}
+
 func updateNode(node yaml.Node, flags yaml.NodeFlags, tag string) yaml.Node {
 	if (flags | node.Flags()) != node.Flags() {
 		node = yaml.AddFlags(node, flags)
@@ -847,6 +1074,21 @@ func updateNode(node yaml.Node, flags yaml.NodeFlags, tag string) yaml.Node {
 	return node
 }
 
+// valueOrigin determines the origin of a value computed by the
+// expression of a node. Plain references and merges keep the origin
+// of the addressed node.
+func valueOrigin(node yaml.Node, e dynaml.Expression, info dynaml.EvaluationInfo) *yaml.Origin {
+	switch e.(type) {
+	case dynaml.ReferenceExpr, dynaml.MergeExpr:
+		if info.Origin != nil {
+			return info.Origin
+		}
+	}
+	o := yaml.NodeOrigin(node)
+	o.Expression = fmt.Sprintf("%s", e)
+	return &o
+}
+
 func substituteNode(v yaml.Node) (yaml.Node, bool) {
 	t, ok := v.Value().(dynaml.TemplateValue)
 	if !ok {
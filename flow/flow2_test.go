@@ -263,6 +263,184 @@ list:
     attr: b
   - field: c
     attr: d
+`)
+			Expect(source).To(FlowAs(resolved, stub))
+		})
+
+		It("merges map lists with composite keys", func() {
+			source := parseYAML(`
+---
+ports:
+  - <<: (( merge on port, protocol ))
+  - port: 53
+    protocol: UDP
+    name: dns
+  - port: 53
+    protocol: TCP
+    name: dns-tcp
+port: (( ports.["port,protocol:53,TCP"].name ))
+`)
+			stub := parseYAML(`
+---
+ports:
+  - port: 53
+    protocol: TCP
+    name: dns-stub
+  - port: 80
+    protocol: TCP
+    name: http
+`)
+			resolved := parseYAML(`
+---
+ports:
+  - port: 80
+    protocol: TCP
+    name: http
+  - port: 53
+    protocol: UDP
+    name: dns
+  - port: 53
+    protocol: TCP
+    name: dns-stub
+port: dns-stub
+`)
+			Expect(source).To(FlowAs(resolved, stub))
+		})
+
+		It("distinguishes composite key values containing commas", func() {
+			source := parseYAML(`
+---
+list:
+  - <<: (( merge on a,b ))
+  - a: x,y
+    b: z
+    v: first
+  - a: x
+    b: y,z
+    v: second
+`)
+			stub := parseYAML(`
+---
+list:
+  - a: x
+    b: y,z
+    v: stub
+`)
+			resolved := parseYAML(`
+---
+list:
+  - a: x,y
+    b: z
+    v: first
+  - a: x
+    b: y,z
+    v: stub
+`)
+			Expect(source).To(FlowAs(resolved, stub))
+		})
+
+		It("does not use non-string values as single keys", func() {
+			source := parseYAML(`
+---
+list:
+  - <<: (( merge on port ))
+  - port: 53
+    name: dns
+  - port: 80
+    name: http
+`)
+			stub := parseYAML(`
+---
+list:
+  - port: 80
+    name: stub
+`)
+			resolved := parseYAML(`
+---
+list:
+  - port: 80
+    name: stub
+  - port: 53
+    name: dns
+  - port: 80
+    name: http
+`)
+			Expect(source).To(FlowAs(resolved, stub))
+		})
+
+		It("merges map lists with nested keys", func() {
+			source := parseYAML(`
+---
+resources:
+  - <<: (( merge on kind,metadata.name ))
+  - kind: Service
+    metadata:
+      name: foo
+    spec: service
+  - kind: Deployment
+    metadata:
+      name: foo
+    spec: deployment
+`)
+			stub := parseYAML(`
+---
+resources:
+  - kind: Deployment
+    metadata:
+      name: foo
+    spec: stub
+`)
+			resolved := parseYAML(`
+---
+resources:
+  - kind: Service
+    metadata:
+      name: foo
+    spec: service
+  - kind: Deployment
+    metadata:
+      name: foo
+    spec: stub
+`)
+			Expect(source).To(FlowAs(resolved, stub))
+		})
+
+		It("merges map lists with composite key tags", func() {
+			source := parseYAML(`
+---
+resources:
+  - key:kind,metadata.name: Service
+    metadata:
+      name: foo
+    spec: service
+  - kind: Deployment
+    metadata:
+      name: foo
+    spec: deployment
+`)
+			stub := parseYAML(`
+---
+resources:
+  - kind: Deployment
+    metadata:
+      name: foo
+    spec: stub
+  - kind: Deployment
+    metadata:
+      name: bar
+    spec: other
+`)
+			resolved := parseYAML(`
+---
+resources:
+  - kind: Service
+    metadata:
+      name: foo
+    spec: service
+  - kind: Deployment
+    metadata:
+      name: foo
+    spec: stub
 `)
 			Expect(source).To(FlowAs(resolved, stub))
 		})
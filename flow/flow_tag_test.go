@@ -178,7 +178,7 @@ data:
   b: (( &tag:tag ))
 `)
 			Expect(source).To(FlowToErr(
-				`	(( &tag:tag ))	in test	data.b	()	*duplicate tag "tag": data.b <-> data.a`,
+				`	(( &tag:tag ))	in test:5:6	data.b	()	*duplicate tag "tag": data.b <-> data.a`,
 			))
 		})
 	})
@@ -253,6 +253,65 @@ fizz: buzz
 		})
 	})
 
+	Context("key order", func() {
+		It("keeps the template order and appends merged keys in stub order", func() {
+			source := parseYAML(`
+---
+zeta: 1
+alpha:
+  <<: (( merge ))
+  keep: (( zeta ))
+  first: a
+beta: (( merge ))
+`)
+
+			stub := parseYAML(`
+---
+beta:
+  z: 1
+  a: 2
+alpha:
+  new2: x
+  new1: z
+`)
+
+			result, err := Flow(source, stub)
+			Expect(err).NotTo(HaveOccurred())
+			data, err := yaml.Marshal(result)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal(`zeta: 1
+alpha:
+  keep: 1
+  first: a
+  new2: x
+  new1: z
+beta:
+  z: 1
+  a: 2
+`))
+		})
+
+		It("keeps the position of tagged list keys", func() {
+			source := parseYAML(`
+---
+list:
+  - key:id: a
+    v: 1
+    w: 2
+`)
+
+			result, err := Flow(source)
+			Expect(err).NotTo(HaveOccurred())
+			data, err := yaml.Marshal(result)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal(`list:
+- id: a
+  v: 1
+  w: 2
+`))
+		})
+	})
+
 	Context("when some dynaml nodes cannot be resolved", func() {
 		It("returns an error", func() {
 			source := parseYAML(`
@@ -264,10 +323,10 @@ foo: (( auto ))
 			Expect(err).To(Equal(dynaml.UnresolvedNodes{
 				Nodes: []dynaml.UnresolvedNode{
 					{
-						Node: yaml.IssueNode(yaml.NewNode(
+						Node: yaml.IssueNode(yaml.PositionedNode(yaml.NewNode(
 							dynaml.AutoExpr{Path: []string{"foo"}},
 							"test",
-						), true, false, yaml.NewPathIssue([]string{"foo"}, "auto only allowed for size entry in resource pools")),
+						), yaml.Position{Line: 3, Column: 6}), true, false, yaml.NewPathIssue([]string{"foo"}, "auto only allowed for size entry in resource pools")),
 						Context: []string{"foo"},
 						Path:    []string{"foo"},
 					},
@@ -8127,6 +8186,76 @@ read: alice
 		})
 	})
 
+	Describe("exec and pipe with options", func() {
+		It("provides a structured result", func() {
+			source := parseYAML(`
+---
+result: (( exec({ $command = "sh", $args = [ "-c", "echo $FOO; echo oops >&2; exit 3" ], $env = { $FOO = "bar" }, $rc = [ 0, 3 ], $result = true }) ))
+`)
+			resolved := parseYAML(`
+---
+result:
+  rc: 3
+  stderr: oops
+  stdout: bar
+`)
+			Expect(source).To(FlowAs(resolved))
+		})
+
+		It("handles output modes, working dir and stdin", func() {
+			source := parseYAML(`
+---
+data:
+  alice: 25
+lines: (( exec({ $command = [ "printf", "a\nb\n" ], $output = "lines" }) ))
+text: (( exec({ $command = "printf", $args = [ "25" ], $output = "text" }) ))
+dir: (( exec({ $command = "pwd", $dir = "/", $output = "text" }) ))
+yaml: (( pipe(data, { $command = "cat", $output = "yaml" }) ))
+stdin: (( exec({ $command = "cat", $stdin = "bob" }) ))
+`)
+			resolved := parseYAML(`
+---
+data:
+  alice: 25
+lines:
+  - a
+  - b
+text: "25"
+dir: /
+yaml:
+  alice: 25
+stdin: bob
+`)
+			Expect(source).To(FlowAs(resolved))
+		})
+
+		It("reports exit code and stderr of failed commands", func() {
+			source := parseYAML(`
+---
+legacy: (( catch(exec("sh", "-c", "echo failed >&2; exit 2")).error ))
+options: (( catch(exec({ $command = "sh", $args = [ "-c", "echo failed >&2; exit 2" ] })).error ))
+`)
+			resolved := parseYAML(`
+---
+legacy: "execution 'sh' failed with exit code 2: failed"
+options: "execution 'sh' failed with exit code 2: failed"
+`)
+			Expect(source).To(FlowAs(resolved))
+		})
+
+		It("stops commands after a timeout", func() {
+			source := parseYAML(`
+---
+result: (( catch(exec({ $command = "sleep", $args = [ 5 ], $timeout = "100ms" })).error ))
+`)
+			resolved := parseYAML(`
+---
+result: "execution 'sleep' failed: timed out after 100ms"
+`)
+			Expect(source).To(FlowAs(resolved))
+		})
+	})
+
 	Describe("node scope", func() {
 		Context("in expressions", func() {
 			It("finds node local direct entry", func() {
@@ -0,0 +1,21 @@
+//go:build !windows
+
+package flow
+
+import (
+	"os"
+	"syscall"
+)
+
+// privateToCurrentUser checks whether a file is owned by the current user
+// and not accessible by others. Files of filesystems without owners only
+// require the permissions.
+func privateToCurrentUser(info os.FileInfo) bool {
+	if info.Mode().Perm()&0077 != 0 {
+		return false
+	}
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		return int(st.Uid) == os.Getuid()
+	}
+	return true
+}
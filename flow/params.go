@@ -0,0 +1,245 @@
+package flow
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mandelsoft/spiff/dynaml"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+// PARAMS is the name of the top level template field declaring the
+// parameters of a template. It is not part of the processing result.
+const PARAMS = "__params"
+
+// Parameter describes a template input expected to be set by stubs.
+type Parameter struct {
+	// Name is the path of the parameter field in the stubs.
+	Name string
+	// Description explains the meaning of the parameter.
+	Description string
+	// Type is the expected type of the parameter value (see dynaml type function).
+	Type string
+	// Default is used if no stub sets the parameter.
+	Default yaml.Node
+	// Required indicates that the parameter must be set by a stub.
+	Required bool
+	// Validate is the list of conditions used to validate the value
+	// (see dynaml validate function).
+	Validate []yaml.Node
+}
+
+// Path provides the field path of the parameter.
+func (p *Parameter) Path() []string {
+	return strings.Split(p.Name, ".")
+}
+
+// ParameterError describes a parameter violation found for a
+// processing.
+type ParameterError struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+func (e ParameterError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Name, e.Message)
+}
+
+// ParameterErrors lists the parameter violations found for a
+// processing.
+type ParameterErrors []ParameterError
+
+func (e ParameterErrors) Error() string {
+	msg := "invalid template parameters:"
+	for _, p := range e {
+		msg = fmt.Sprintf("%s\n\t%s", msg, p)
+	}
+	return msg
+}
+
+// InvalidParameters is the error of a failed processing with parameter
+// violations. It keeps both, the violations and the processing error.
+type InvalidParameters struct {
+	Parameters ParameterErrors
+	Err        error
+}
+
+func (e InvalidParameters) Error() string {
+	return fmt.Sprintf("%s\n%s", e.Parameters, e.Err)
+}
+
+func (e InvalidParameters) Unwrap() []error {
+	return []error{e.Parameters, e.Err}
+}
+
+var parameterTypes = map[string]bool{
+	"string": true, "int": true, "float": true, "bool": true,
+	"list": true, "map": true, "template": true, "lambda": true, "nil": true,
+}
+
+// Parameters provides the parameter declarations of a template, sorted
+// by name. The declarations are read from the unprocessed template and
+// must therefore be given as plain values.
+func Parameters(template yaml.Node) ([]*Parameter, error) {
+	if template == nil {
+		return nil, nil
+	}
+	m, ok := template.Value().(map[string]yaml.Node)
+	if !ok || m[PARAMS] == nil {
+		return nil, nil
+	}
+	decls, ok := m[PARAMS].Value().(map[string]yaml.Node)
+	if !ok {
+		return nil, fmt.Errorf("%s: parameter declarations must be a map", PARAMS)
+	}
+
+	var params []*Parameter
+	for name, decl := range decls {
+		p, err := parseParameter(name, decl)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %s", PARAMS, name, err)
+		}
+		params = append(params, p)
+	}
+	sort.Slice(params, func(i, j int) bool { return params[i].Name < params[j].Name })
+	return params, nil
+}
+
+func parseParameter(name string, decl yaml.Node) (*Parameter, error) {
+	p := &Parameter{Name: name}
+	if decl == nil || decl.Value() == nil {
+		p.Required = true
+		return p, nil
+	}
+	fields, ok := decl.Value().(map[string]yaml.Node)
+	if !ok {
+		return nil, fmt.Errorf("parameter declaration must be a map")
+	}
+	var required *bool
+	for k, v := range fields {
+		var ok bool
+		switch k {
+		case "description":
+			p.Description, ok = v.Value().(string)
+		case "type":
+			p.Type, ok = v.Value().(string)
+			if ok && !parameterTypes[p.Type] {
+				return nil, fmt.Errorf("unknown type %q", p.Type)
+			}
+		case "default":
+			p.Default, ok = v, true
+		case "required":
+			var b bool
+			b, ok = v.Value().(bool)
+			required = &b
+		case "validate":
+			p.Validate, ok = v.Value().([]yaml.Node)
+		default:
+			return nil, fmt.Errorf("unknown field %q", k)
+		}
+		if !ok {
+			return nil, fmt.Errorf("invalid value for field %q", k)
+		}
+	}
+	if required != nil {
+		p.Required = *required
+	} else {
+		p.Required = p.Default == nil
+	}
+	return p, nil
+}
+
+// withoutParameters removes the parameter declarations from a template.
+func withoutParameters(template yaml.Node) yaml.Node {
+	m, ok := template.Value().(map[string]yaml.Node)
+	if !ok || m[PARAMS] == nil {
+		return template
+	}
+	r := map[string]yaml.Node{}
+	for k, v := range m {
+		if k != PARAMS {
+			r[k] = v
+		}
+	}
+	return yaml.ReplaceValue(r, template)
+}
+
+// parameterDefaults checks the parameters set by the stubs. It provides
+// a stub with the defaults of the parameters not set by any stub and the
+// violations of required parameters.
+func parameterDefaults(binding dynaml.Binding, params []*Parameter, stubs []yaml.Node, source string) (yaml.Node, ParameterErrors) {
+	var errs ParameterErrors
+	defaults := map[string]yaml.Node{}
+	for _, p := range params {
+		found := false
+		for _, stub := range stubs {
+			if _, found = yaml.Find(stub, binding.GetFeatures(), p.Path()...); found {
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		if p.Default != nil {
+			addParameterDefault(defaults, p.Path(), p.Default, source)
+		} else if p.Required {
+			errs = append(errs, ParameterError{p.Name, "parameter must be set by a stub"})
+		}
+	}
+	if len(defaults) == 0 {
+		return nil, errs
+	}
+	return yaml.NewNode(defaults, source), errs
+}
+
+func addParameterDefault(m map[string]yaml.Node, path []string, value yaml.Node, source string) {
+	if len(path) == 1 {
+		m[path[0]] = value
+		return
+	}
+	var sub map[string]yaml.Node
+	if n := m[path[0]]; n != nil {
+		sub, _ = n.Value().(map[string]yaml.Node)
+	}
+	if sub == nil {
+		sub = map[string]yaml.Node{}
+		m[path[0]] = yaml.NewNode(sub, source)
+	}
+	addParameterDefault(sub, path[1:], value, source)
+}
+
+// checkParameters checks the type and the validation conditions of the
+// parameter values found in the processing result. With the feature
+// lenient-validation failed conditions are reported as warnings.
+func checkParameters(binding dynaml.Binding, params []*Parameter, result yaml.Node) ParameterErrors {
+	var errs ParameterErrors
+	for _, p := range params {
+		value, found := yaml.Find(result, binding.GetFeatures(), p.Path()...)
+		if !found {
+			continue
+		}
+		if p.Type != "" {
+			if t := dynaml.ExpressionType(value); t != p.Type {
+				errs = append(errs, ParameterError{p.Name, fmt.Sprintf("type %s expected, but found %s", p.Type, t)})
+				continue
+			}
+		}
+		for i, c := range p.Validate {
+			r, m, err, _ := dynaml.EvalValidationExpression(value.Value(), c, binding)
+			if err != nil {
+				errs = append(errs, ParameterError{p.Name, fmt.Sprintf("condition %d has problem: %s", i+1, err)})
+				break
+			}
+			if !r {
+				if binding.GetFeatures().LenientValidationEnabled() {
+					binding.GetState().GetWarnings().Add(p.Path(), "condition %d failed: %s", i+1, m)
+					continue
+				}
+				errs = append(errs, ParameterError{p.Name, fmt.Sprintf("condition %d failed: %s", i+1, m)})
+				break
+			}
+		}
+	}
+	return errs
+}
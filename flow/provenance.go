@@ -0,0 +1,170 @@
+package flow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// provenance of processed values
+//
+// Every value node keeps the location of the node finally supplying its
+// value: the template, a stub, an injected node, or a read file. Values
+// computed by an expression keep the expression and its location, plain
+// references and merges pass the origin of the addressed node.
+
+// Provenance describes the origin of a leaf value of a processed document.
+type Provenance struct {
+	Path       []string
+	Origin     yaml.Origin
+	SourcePath []string // path in the source document, if known
+	Flags      []string
+}
+
+func (p Provenance) String() string {
+	s := p.Origin.Location()
+	if p.SourcePath != nil && strings.Join(p.SourcePath, ".") != strings.Join(p.Path, ".") {
+		s += " " + strings.Join(p.SourcePath, ".")
+	}
+	if p.Origin.Expression != "" {
+		s += " (( " + p.Origin.Expression + " ))"
+	}
+	if len(p.Flags) > 0 {
+		s += " (" + strings.Join(p.Flags, ",") + ")"
+	}
+	return s
+}
+
+// DetermineProvenance determines the provenance of a node of a processed
+// document. The path of the origin in its source document is taken from
+// the given source index.
+func DetermineProvenance(path []string, node yaml.Node, sources *SourceIndex) Provenance {
+	p := Provenance{Path: path, Origin: yaml.NodeOrigin(node)}
+	if sources != nil {
+		p.SourcePath = sources.Lookup(p.Origin.Source, p.Origin.Position)
+	}
+	flags := node.Flags()
+	if flags.Inject() {
+		p.Flags = append(p.Flags, "inject")
+	}
+	if flags.Default() {
+		p.Flags = append(p.Flags, "default")
+	}
+	if flags.State() {
+		p.Flags = append(p.Flags, "state")
+	}
+	return p
+}
+
+// ProvenanceOfLeafs determines the provenance of all leaf values of a
+// processed document in document order.
+func ProvenanceOfLeafs(root yaml.Node, sources *SourceIndex) []Provenance {
+	var result []Provenance
+	walkLeafs(nil, root, func(path []string, node yaml.Node) {
+		result = append(result, DetermineProvenance(path, node, sources))
+	})
+	return result
+}
+
+func walkLeafs(path []string, node yaml.Node, f func([]string, yaml.Node)) {
+	if node != nil {
+		switch v := node.Value().(type) {
+		case map[string]yaml.Node:
+			if len(v) > 0 {
+				for _, k := range yaml.GetOrderedKeys(node) {
+					walkLeafs(append(path[:len(path):len(path)], k), v[k], f)
+				}
+				return
+			}
+		case []yaml.Node:
+			if len(v) > 0 {
+				for i, e := range v {
+					walkLeafs(append(path[:len(path):len(path)], fmt.Sprintf("[%d]", i)), e, f)
+				}
+				return
+			}
+		}
+	}
+	f(path, node)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// SourceLoader provides the documents of a source not yet known
+// by a source index.
+type SourceLoader func(source string) []yaml.Node
+
+// SourceIndex maps source locations of nodes to their paths in the
+// source documents.
+type SourceIndex struct {
+	paths  map[string]map[yaml.Position][]string
+	loader SourceLoader
+}
+
+// NewSourceIndex creates an index for the given documents. Sources
+// not covered by the documents are requested from the loader, if given.
+func NewSourceIndex(loader SourceLoader, docs ...yaml.Node) *SourceIndex {
+	i := &SourceIndex{map[string]map[yaml.Position][]string{}, loader}
+	for _, d := range docs {
+		i.Add(d)
+	}
+	return i
+}
+
+// Add adds the nodes of a parsed document to the index.
+func (i *SourceIndex) Add(doc yaml.Node) {
+	if doc == nil {
+		return
+	}
+	paths := i.paths[doc.SourceName()]
+	if paths == nil {
+		paths = map[yaml.Position][]string{}
+		i.paths[doc.SourceName()] = paths
+	}
+	i.add(paths, nil, doc)
+}
+
+func (i *SourceIndex) add(paths map[yaml.Position][]string, path []string, node yaml.Node) {
+	if node == nil {
+		return
+	}
+	if pos := node.SourcePosition(); pos.IsValid() {
+		if _, ok := paths[pos]; !ok {
+			paths[pos] = path
+		}
+	}
+	switch v := node.Value().(type) {
+	case map[string]yaml.Node:
+		for k, e := range v {
+			i.add(paths, append(path[:len(path):len(path)], k), e)
+		}
+	case []yaml.Node:
+		for n, e := range v {
+			i.add(paths, append(path[:len(path):len(path)], fmt.Sprintf("[%d]", n)), e)
+		}
+	}
+}
+
+// Lookup determines the path of the node found at the given location.
+func (i *SourceIndex) Lookup(source string, pos yaml.Position) []string {
+	if !pos.IsValid() {
+		return nil
+	}
+	paths, ok := i.paths[source]
+	if !ok {
+		paths = map[yaml.Position][]string{}
+		i.paths[source] = paths
+		if i.loader != nil {
+			for _, d := range i.loader(source) {
+				i.add(paths, nil, d)
+			}
+		}
+	}
+	path, ok := paths[pos]
+	if ok && path == nil {
+		return []string{}
+	}
+	return path
+}
@@ -0,0 +1,94 @@
+package flow
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+var _ = Describe("Provenance", func() {
+	blame := func(template yaml.Node, stubs ...yaml.Node) map[string]string {
+		sources := NewSourceIndex(nil, append([]yaml.Node{template}, stubs...)...)
+		prepared, err := PrepareStubs(nil, false, stubs...)
+		Expect(err).NotTo(HaveOccurred())
+		result, err := Apply(nil, template, prepared, Options{})
+		Expect(err).NotTo(HaveOccurred())
+		found := map[string]string{}
+		for _, p := range ProvenanceOfLeafs(result, sources) {
+			found[strings.Join(p.Path, ".")] = p.String()
+		}
+		return found
+	}
+
+	It("reports template and stub values", func() {
+		template := parseYAML(`
+---
+meta:
+  name: (( merge ))
+  size: 3
+`, "template")
+		stub := parseYAML(`
+---
+meta:
+  name: stub
+`, "stub")
+		Expect(blame(template, stub)).To(Equal(map[string]string{
+			"meta.name": "stub:4:9",
+			"meta.size": "template:5:9",
+		}))
+	})
+
+	It("follows references and redirected merges", func() {
+		template := parseYAML(`
+---
+alice: (( bob.value ))
+bob:
+  value: 1
+redirected:
+  <<: (( merge other ))
+  value: 2
+`, "template")
+		stub := parseYAML(`
+---
+other:
+  value: stub
+`, "stub")
+		Expect(blame(template, stub)).To(Equal(map[string]string{
+			"alice":            "template:5:10 bob.value",
+			"bob.value":        "template:5:10",
+			"redirected.value": "stub:4:10 other.value",
+		}))
+	})
+
+	It("reports expressions computing values", func() {
+		template := parseYAML(`
+---
+a: (( b * 2 ))
+b: 1
+`, "template")
+		Expect(blame(template)).To(Equal(map[string]string{
+			"a": "template:3:4 (( b * 2 ))",
+			"b": "template:4:4",
+		}))
+	})
+
+	It("reports injected values", func() {
+		template := parseYAML(`
+---
+map:
+  a: 1
+`, "template")
+		stub := parseYAML(`
+---
+map:
+  b: (( &inject(2) ))
+`, "stub")
+		Expect(blame(template, stub)).To(Equal(map[string]string{
+			"map.a": "template:4:6",
+			"map.b": "stub:4:6 (( &inject ((2)) )) (inject)",
+		}))
+	})
+})
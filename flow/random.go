@@ -0,0 +1,46 @@
+package flow
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+)
+
+// seededReader provides an endless deterministic stream of pseudo random
+// data derived from a seed and a key (sha256 in counter mode).
+type seededReader struct {
+	seed    [sha256.Size]byte
+	counter uint64
+	buf     []byte
+}
+
+var _ io.Reader = (*seededReader)(nil)
+
+func newSeededReader(seed, key string) *seededReader {
+	return &seededReader{seed: sha256.Sum256([]byte(seed + "\x00" + key))}
+}
+
+func (r *seededReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(r.buf) == 0 {
+			var block [sha256.Size + 8]byte
+			copy(block[:], r.seed[:])
+			binary.BigEndian.PutUint64(block[sha256.Size:], r.counter)
+			sum := sha256.Sum256(block[:])
+			r.buf = sum[:]
+			r.counter++
+		}
+		c := copy(p[n:], r.buf)
+		r.buf = r.buf[c:]
+		n += c
+	}
+	return n, nil
+}
+
+// seededName provides a stable name for a key derived from a seed.
+func seededName(seed, key string) string {
+	sum := sha256.Sum256([]byte(seed + "\x00" + key))
+	return hex.EncodeToString(sum[:10])
+}
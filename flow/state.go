@@ -1,11 +1,11 @@
 package flow
 
 import (
+	"crypto/rand"
 	"crypto/sha512"
 	"encoding/base64"
 	"fmt"
-	"io/ioutil"
-	"net/http"
+	"io"
 	"path"
 	"reflect"
 	"sort"
@@ -13,6 +13,8 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/mandelsoft/vfs/pkg/layerfs"
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
 	"github.com/mandelsoft/vfs/pkg/osfs"
 	"github.com/mandelsoft/vfs/pkg/vfs"
 
@@ -54,7 +56,9 @@ var _ dynaml.ExecCache = &execCache{}
 
 type State struct {
 	files      map[string]string // content hash to temp file name
+	tempDir    string            // private temp directory for deterministic mode
 	fileCache  map[string][]byte // file content cache
+	probed     map[string]bool   // files checked for existence
 	key        string            // default encryption key
 	mode       int
 	exec_cache dynaml.ExecCache // execution cache
@@ -62,7 +66,15 @@ type State struct {
 	registry   dynaml.Registry
 	features   features.FeatureFlags
 	tags       map[string]*dynaml.TagInfo
-	docno      int // document number
+	policy     *dynaml.Policy  // access restrictions
+	fetcher    *Fetcher        // remote content access
+	seed       *string         // seed for deterministic mode
+	journal    *dynaml.Journal // side effects recorded in dry-run mode
+	profiler   *dynaml.Profiler
+	warnings   *dynaml.Warnings
+	docno      int              // document number
+	recorders  []*dependencies  // dependency recording for actual evaluations
+	randoms    []map[string]int // random source requests of actual evaluations
 }
 
 var _ dynaml.State = &State{}
@@ -83,6 +95,7 @@ func NewState(key string, mode int, optfs ...vfs.FileSystem) *State {
 		tags:       map[string]*dynaml.TagInfo{},
 		files:      map[string]string{},
 		fileCache:  map[string][]byte{},
+		probed:     map[string]bool{},
 		key:        key,
 		mode:       mode,
 		exec_cache: &execCache{cache: make(map[string][]byte)},
@@ -90,6 +103,7 @@ func NewState(key string, mode int, optfs ...vfs.FileSystem) *State {
 		docno:      1,
 		features:   features.Features(),
 		registry:   dynaml.DefaultRegistry(),
+		warnings:   &dynaml.Warnings{},
 	}
 }
 
@@ -135,6 +149,155 @@ func (s *State) ControlEnabled() bool {
 	return s.features.ControlEnabled()
 }
 
+// SetPolicy sets a policy restricting the access to external resources.
+func (s *State) SetPolicy(p *dynaml.Policy) *State {
+	s.policy = p
+	return s
+}
+
+func (s *State) GetPolicy() *dynaml.Policy {
+	if s == nil {
+		return nil
+	}
+	return s.policy
+}
+
+// SetDeterministic enables the deterministic mode using the given seed.
+// Random values, generated keys, salts and temp file names are then
+// derived from the seed, and uncached command executions are rejected.
+func (s *State) SetDeterministic(seed string) *State {
+	s.seed = &seed
+	return s
+}
+
+func (s *State) Deterministic() bool {
+	return s.seed != nil
+}
+
+// GetRandomSource provides the source for random data for the given key.
+// In deterministic mode it is derived from the seed and the key.
+// Repeated requests for the same key during a single expression evaluation
+// (for example by lambda calls or iterations) are numbered to provide
+// different data for every request.
+func (s *State) GetRandomSource(key string) io.Reader {
+	if s.seed == nil {
+		return rand.Reader
+	}
+	if n := len(s.randoms); n > 0 {
+		calls := s.randoms[n-1]
+		if c := calls[key]; c > 0 {
+			calls[key] = c + 1
+			key = fmt.Sprintf("%s#%d", key, c)
+		} else {
+			calls[key] = 1
+		}
+	}
+	return newSeededReader(*s.seed, key)
+}
+
+// startRandoms starts counting the random source requests of an
+// expression evaluation.
+func startRandoms(env dynaml.Binding) {
+	if s, ok := env.GetState().(*State); ok && s != nil {
+		s.randoms = append(s.randoms, map[string]int{})
+	}
+}
+
+// stopRandoms stops counting the random source requests of an
+// expression evaluation.
+func stopRandoms(env dynaml.Binding) {
+	if s, ok := env.GetState().(*State); ok && s != nil && len(s.randoms) > 0 {
+		s.randoms = s.randoms[:len(s.randoms)-1]
+	}
+}
+
+// SetDryRun enables the dry-run mode. File system modifications are done
+// in an in-memory layer on top of the actual filesystem and command
+// executions are not performed. All those side effects are recorded in
+// the given journal.
+func (s *State) SetDryRun(journal *dynaml.Journal) *State {
+	if journal == nil {
+		journal = &dynaml.Journal{}
+	}
+	if s.journal == nil {
+		s.fileSystem = vfs.New(&layeredFileSystem{
+			FileSystem: layerfs.New(memoryfs.New(), s.fileSystem),
+			tempDir:    s.fileSystem.FSTempDir(),
+		})
+	}
+	s.journal = journal
+	return s
+}
+
+// layeredFileSystem keeps the temp directory of the base filesystem
+// for a layered filesystem.
+type layeredFileSystem struct {
+	vfs.FileSystem
+	tempDir string
+}
+
+func (fs *layeredFileSystem) FSTempDir() string {
+	return fs.tempDir
+}
+
+// GetJournal provides the journal of side effects recorded in dry-run mode.
+func (s *State) GetJournal() *dynaml.Journal {
+	return s.journal
+}
+
+// SetProfiler sets a profiler recording the effort of the processing.
+func (s *State) SetProfiler(p *dynaml.Profiler) *State {
+	s.profiler = p
+	return s
+}
+
+func (s *State) GetProfiler() *dynaml.Profiler {
+	if s == nil {
+		return nil
+	}
+	return s.profiler
+}
+
+// SetWarnings sets the collector for the warnings reported
+// during the processing.
+func (s *State) SetWarnings(w *dynaml.Warnings) *State {
+	if w == nil {
+		w = &dynaml.Warnings{}
+	}
+	s.warnings = w
+	return s
+}
+
+// GetWarnings provides the warnings reported during the processing.
+func (s *State) GetWarnings() *dynaml.Warnings {
+	if s == nil {
+		return nil
+	}
+	return s.warnings
+}
+
+// SetFetcher sets the fetcher used to read remote content.
+func (s *State) SetFetcher(f *Fetcher) *State {
+	s.fetcher = f
+	return s
+}
+
+// GetFetcher provides the fetcher used to read remote content.
+func (s *State) GetFetcher() *Fetcher {
+	if s == nil {
+		return nil
+	}
+	return s.fetcher
+}
+
+// Fetch reads remote content with the configured fetcher.
+func (s *State) Fetch(url string, opts *dynaml.FetchOptions) ([]byte, error) {
+	if s.fetcher == nil {
+		s.fetcher = NewFetcher("", false)
+	}
+	return s.fetcher.Fetch(s, url, opts)
+}
+
 func (s *State) OSAccessAllowed() bool {
 	return s.mode&MODE_OS_ACCESS != 0
 }
@@ -178,16 +341,60 @@ func (s *State) GetTempName(data []byte) (string, error) {
 
 	name, ok := s.files[hash]
 	if !ok {
-		file, err := s.fileSystem.TempFile("", "spiff-")
-		if err != nil {
-			return "", err
+		if s.seed != nil {
+			// the names are derived from the seed, the files are created
+			// exclusively in a private directory of the current user
+			if s.tempDir == "" {
+				dir, err := s.privateTempDir()
+				if err != nil {
+					return "", err
+				}
+				s.tempDir = dir
+			}
+			name = path.Join(s.tempDir, "spiff-"+seededName(*s.seed, hash))
+			file, err := s.fileSystem.OpenFile(name, vfs.O_RDWR|vfs.O_CREATE|vfs.O_EXCL, 0600)
+			if vfs.IsErrExist(err) {
+				// left over by an earlier processing
+				if err = s.fileSystem.Remove(name); err == nil {
+					file, err = s.fileSystem.OpenFile(name, vfs.O_RDWR|vfs.O_CREATE|vfs.O_EXCL, 0600)
+				}
+			}
+			if err != nil {
+				return "", err
+			}
+			file.Close()
+		} else {
+			file, err := s.fileSystem.TempFile("", "spiff-")
+			if err != nil {
+				return "", err
+			}
+			name = file.Name()
 		}
-		name = file.Name()
 		s.files[hash] = name
 	}
 	return name, nil
 }
 
+// privateTempDir provides the temp directory for deterministic mode. Its
+// name is derived from the seed. It is created exclusively, an existing
+// directory is only used if it is a directory owned by the current user
+// and not accessible by others.
+func (s *State) privateTempDir() (string, error) {
+	dir := path.Join(s.fileSystem.FSTempDir(), "spiff-"+seededName(*s.seed, "tempdir"))
+	err := s.fileSystem.Mkdir(dir, 0700)
+	if err == nil || !vfs.IsErrExist(err) {
+		return dir, err
+	}
+	info, err := s.fileSystem.Lstat(dir)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() || !privateToCurrentUser(info) {
+		return "", fmt.Errorf("temp directory %q is not private to the current user", dir)
+	}
+	return dir, nil
+}
+
 func (s *State) SetTag(name string, node yaml.Node, path []string, scope dynaml.TagScope) error {
 	name = strings.Replace(name, ":", ".", -1)
 	debug.Debug("setting tag: %v\n", path)
@@ -205,6 +412,7 @@ func (s *State) SetTag(name string, node yaml.Node, path []string, scope dynaml.
 }
 
 func (s *State) GetTag(name string) *dynaml.Tag {
+	s.recordVolatile()
 	name = strings.Replace(name, ":", ".", -1)
 	if strings.HasPrefix(name, "doc.") {
 		i, err := strconv.Atoi(name[4:])
@@ -227,6 +435,7 @@ func (s *State) GetTag(name string) *dynaml.Tag {
 }
 
 func (s *State) GetTags(name string) []*dynaml.TagInfo {
+	s.recordVolatile()
 	name = strings.Replace(name, ":", ".", -1)
 	if strings.HasPrefix(name, "doc.") {
 		i, err := strconv.Atoi(name[4:])
@@ -263,6 +472,18 @@ func (s *State) GetTags(name string) []*dynaml.TagInfo {
 	return list
 }
 
+// Tags provides all tags currently known, ordered by name.
+func (s *State) Tags() []*dynaml.Tag {
+	var list []*dynaml.Tag
+	for _, t := range s.tags {
+		list = append(list, t.Tag())
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name() < list[j].Name()
+	})
+	return list
+}
+
 func (s *State) ResetTags() {
 	s.tags = map[string]*dynaml.TagInfo{}
 	s.docno = 1
@@ -294,6 +515,31 @@ func (s *State) Cleanup() {
 		s.fileSystem.Remove(n)
 	}
 	s.files = map[string]string{}
+	if s.tempDir != "" {
+		s.fileSystem.Remove(s.tempDir)
+		s.tempDir = ""
+	}
+}
+
+// CachedFiles provides the names of all files read or probed during the
+// processing, ordered by name.
+func (s *State) CachedFiles() []string {
+	var list []string
+	for f := range s.fileCache {
+		list = append(list, f)
+	}
+	for f := range s.probed {
+		if _, ok := s.fileCache[f]; !ok {
+			list = append(list, f)
+		}
+	}
+	sort.Strings(list)
+	return list
+}
+
+// ProbeFile records a file checked for existence by the processing.
+func (s *State) ProbeFile(file string) {
+	s.probed[dynaml.FilePath(file)] = true
 }
 
 func (s *State) GetFileContent(file string, cached bool) ([]byte, error) {
@@ -303,19 +549,15 @@ func (s *State) GetFileContent(file string, cached bool) ([]byte, error) {
 	data := s.fileCache[file]
 	if !cached || data == nil {
 		debug.Debug("reading file %s\n", file)
-		if strings.HasPrefix(file, "http:") || strings.HasPrefix(file, "https:") {
-			response, err := http.Get(file)
+		if r := dynaml.LookupResolver(s.registry, file); r != nil {
+			data, err = r.GetContent(s, file)
 			if err != nil {
 				return nil, fmt.Errorf("error getting [%s]: %s", file, err)
-			} else {
-				defer response.Body.Close()
-				contents, err := ioutil.ReadAll(response.Body)
-				if err != nil {
-					return nil, fmt.Errorf("error getting body [%s]: %s", file, err)
-				}
-				data = contents
 			}
 		} else {
+			if err := s.policy.CheckRead(file); err != nil {
+				return nil, err
+			}
 			data, err = s.fileSystem.ReadFile(file)
 			if err != nil {
 				return nil, fmt.Errorf("error reading [%s]: %s", path.Clean(file), err)
@@ -3,6 +3,9 @@ package flow
 import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/spiff/dynaml"
+	"github.com/mandelsoft/spiff/features"
 )
 
 var _ = Describe("Flowing YAML for validation", func() {
@@ -34,6 +37,28 @@ val:
 			})
 		})
 
+		Context("lenient", func() {
+			It("reports failed conditions as warnings", func() {
+				source := parseYAML(`
+---
+val: (( validate("1.2.3.4/200", "cidr") ))
+`)
+				resolved := parseYAML(`
+---
+val: 1.2.3.4/200
+`)
+				flags := features.FeatureFlags{}
+				flags.SetLenientValidation(true)
+				state := NewDefaultState().SetFeatures(flags)
+				result, err := NestedFlow(NewEnvironment(nil, "", state), source)
+				Expect(err).To(Succeed())
+				Expect(result.EquivalentToNode(resolved)).To(BeTrue())
+				Expect(state.GetWarnings().List()).To(Equal([]dynaml.Warning{
+					{Path: []string{"val"}, Message: "condition 1 failed: is no CIDR: invalid CIDR address: 1.2.3.4/200"},
+				}))
+			})
+		})
+
 		Context("ip", func() {
 			It("accepts", func() {
 				source := parseYAML(`
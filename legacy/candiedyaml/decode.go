@@ -29,6 +29,17 @@ type Unmarshaler interface {
 	UnmarshalYAML(tag string, value interface{}) error
 }
 
+// Positioned wraps a decoded value together with the position of its
+// start in the source document. It is only generated if position
+// tracking is enabled for a Decoder (see UsePositions).
+// For mappings Keys describes the order of the keys in the document.
+type Positioned struct {
+	Value  interface{}
+	Line   int
+	Column int
+	Keys   []interface{}
+}
+
 // A Number represents a JSON number literal.
 type Number string
 
@@ -50,6 +61,7 @@ type Decoder struct {
 	event         yaml_event_t
 	replay_events []yaml_event_t
 	useNumber     bool
+	usePositions  bool
 
 	anchors          map[string][]yaml_event_t
 	tracking_anchors [][]yaml_event_t
@@ -136,6 +148,10 @@ func (d *Decoder) Decode(v interface{}) (err error) {
 
 func (d *Decoder) UseNumber() { d.useNumber = true }
 
+// UsePositions causes the Decoder to wrap every value decoded into
+// an empty interface into a Positioned value carrying its source position.
+func (d *Decoder) UsePositions() { d.usePositions = true }
+
 func (d *Decoder) error(err error) {
 	panic(err)
 }
@@ -183,7 +199,12 @@ func (d *Decoder) document(rv reflect.Value) {
 	}
 
 	d.nextEvent()
-	d.parse(rv)
+	if d.usePositions && d.event.event_type != yaml_DOCUMENT_END_EVENT &&
+		rv.Elem().Kind() == reflect.Interface && rv.Elem().NumMethod() == 0 {
+		rv.Elem().Set(reflect.ValueOf(d.valueInterface()))
+	} else {
+		d.parse(rv)
+	}
 
 	if d.event.event_type != yaml_DOCUMENT_END_EVENT {
 		d.error(fmt.Errorf("Expected document end at %s", d.event.start_mark))
@@ -538,9 +559,22 @@ func (d *Decoder) alias(rv reflect.Value) {
 	d.parse(rv)
 }
 
+func (d *Decoder) aliasInterface() interface{} {
+	val, ok := d.anchors[string(d.event.anchor)]
+	if !ok {
+		d.error(fmt.Errorf("missing anchor: '%s' at %s", d.event.anchor, d.event.start_mark))
+	}
+
+	d.replay_events = val
+	d.nextEvent()
+	return d.valueInterface()
+}
+
 func (d *Decoder) valueInterface() interface{} {
 	var v interface{}
+	var keys []interface{}
 
+	mark := d.event.start_mark
 	anchor := string(d.event.anchor)
 	switch d.event.event_type {
 	case yaml_SEQUENCE_START_EVENT:
@@ -548,11 +582,14 @@ func (d *Decoder) valueInterface() interface{} {
 		v = d.sequenceInterface()
 	case yaml_MAPPING_START_EVENT:
 		d.begin_anchor(anchor)
-		v = d.mappingInterface()
+		v, keys = d.orderedMappingInterface()
 	case yaml_SCALAR_EVENT:
 		d.begin_anchor(anchor)
 		v = d.scalarInterface()
 	case yaml_ALIAS_EVENT:
+		if d.usePositions {
+			return d.aliasInterface()
+		}
 		rv := reflect.ValueOf(&v)
 		d.alias(rv)
 		return v
@@ -566,6 +603,9 @@ func (d *Decoder) valueInterface() interface{} {
 	}
 	d.end_anchor(anchor)
 
+	if d.usePositions {
+		return Positioned{Value: v, Line: mark.line + 1, Column: mark.column + 1, Keys: keys}
+	}
 	return v
 }
 
@@ -601,7 +641,15 @@ done:
 
 // objectInterface is like object but returns map[string]interface{}.
 func (d *Decoder) mappingInterface() map[interface{}]interface{} {
+	m, _ := d.orderedMappingInterface()
+	return m
+}
+
+// orderedMappingInterface is like mappingInterface but additionally
+// returns the keys in the order of their occurrence.
+func (d *Decoder) orderedMappingInterface() (map[interface{}]interface{}, []interface{}) {
 	m := make(map[interface{}]interface{})
+	var keys []interface{}
 
 	d.nextEvent()
 
@@ -613,8 +661,14 @@ done:
 		}
 
 		key := d.valueInterface()
+		if p, ok := key.(Positioned); ok {
+			key = p.Value
+		}
 
 		// Read value.
+		if _, ok := m[key]; !ok {
+			keys = append(keys, key)
+		}
 		m[key] = d.valueInterface()
 	}
 
@@ -622,5 +676,5 @@ done:
 		d.nextEvent()
 	}
 
-	return m
+	return m, keys
 }
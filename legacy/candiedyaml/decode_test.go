@@ -896,6 +896,37 @@ b:
 			Expect(n.String()).To(Equal("123"))
 		})
 	})
+	Context("Tracks positions", func() {
+		It("wraps values with their source position", func() {
+			d := NewDecoder(strings.NewReader("a:\n  - b\n  - &x c\nd: *x\n"))
+			d.UsePositions()
+			var v interface{}
+
+			err := d.Decode(&v)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v).To(Equal(Positioned{
+				Value: map[interface{}]interface{}{
+					"a": Positioned{Value: []interface{}{
+						Positioned{Value: "b", Line: 2, Column: 5},
+						Positioned{Value: "c", Line: 3, Column: 5},
+					}, Line: 2, Column: 3},
+					"d": Positioned{Value: "c", Line: 3, Column: 5},
+				}, Line: 1, Column: 1, Keys: []interface{}{"a", "d"}}))
+		})
+	})
+
+	Context("Tracks key order", func() {
+		It("reports the keys of a mapping in document order", func() {
+			d := NewDecoder(strings.NewReader("z: 1\na: 2\nm: 3\n"))
+			d.UsePositions()
+			var v interface{}
+
+			err := d.Decode(&v)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v.(Positioned).Keys).To(Equal([]interface{}{"z", "a", "m"}))
+		})
+	})
+
 	Context("When there are special characters", func() {
 		It("returns an error", func() {
 			d := NewDecoder(strings.NewReader(`
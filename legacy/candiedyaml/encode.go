@@ -30,6 +30,7 @@ var (
 	timeTimeType  = reflect.TypeOf(time.Time{})
 	marshalerType = reflect.TypeOf(new(Marshaler)).Elem()
 	numberType    = reflect.TypeOf(Number(""))
+	mapSliceType  = reflect.TypeOf(MapSlice{})
 	nonPrintable  = regexp.MustCompile("[^\t\n\r\u0020-\u007E\u0085\u00A0-\uD7FF\uE000-\uFFFD]")
 	multiline     = regexp.MustCompile("\n|\u0085|\u2028|\u2029")
 
@@ -50,6 +51,16 @@ type Marshaler interface {
 	MarshalYAML() (tag string, value interface{}, err error)
 }
 
+// MapItem is a single key/value pair of a MapSlice.
+type MapItem struct {
+	Key   interface{}
+	Value interface{}
+}
+
+// MapSlice is a mapping emitted with the keys in the given order
+// instead of the sorted order used for regular maps.
+type MapSlice []MapItem
+
 // An Encoder writes JSON objects to an output stream.
 type Encoder struct {
 	w       io.Writer
@@ -118,6 +129,11 @@ func (e *Encoder) marshal(tag string, v reflect.Value, allowAddr bool) {
 		}
 	}
 
+	if vt == mapSliceType {
+		e.emitMapSlice(tag, v.Interface().(MapSlice))
+		return
+	}
+
 	switch v.Kind() {
 	case reflect.Interface:
 		if v.IsNil() {
@@ -163,6 +179,19 @@ func (e *Encoder) emitMap(tag string, v reflect.Value) {
 	})
 }
 
+func (e *Encoder) emitMapSlice(tag string, m MapSlice) {
+	e.mapping(tag, func() {
+		for _, item := range m {
+			e.marshal("", reflect.ValueOf(item.Key), true)
+			if item.Value == nil {
+				e.emitNil()
+			} else {
+				e.marshal("", reflect.ValueOf(item.Value), true)
+			}
+		}
+	})
+}
+
 func (e *Encoder) emitStruct(tag string, v reflect.Value) {
 	if v.Type() == timeTimeType {
 		e.emitTime(tag, v)
@@ -354,6 +354,20 @@ name: Mark McGwire
 			Expect(buf.String()).To(Equal(`avg: 0.278
 hr: 65
 name: Mark McGwire
+`))
+		})
+
+		It("keeps the key order of map slices", func() {
+			err := enc.Encode(MapSlice{
+				{"name", "Mark McGwire"},
+				{"hr", 65},
+				{"avg", nil},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(buf.String()).To(Equal(`name: Mark McGwire
+hr: 65
+avg: null
 `))
 		})
 	})
@@ -0,0 +1,517 @@
+package lsp
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mandelsoft/spiff/compile"
+	"github.com/mandelsoft/spiff/dynaml"
+	"github.com/mandelsoft/spiff/features"
+	"github.com/mandelsoft/spiff/flow"
+	"github.com/mandelsoft/spiff/legacy/candiedyaml"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+// Document is an analysed text document.
+type Document struct {
+	URI  string
+	Path string // source name used for parsing
+	Text string
+
+	lines  []string
+	docs   []yaml.Node
+	err    error
+	index  []entry
+	merged []yaml.Node
+}
+
+// entry describes the location of a node of a document.
+type entry struct {
+	doc  int
+	path []string
+	node yaml.Node
+}
+
+// NewDocument parses a text document and indexes its nodes.
+func NewDocument(uri string, text string) *Document {
+	d := &Document{
+		URI:   uri,
+		Path:  URIToPath(uri),
+		Text:  text,
+		lines: strings.Split(text, "\n"),
+	}
+	d.docs, d.err = yaml.ParseMulti(d.Path, []byte(text))
+	for i, doc := range d.docs {
+		d.add(i, nil, doc)
+	}
+	sort.SliceStable(d.index, func(i, j int) bool {
+		a, b := d.index[i].node.SourcePosition(), d.index[j].node.SourcePosition()
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return d
+}
+
+func (d *Document) add(doc int, path []string, node yaml.Node) {
+	if node == nil {
+		return
+	}
+	if node.SourcePosition().IsValid() {
+		d.index = append(d.index, entry{doc, path, node})
+	}
+	switch v := node.Value().(type) {
+	case map[string]yaml.Node:
+		for k, e := range v {
+			d.add(doc, append(path[:len(path):len(path)], k), e)
+		}
+	case []yaml.Node:
+		for i, e := range v {
+			d.add(doc, append(path[:len(path):len(path)], fmt.Sprintf("[%d]", i)), e)
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// diagnostics
+
+var yamlErrorPosition = regexp.MustCompile(`at line (\d+), column (\d+)`)
+
+// Diagnostics provides the findings of the static checks for the document.
+func (d *Document) Diagnostics(stubs []yaml.Node, opts compile.LintOptions) []Diagnostic {
+	result := []Diagnostic{}
+	if d.err != nil {
+		line, col := 1, 1
+		if m := yamlErrorPosition.FindStringSubmatch(d.err.Error()); m != nil {
+			line, _ = strconv.Atoi(m[1])
+			col, _ = strconv.Atoi(m[2])
+		}
+		return append(result, Diagnostic{
+			Range:    d.lineRange(yaml.Position{Line: line, Column: col}),
+			Severity: SEVERITY_ERROR,
+			Source:   "spiff",
+			Message:  d.err.Error(),
+		})
+	}
+	for _, e := range compile.Lint(d.docs, stubs, opts) {
+		if e.Source != d.Path {
+			continue
+		}
+		result = append(result, Diagnostic{
+			Range:    d.lineRange(e.Position),
+			Severity: SEVERITY_ERROR,
+			Source:   "spiff",
+			Message:  fmt.Sprintf("%s: %s", strings.Join(e.Path, "."), e.Message),
+		})
+	}
+	return result
+}
+
+// lineRange provides the range from a source position to the end of its line.
+func (d *Document) lineRange(pos yaml.Position) Range {
+	start := toPosition(pos)
+	end := start
+	if start.Line < len(d.lines) {
+		end.Character = len(strings.TrimRight(d.lines[start.Line], "\r"))
+	}
+	if end.Character < start.Character {
+		end.Character = start.Character
+	}
+	return Range{start, end}
+}
+
+func toPosition(pos yaml.Position) Position {
+	p := Position{Line: pos.Line - 1, Character: pos.Column - 1}
+	if p.Line < 0 {
+		p.Line = 0
+	}
+	if p.Character < 0 {
+		p.Character = 0
+	}
+	return p
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// merge
+
+// Merge processes the document with the given stubs. The result is kept
+// to show evaluated values. The document is processed on every change,
+// therefore neither operating system commands nor file or network
+// accesses are executed and the processing is done in dry-run mode.
+func (d *Document) Merge(stubs []yaml.Node) error {
+	if d.err != nil {
+		return d.err
+	}
+	state := flow.NewState(features.EncryptionKey(), 0).SetFeatures(features.Features()).SetDryRun(nil)
+	env := flow.NewEnvironment(nil, "context", state)
+	prepared, err := flow.PrepareStubs(env, true, stubs...)
+	var merged []yaml.Node
+	for _, doc := range d.docs {
+		result, e := flow.Apply(env, doc, prepared, flow.Options{Partial: true})
+		if e != nil {
+			result = dynaml.ResetUnresolvedNodes(result)
+			if err == nil {
+				err = e
+			}
+		}
+		merged = append(merged, result)
+	}
+	d.merged = merged
+	return err
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// navigation
+
+// nodeAt determines the most specific node found at the line of a position.
+// For a key line without a value on the same line the nested map or list
+// starts at the next line.
+func (d *Document) nodeAt(pos Position) (entry, bool) {
+	line := pos.Line
+	keyLine := strings.HasSuffix(strings.TrimSpace(d.line(pos.Line)), ":")
+	var found *entry
+	for i := range d.index {
+		e := &d.index[i]
+		l := e.node.SourcePosition().Line - 1
+		if keyLine && l > line && found == nil {
+			line = l
+		}
+		if l == line && (!keyLine || l > pos.Line) {
+			// the value of a key line is the least specific nested node
+			if found == nil || keyLine && len(e.path) < len(found.path) || !keyLine && len(e.path) > len(found.path) {
+				found = e
+			}
+		}
+		if l > line {
+			break
+		}
+	}
+	if found == nil {
+		return entry{}, false
+	}
+	return *found, true
+}
+
+func (d *Document) line(n int) string {
+	if n < 0 || n >= len(d.lines) {
+		return ""
+	}
+	return strings.TrimRight(d.lines[n], "\r")
+}
+
+func isWordChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '_' || c == '-' || c == '.' || c == ':' || c == '[' || c == ']'
+}
+
+// wordAt provides the reference like word found at a position.
+func (d *Document) wordAt(pos Position) (string, Range) {
+	line := d.line(pos.Line)
+	start := pos.Character
+	if start > len(line) {
+		start = len(line)
+	}
+	end := start
+	for start > 0 && isWordChar(line[start-1]) {
+		start--
+	}
+	for end < len(line) && isWordChar(line[end]) {
+		end++
+	}
+	return line[start:end], Range{Position{pos.Line, start}, Position{pos.Line, end}}
+}
+
+// inExpression checks whether a position is located inside a dynaml expression.
+func (d *Document) inExpression(pos Position) bool {
+	line := d.line(pos.Line)
+	if pos.Character < len(line) {
+		line = line[:pos.Character]
+	}
+	i := strings.LastIndex(line, "((")
+	return i >= 0 && !strings.Contains(line[i:], "))")
+}
+
+// reference parses the reference found at a position.
+func (d *Document) reference(pos Position) (dynaml.ReferenceExpr, bool) {
+	if !d.inExpression(pos) {
+		return dynaml.ReferenceExpr{}, false
+	}
+	word, _ := d.wordAt(pos)
+	word = strings.TrimRight(word, ".")
+	if word == "" {
+		return dynaml.ReferenceExpr{}, false
+	}
+	expr, err := dynaml.Parse(word, nil, nil)
+	if err != nil {
+		return dynaml.ReferenceExpr{}, false
+	}
+	ref, ok := expr.(dynaml.ReferenceExpr)
+	return ref, ok
+}
+
+// resolve determines the node addressed by a reference used at the given
+// location. Relative references are looked up in the enclosing maps,
+// tagged references in the nodes tagged by a tag marker.
+func (d *Document) resolve(at entry, ref dynaml.ReferenceExpr) (entry, bool) {
+	if ref.Tag != "" {
+		for _, t := range d.tags(ref.Tag) {
+			if len(ref.Path) == 1 && ref.Path[0] == "" {
+				return t, true
+			}
+			if n, ok := d.find(t.doc, append(t.path[:len(t.path):len(t.path)], ref.Path...)); ok {
+				return n, true
+			}
+		}
+		return entry{}, false
+	}
+	if ref.Path[0] == "" {
+		return d.find(at.doc, ref.Path[1:])
+	}
+	scope := at.path
+	if at.node != nil && len(scope) > 0 {
+		scope = scope[:len(scope)-1]
+	}
+	for i := len(scope); i >= 0; i-- {
+		if m, ok := d.find(at.doc, scope[:i]); ok {
+			if v, ok := m.node.Value().(map[string]yaml.Node); ok {
+				if _, ok := v[ref.Path[0]]; ok {
+					return d.find(at.doc, append(scope[:i:i], ref.Path...))
+				}
+			}
+		}
+	}
+	return entry{}, false
+}
+
+// find looks up an indexed node by its path.
+func (d *Document) find(doc int, path []string) (entry, bool) {
+	if doc >= len(d.docs) {
+		return entry{}, false
+	}
+	node := d.docs[doc]
+	for _, c := range path {
+		if node == nil {
+			return entry{}, false
+		}
+		switch v := node.Value().(type) {
+		case map[string]yaml.Node:
+			n, ok := v[c]
+			if !ok {
+				return entry{}, false
+			}
+			node = n
+		case []yaml.Node:
+			i, ok := listIndex(c)
+			if !ok || i >= len(v) {
+				return entry{}, false
+			}
+			node = v[i]
+		default:
+			return entry{}, false
+		}
+	}
+	return entry{doc, path, node}, node != nil
+}
+
+func listIndex(c string) (int, bool) {
+	if !strings.HasPrefix(c, "[") || !strings.HasSuffix(c, "]") {
+		return 0, false
+	}
+	i, err := strconv.Atoi(c[1 : len(c)-1])
+	return i, err == nil && i >= 0
+}
+
+// tags determines the nodes tagged with the given tag by a tag marker.
+// A marker used for the merge key tags the enclosing map.
+func (d *Document) tags(name string) []entry {
+	var result []entry
+	for _, e := range d.index {
+		sub := yaml.EmbeddedDynaml(e.node, false)
+		if sub == nil {
+			continue
+		}
+		expr, err := dynaml.Parse(*sub, nil, nil)
+		if err != nil {
+			continue
+		}
+		if m, ok := expr.(dynaml.MarkerExpr); ok && m.GetTag() == name {
+			if len(e.path) > 0 && e.path[len(e.path)-1] == "<<" {
+				if t, ok := d.find(e.doc, e.path[:len(e.path)-1]); ok {
+					result = append(result, t)
+				}
+				continue
+			}
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// Definition provides the location of the node addressed by the reference
+// found at a position.
+func (d *Document) Definition(pos Position) *Location {
+	at, ok := d.nodeAt(pos)
+	if !ok {
+		return nil
+	}
+	ref, ok := d.reference(pos)
+	if !ok {
+		return nil
+	}
+	target, ok := d.resolve(at, ref)
+	if !ok || target.node == nil {
+		return nil
+	}
+	p := toPosition(target.node.SourcePosition())
+	return &Location{URI: d.URI, Range: Range{p, p}}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// hover
+
+// Hover shows the value of the last merge for the node addressed by
+// a reference at the given position or for the node at its line.
+func (d *Document) Hover(pos Position) *Hover {
+	at, ok := d.nodeAt(pos)
+	if !ok || d.merged == nil {
+		return nil
+	}
+	target := at
+	var rng *Range
+	if ref, ok := d.reference(pos); ok {
+		if t, ok := d.resolve(at, ref); ok {
+			target = t
+			_, r := d.wordAt(pos)
+			rng = &r
+		}
+	}
+	if target.doc >= len(d.merged) {
+		return nil
+	}
+	value, ok := lookup(d.merged[target.doc], target.path)
+	if !ok {
+		return nil
+	}
+	data, err := candiedyaml.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	text := fmt.Sprintf("`%s`\n```yaml\n%s```", strings.Join(target.path, "."), data)
+	return &Hover{Contents: MarkupContent{Kind: "markdown", Value: text}, Range: rng}
+}
+
+func lookup(node yaml.Node, path []string) (yaml.Node, bool) {
+	for _, c := range path {
+		if node == nil {
+			return nil, false
+		}
+		switch v := node.Value().(type) {
+		case map[string]yaml.Node:
+			n, ok := v[c]
+			if !ok {
+				return nil, false
+			}
+			node = n
+		case []yaml.Node:
+			i, ok := listIndex(c)
+			if !ok || i >= len(v) {
+				return nil, false
+			}
+			node = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return node, true
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// completion
+
+// Completion provides the completion candidates at a position inside
+// a dynaml expression: function names and the fields of the enclosing
+// maps or of the map addressed by a partial path.
+func (d *Document) Completion(pos Position) []CompletionItem {
+	result := []CompletionItem{}
+	if !d.inExpression(pos) {
+		return result
+	}
+	line := d.line(pos.Line)
+	start := pos.Character
+	if start > len(line) {
+		start = len(line)
+	}
+	for start > 0 && isWordChar(line[start-1]) {
+		start--
+	}
+	prefix := line[start:min(pos.Character, len(line))]
+	at, _ := d.nodeAt(pos)
+
+	if i := strings.LastIndex(prefix, "."); i >= 0 {
+		ref, err := dynaml.Parse(prefix[:i], nil, nil)
+		if r, ok := ref.(dynaml.ReferenceExpr); err == nil && ok {
+			target, ok := d.resolve(at, r)
+			if ok {
+				result = appendFields(result, target.node, prefix[i+1:])
+			}
+		} else if prefix[:i] == "" {
+			if at.doc < len(d.docs) {
+				result = appendFields(result, d.docs[at.doc], prefix[i+1:])
+			}
+		}
+		return result
+	}
+
+	for _, f := range dynaml.FunctionNames() {
+		if strings.HasPrefix(f, prefix) {
+			result = append(result, CompletionItem{Label: f, Kind: COMPLETION_FUNCTION, Detail: functionDetail(f)})
+		}
+	}
+	found := map[string]bool{}
+	scope := at.path
+	for i := len(scope); i >= 0; i-- {
+		if m, ok := d.find(at.doc, scope[:i]); ok {
+			if v, ok := m.node.Value().(map[string]yaml.Node); ok {
+				for _, k := range yaml.GetSortedKeys(v) {
+					if !found[k] && strings.HasPrefix(k, prefix) {
+						found[k] = true
+						result = append(result, CompletionItem{Label: k, Kind: COMPLETION_FIELD, Detail: strings.Join(append(scope[:i:i], k), ".")})
+					}
+				}
+			}
+		}
+	}
+	return result
+}
+
+func appendFields(result []CompletionItem, node yaml.Node, prefix string) []CompletionItem {
+	if node == nil {
+		return result
+	}
+	if m, ok := node.Value().(map[string]yaml.Node); ok {
+		for _, k := range yaml.GetSortedKeys(m) {
+			if strings.HasPrefix(k, prefix) {
+				result = append(result, CompletionItem{Label: k, Kind: COMPLETION_FIELD})
+			}
+		}
+	}
+	return result
+}
+
+func functionDetail(name string) string {
+	min, max, ok := dynaml.BuiltinArity(name)
+	switch {
+	case !ok:
+		return "function"
+	case max < 0:
+		return fmt.Sprintf("builtin function (%d or more arguments)", min)
+	case min == max:
+		return fmt.Sprintf("builtin function (%d arguments)", min)
+	default:
+		return fmt.Sprintf("builtin function (%d to %d arguments)", min, max)
+	}
+}
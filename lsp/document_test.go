@@ -0,0 +1,147 @@
+package lsp
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/spiff/compile"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+const source = `---
+meta:
+  name: alice
+  size: 2
+value: (( meta.size * 3 ))
+nested:
+  ref: (( name ))
+  name: bob
+tagged:
+  <<: (( &tag:data ))
+  info: tagged
+fromtag: (( data::info ))
+`
+
+var _ = Describe("Document", func() {
+	var doc *Document
+
+	BeforeEach(func() {
+		doc = NewDocument("file:///test.yml", source)
+	})
+
+	Context("diagnostics", func() {
+		It("reports nothing for valid documents", func() {
+			Expect(doc.Diagnostics(nil, compile.LintOptions{})).To(BeEmpty())
+		})
+
+		It("reports dynaml errors", func() {
+			doc = NewDocument("file:///test.yml", "a: (( blub( ))\nb: (( foo(1) ))\n")
+			Expect(doc.Diagnostics(nil, compile.LintOptions{})).To(Equal([]Diagnostic{
+				{Range{Position{0, 3}, Position{0, 14}}, SEVERITY_ERROR, "spiff", "a: parse error near symbol 7 - symbol 8: ' '"},
+				{Range{Position{1, 3}, Position{1, 15}}, SEVERITY_ERROR, "spiff", "b: unknown function 'foo'"},
+			}))
+		})
+
+		It("reports yaml errors", func() {
+			doc = NewDocument("file:///test.yml", "a: 1\n b: [\n")
+			diags := doc.Diagnostics(nil, compile.LintOptions{})
+			Expect(len(diags)).To(Equal(1))
+			Expect(diags[0].Range.Start).To(Equal(Position{1, 2}))
+		})
+	})
+
+	Context("definition", func() {
+		It("resolves absolute references", func() {
+			Expect(doc.Definition(Position{4, 14})).To(Equal(&Location{"file:///test.yml", Range{Position{3, 8}, Position{3, 8}}}))
+		})
+
+		It("resolves references in enclosing maps", func() {
+			Expect(doc.Definition(Position{6, 11})).To(Equal(&Location{"file:///test.yml", Range{Position{7, 8}, Position{7, 8}}}))
+		})
+
+		It("resolves tagged references", func() {
+			Expect(doc.Definition(Position{11, 18})).To(Equal(&Location{"file:///test.yml", Range{Position{10, 8}, Position{10, 8}}}))
+		})
+
+		It("ignores positions outside of expressions", func() {
+			Expect(doc.Definition(Position{2, 9})).To(BeNil())
+		})
+	})
+
+	Context("hover", func() {
+		BeforeEach(func() {
+			Expect(doc.Merge(nil)).To(Succeed())
+		})
+
+		It("shows the merged value of a line", func() {
+			h := doc.Hover(Position{4, 2})
+			Expect(h).NotTo(BeNil())
+			Expect(h.Contents.Value).To(Equal("`value`\n```yaml\n6\n```"))
+		})
+
+		It("shows the merged value of a referenced node", func() {
+			h := doc.Hover(Position{4, 10})
+			Expect(h).NotTo(BeNil())
+			Expect(h.Contents.Value).To(Equal("`meta.size`\n```yaml\n2\n```"))
+			Expect(h.Range).To(Equal(&Range{Position{4, 10}, Position{4, 19}}))
+		})
+
+		It("shows the value of a key with nested fields", func() {
+			h := doc.Hover(Position{1, 1})
+			Expect(h).NotTo(BeNil())
+			Expect(h.Contents.Value).To(Equal("`meta`\n```yaml\nname: alice\nsize: 2\n```"))
+		})
+
+		It("does not access files or execute commands", func() {
+			dir, err := ioutil.TempDir("", "lsp")
+			Expect(err).To(Succeed())
+			defer os.RemoveAll(dir)
+			file := filepath.Join(dir, "out")
+			doc := NewDocument("file:///side.yml", fmt.Sprintf("written: (( write(%q, \"data\") ))\nexec: (( exec(\"touch\", %q) ))\n", file, file+".exec"))
+			doc.Merge(nil)
+			Expect(file).NotTo(BeAnExistingFile())
+			Expect(file + ".exec").NotTo(BeAnExistingFile())
+		})
+
+		It("uses the stubs for the merge", func() {
+			stub, err := yaml.Parse("stub", []byte("meta:\n  size: 3\n"))
+			Expect(err).To(Succeed())
+			Expect(doc.Merge([]yaml.Node{stub})).To(Succeed())
+			Expect(doc.Hover(Position{4, 2}).Contents.Value).To(Equal("`value`\n```yaml\n9\n```"))
+		})
+	})
+
+	Context("completion", func() {
+		labels := func(items []CompletionItem) []string {
+			result := []string{}
+			for _, i := range items {
+				result = append(result, i.Label)
+			}
+			return result
+		}
+
+		It("completes function names and fields", func() {
+			doc = NewDocument("file:///test.yml", "meta:\n  lowest: 1\nlower: 1\nvalue: (( low")
+			Expect(labels(doc.Completion(Position{3, 14}))).To(Equal([]string{"lower", "lower"}))
+			Expect(doc.Completion(Position{3, 14})[0].Kind).To(Equal(COMPLETION_FUNCTION))
+		})
+
+		It("completes fields of paths", func() {
+			doc = NewDocument("file:///test.yml", "meta:\n  name: alice\n  size: 2\nvalue: (( meta.s")
+			Expect(labels(doc.Completion(Position{3, 16}))).To(Equal([]string{"size"}))
+		})
+
+		It("completes fields of enclosing maps", func() {
+			Expect(labels(doc.Completion(Position{6, 10}))).To(ContainElements("name", "nested", "meta"))
+		})
+
+		It("completes nothing outside of expressions", func() {
+			Expect(doc.Completion(Position{2, 9})).To(BeEmpty())
+		})
+	})
+})
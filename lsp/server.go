@@ -0,0 +1,200 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/mandelsoft/spiff/compile"
+	"github.com/mandelsoft/spiff/debug"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+// Options configures the language server.
+type Options struct {
+	// Stubs are the stub files used for checks and merges.
+	Stubs []string
+	// Lint configures the static checks used for diagnostics.
+	Lint compile.LintOptions
+}
+
+// Server is a language server for dynaml in yaml documents.
+type Server struct {
+	opts      Options
+	documents map[string]*Document
+	out       io.Writer
+}
+
+// NewServer creates a language server.
+func NewServer(opts Options) *Server {
+	return &Server{opts: opts, documents: map[string]*Document{}}
+}
+
+// Serve handles the messages read from the given stream until the
+// exit notification is received or the stream is closed.
+func (s *Server) Serve(in io.Reader, out io.Writer) error {
+	s.out = out
+	r := bufio.NewReader(in)
+	for {
+		msg, err := ReadMessage(r)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if msg.Method == "exit" {
+			return nil
+		}
+		result, rerr := s.handle(msg)
+		if msg.ID == nil {
+			continue
+		}
+		response := &Message{ID: msg.ID, Result: result, Error: rerr}
+		if rerr == nil && result == nil {
+			response.Result = json.RawMessage("null")
+		}
+		if err := WriteMessage(out, response); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(msg *Message) (interface{}, *ResponseError) {
+	debug.Debug("lsp: %s\n", msg.Method)
+	switch msg.Method {
+	case "initialize":
+		return map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync": map[string]interface{}{
+					"openClose": true,
+					"change":    1, // full document
+					"save":      map[string]interface{}{"includeText": true},
+				},
+				"completionProvider": map[string]interface{}{
+					"triggerCharacters": []string{".", "("},
+				},
+				"hoverProvider":      true,
+				"definitionProvider": true,
+			},
+			"serverInfo": map[string]interface{}{"name": "spiff"},
+		}, nil
+	case "shutdown":
+		return nil, nil
+
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		s.update(params.TextDocument.URI, params.TextDocument.Text)
+	case "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		if n := len(params.ContentChanges); n > 0 {
+			s.update(params.TextDocument.URI, params.ContentChanges[n-1].Text)
+		}
+	case "textDocument/didSave":
+		var params DidSaveTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		if params.Text != nil {
+			s.update(params.TextDocument.URI, *params.Text)
+		} else if d := s.documents[params.TextDocument.URI]; d != nil {
+			s.update(d.URI, d.Text)
+		}
+	case "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		delete(s.documents, params.TextDocument.URI)
+		s.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{URI: params.TextDocument.URI, Diagnostics: []Diagnostic{}})
+
+	case "textDocument/completion":
+		d, pos, err := s.position(msg)
+		if err != nil || d == nil {
+			return nil, err
+		}
+		return CompletionList{Items: d.Completion(pos)}, nil
+	case "textDocument/hover":
+		d, pos, err := s.position(msg)
+		if err != nil || d == nil {
+			return nil, err
+		}
+		if h := d.Hover(pos); h != nil {
+			return h, nil
+		}
+	case "textDocument/definition":
+		d, pos, err := s.position(msg)
+		if err != nil || d == nil {
+			return nil, err
+		}
+		if l := d.Definition(pos); l != nil {
+			return l, nil
+		}
+	default:
+		if msg.ID != nil {
+			return nil, &ResponseError{Code: CODE_METHOD_NOT_FOUND, Message: fmt.Sprintf("method %q not supported", msg.Method)}
+		}
+	}
+	return nil, nil
+}
+
+func invalidParams(err error) *ResponseError {
+	return &ResponseError{Code: CODE_INVALID_PARAMS, Message: err.Error()}
+}
+
+func (s *Server) position(msg *Message) (*Document, Position, *ResponseError) {
+	var params TextDocumentPositionParams
+	if err := json.Unmarshal(msg.Params, &params); err != nil {
+		return nil, Position{}, invalidParams(err)
+	}
+	return s.documents[params.TextDocument.URI], params.Position, nil
+}
+
+// update analyses a changed document and publishes its diagnostics.
+func (s *Server) update(uri string, text string) {
+	d := NewDocument(uri, text)
+	s.documents[uri] = d
+	s.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+		URI:         uri,
+		Diagnostics: d.Diagnostics(s.stubs(), s.opts.Lint),
+	})
+	if err := d.Merge(s.stubs()); err != nil {
+		debug.Debug("lsp: merge %s: %s\n", uri, err)
+	}
+}
+
+// stubs parses the configured stub files. They are read for every
+// use, because processing modifies the parsed documents.
+func (s *Server) stubs() []yaml.Node {
+	var stubs []yaml.Node
+	for _, file := range s.opts.Stubs {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			debug.Debug("lsp: cannot read stub %s: %s\n", file, err)
+			continue
+		}
+		stub, err := yaml.Parse(file, data)
+		if err != nil {
+			debug.Debug("lsp: cannot parse stub %s: %s\n", file, err)
+			continue
+		}
+		stubs = append(stubs, stub)
+	}
+	return stubs
+}
+
+func (s *Server) notify(method string, params interface{}) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return
+	}
+	WriteMessage(s.out, &Message{Method: method, Params: data})
+}
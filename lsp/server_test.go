@@ -0,0 +1,94 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func request(buf *bytes.Buffer, id int, method string, params interface{}) {
+	data, err := json.Marshal(params)
+	Expect(err).To(Succeed())
+	msg := &Message{Method: method, Params: data}
+	if id > 0 {
+		raw := json.RawMessage(fmt.Sprintf("%d", id))
+		msg.ID = &raw
+	}
+	Expect(WriteMessage(buf, msg)).To(Succeed())
+}
+
+func responses(out *bytes.Buffer) []map[string]interface{} {
+	result := []map[string]interface{}{}
+	r := bufio.NewReader(out)
+	for {
+		data, err := readContent(r)
+		if err != nil {
+			return result
+		}
+		m := map[string]interface{}{}
+		Expect(json.Unmarshal(data, &m)).To(Succeed())
+		result = append(result, m)
+	}
+}
+
+var _ = Describe("Server", func() {
+	It("handles a session", func() {
+		in := &bytes.Buffer{}
+		out := &bytes.Buffer{}
+		uri := "file:///test.yml"
+		request(in, 1, "initialize", map[string]interface{}{})
+		request(in, 0, "initialized", map[string]interface{}{})
+		request(in, 0, "textDocument/didOpen", map[string]interface{}{
+			"textDocument": map[string]interface{}{"uri": uri, "text": "a: 1\nb: (( a + foo(1) ))\n"},
+		})
+		request(in, 2, "textDocument/definition", map[string]interface{}{
+			"textDocument": map[string]interface{}{"uri": uri},
+			"position":     map[string]interface{}{"line": 1, "character": 6},
+		})
+		request(in, 3, "textDocument/unknown", map[string]interface{}{})
+		request(in, 4, "shutdown", nil)
+		request(in, 0, "exit", nil)
+
+		Expect(NewServer(Options{}).Serve(in, out)).To(Succeed())
+		msgs := responses(out)
+		Expect(len(msgs)).To(Equal(5))
+
+		Expect(msgs[0]["id"]).To(Equal(1.0))
+		Expect(msgs[0]["result"]).To(HaveKey("capabilities"))
+
+		Expect(msgs[1]["method"]).To(Equal("textDocument/publishDiagnostics"))
+		Expect(msgs[1]["params"]).To(Equal(map[string]interface{}{
+			"uri": uri,
+			"diagnostics": []interface{}{
+				map[string]interface{}{
+					"range": map[string]interface{}{
+						"start": map[string]interface{}{"line": 1.0, "character": 3.0},
+						"end":   map[string]interface{}{"line": 1.0, "character": 19.0},
+					},
+					"severity": 1.0,
+					"source":   "spiff",
+					"message":  "b: unknown function 'foo'",
+				},
+			},
+		}))
+
+		Expect(msgs[2]["id"]).To(Equal(2.0))
+		Expect(msgs[2]["result"]).To(Equal(map[string]interface{}{
+			"uri": uri,
+			"range": map[string]interface{}{
+				"start": map[string]interface{}{"line": 0.0, "character": 3.0},
+				"end":   map[string]interface{}{"line": 0.0, "character": 3.0},
+			},
+		}))
+
+		Expect(msgs[3]["id"]).To(Equal(3.0))
+		Expect(msgs[3]["error"]).To(HaveKeyWithValue("code", float64(CODE_METHOD_NOT_FOUND)))
+
+		Expect(msgs[4]["id"]).To(Equal(4.0))
+		Expect(msgs[4]).To(HaveKeyWithValue("result", BeNil()))
+	})
+})
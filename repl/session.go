@@ -0,0 +1,383 @@
+// Package repl provides an interactive session evaluating dynaml
+// expressions against a merged document.
+//
+// The template is processed with its stubs once. Afterwards expressions
+// are parsed and evaluated in the context of the merged document, or a
+// node of it selected by a path, without processing the template again.
+package repl
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mandelsoft/spiff/dynaml"
+	"github.com/mandelsoft/spiff/features"
+	"github.com/mandelsoft/spiff/flow"
+	"github.com/mandelsoft/spiff/legacy/candiedyaml"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+// Options configures a session.
+type Options struct {
+	// Stubs are the stub files used for the merge.
+	Stubs []string
+	// Tags are additional global tags.
+	Tags []*dynaml.Tag
+	// Bindings are additional bindings for the merge.
+	Bindings map[string]yaml.Node
+	// Features are the feature flags used for the merge.
+	Features features.FeatureFlags
+	// Partial allows a partial evaluation of the template.
+	Partial bool
+	// History is the file used to persist the input history.
+	History string
+	// ReadFile is used to read the template and stub files.
+	// It defaults to ioutil.ReadFile.
+	ReadFile func(file string) ([]byte, error)
+}
+
+// Session keeps the merged document and the state of an
+// interactive evaluation.
+type Session struct {
+	opts     Options
+	template string
+	stubs    []string
+	state    *flow.State
+	binding  dynaml.Binding
+	merged   yaml.Node
+	path     []string
+	history  []string
+}
+
+// NewSession merges the given template with the stubs of the options.
+func NewSession(template string, opts Options) (*Session, error) {
+	if opts.ReadFile == nil {
+		opts.ReadFile = ioutil.ReadFile
+	}
+	if opts.Features == nil {
+		opts.Features = features.Features()
+	}
+	s := &Session{opts: opts, template: template, stubs: append([]string{}, opts.Stubs...)}
+	if err := s.merge(s.stubs); err != nil {
+		return nil, err
+	}
+	s.loadHistory()
+	return s, nil
+}
+
+// merge processes the template with the given stubs. The files are
+// read and parsed again for every merge, because processing modifies
+// the parsed documents.
+func (s *Session) merge(stubFiles []string) error {
+	template, err := s.read(s.template, "template")
+	if err != nil {
+		return err
+	}
+	stubs := []yaml.Node{}
+	for _, file := range stubFiles {
+		stub, err := s.read(file, "stub")
+		if err != nil {
+			return err
+		}
+		stubs = append(stubs, stub)
+	}
+
+	state := flow.NewDefaultState().SetFeatures(s.opts.Features).SetTags(s.opts.Tags...)
+	binding := flow.NewEnvironment(nil, "context", state)
+	if s.opts.Bindings != nil {
+		binding = binding.WithLocalScope(s.opts.Bindings)
+	}
+	merged, err := flow.Cascade(binding, template, flow.Options{Partial: s.opts.Partial}, stubs...)
+	if err != nil {
+		if !s.opts.Partial {
+			flow.CleanupEnvironment(binding)
+			return fmt.Errorf("error generating manifest: %s", err)
+		}
+		merged = dynaml.ResetUnresolvedNodes(merged)
+	}
+	if _, ok := merged.Value().(map[string]yaml.Node); !ok {
+		flow.CleanupEnvironment(binding)
+		return fmt.Errorf("no map document")
+	}
+	if s.binding != nil {
+		flow.CleanupEnvironment(s.binding)
+	}
+	s.state = state
+	s.binding = binding
+	s.merged = merged
+	if _, ok := s.Node(); !ok {
+		s.path = nil
+	}
+	return nil
+}
+
+func (s *Session) read(file, desc string) (yaml.Node, error) {
+	data, err := s.opts.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s [%s]: %s", desc, file, err)
+	}
+	node, err := yaml.Parse(file, data)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s [%s]: %s", desc, file, err)
+	}
+	return node, nil
+}
+
+// Close releases the resources of the session.
+func (s *Session) Close() {
+	flow.CleanupEnvironment(s.binding)
+}
+
+// Load merges the template again with the given additional stubs.
+// They take precedence over the stubs used so far.
+func (s *Session) Load(files ...string) error {
+	stubs := append(append([]string{}, s.stubs...), files...)
+	if err := s.merge(stubs); err != nil {
+		return err
+	}
+	s.stubs = stubs
+	return nil
+}
+
+// Merged provides the merged document.
+func (s *Session) Merged() yaml.Node {
+	return s.merged
+}
+
+// Path provides the path of the current node.
+func (s *Session) Path() []string {
+	return s.path
+}
+
+// Node provides the current node.
+func (s *Session) Node() (yaml.Node, bool) {
+	return yaml.FindR(true, s.merged, s.opts.Features, s.path...)
+}
+
+// SetPath selects the current node used as context for evaluations.
+// Paths are relative to the current node, unless they start with a
+// slash. Steps are separated by slashes, and the step .. selects the
+// parent node (for example ../other.field).
+func (s *Session) SetPath(path string) error {
+	var comps []string
+	path = strings.TrimSpace(path)
+	if !strings.HasPrefix(path, "/") {
+		comps = append(comps, s.path...)
+	}
+	for _, step := range strings.Split(strings.Trim(path, "/"), "/") {
+		switch step {
+		case "", ".":
+		case "..":
+			if len(comps) > 0 {
+				comps = comps[:len(comps)-1]
+			}
+		default:
+			comps = append(comps, dynaml.PathComponents(step, false)...)
+		}
+	}
+	if _, ok := yaml.FindR(true, s.merged, s.opts.Features, comps...); !ok {
+		return fmt.Errorf("path %q not found", strings.Join(comps, "."))
+	}
+	s.path = comps
+	return nil
+}
+
+// Tags provides the tags known after the merge.
+func (s *Session) Tags() []*dynaml.Tag {
+	return s.state.Tags()
+}
+
+// Evaluate evaluates a dynaml expression in the context of the
+// current node. The expression may be given with or without the
+// enclosing brackets.
+func (s *Session) Evaluate(expr string) (yaml.Node, error) {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "((") && strings.HasSuffix(expr, "))") {
+		expr = strings.TrimSpace(expr[2 : len(expr)-2])
+	}
+	if expr == "" {
+		return nil, fmt.Errorf("expression required")
+	}
+	e, err := dynaml.Parse(expr, s.path, s.path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %q: %s", expr, err)
+	}
+	binding := flow.NewNestedEnvironment(nil, "context", s.binding)
+	node := s.merged
+	if m, ok := node.Value().(map[string]yaml.Node); ok {
+		binding = binding.WithLocalScope(m)
+	}
+	for _, c := range s.path {
+		node, _ = yaml.FindR(true, node, s.opts.Features, c)
+		if m, ok := node.Value().(map[string]yaml.Node); ok {
+			binding = binding.WithLocalScope(m)
+		}
+	}
+	return flow.Cascade(binding, yaml.NewNode(e, "<repl>"), flow.Options{})
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// interactive processing
+
+const help = `Enter dynaml expressions (with or without (( ))) or commands:
+  :path [<path>]      show or select the current node (/ for root, .. for parent)
+  :tags               list the known tags
+  :load <stub> ...    merge again with additional stubs
+  :history            show the input history
+  !!, !<n>            repeat the last or the n-th input
+  :help               show this help
+  :quit               leave the session
+`
+
+// Prompt provides the prompt for the current node.
+func (s *Session) Prompt() string {
+	if len(s.path) == 0 {
+		return "spiff> "
+	}
+	return fmt.Sprintf("spiff %s> ", strings.Join(s.path, "."))
+}
+
+// Run reads inputs from the given stream until it is closed or
+// the session is left and writes the results to out.
+func (s *Session) Run(in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(out, s.Prompt())
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			return scanner.Err()
+		}
+		if s.Execute(scanner.Text(), out) {
+			return nil
+		}
+	}
+}
+
+// Execute handles a single input line. It returns true if the
+// session should be left.
+func (s *Session) Execute(line string, out io.Writer) bool {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return false
+	}
+	if strings.HasPrefix(line, "!") {
+		recalled, err := s.recall(line)
+		if err != nil {
+			fmt.Fprintf(out, "error: %s\n", err)
+			return false
+		}
+		fmt.Fprintln(out, recalled)
+		line = recalled
+	}
+	s.addHistory(line)
+
+	if !strings.HasPrefix(line, ":") {
+		result, err := s.Evaluate(line)
+		if err != nil {
+			fmt.Fprintf(out, "error: %s\n", err)
+			return false
+		}
+		data, err := candiedyaml.Marshal(result)
+		if err != nil {
+			fmt.Fprintf(out, "error: %s\n", err)
+			return false
+		}
+		fmt.Fprint(out, string(data))
+		return false
+	}
+
+	fields := strings.Fields(line)
+	var err error
+	switch fields[0] {
+	case ":quit", ":exit", ":q":
+		return true
+	case ":help", ":h":
+		fmt.Fprint(out, help)
+	case ":path":
+		if len(fields) > 1 {
+			err = s.SetPath(strings.Join(fields[1:], ""))
+		}
+		if err == nil {
+			fmt.Fprintf(out, "/%s\n", strings.Join(s.path, "."))
+		}
+	case ":tags":
+		for _, t := range s.Tags() {
+			if len(t.Path()) > 0 {
+				fmt.Fprintf(out, "%s: %s\n", t.Name(), strings.Join(t.Path(), "."))
+			} else {
+				fmt.Fprintf(out, "%s\n", t.Name())
+			}
+		}
+	case ":load":
+		if len(fields) < 2 {
+			err = fmt.Errorf("stub file required")
+		} else {
+			err = s.Load(fields[1:]...)
+		}
+	case ":history":
+		for i, h := range s.history {
+			fmt.Fprintf(out, "%4d  %s\n", i+1, h)
+		}
+	default:
+		err = fmt.Errorf("unknown command %q (see :help)", fields[0])
+	}
+	if err != nil {
+		fmt.Fprintf(out, "error: %s\n", err)
+	}
+	return false
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// history
+
+// History provides the inputs of the session.
+func (s *Session) History() []string {
+	return s.history
+}
+
+func (s *Session) recall(line string) (string, error) {
+	if len(s.history) == 0 {
+		return "", fmt.Errorf("history is empty")
+	}
+	if line == "!!" {
+		return s.history[len(s.history)-1], nil
+	}
+	n, err := strconv.Atoi(line[1:])
+	if err != nil || n < 1 || n > len(s.history) {
+		return "", fmt.Errorf("invalid history reference %q", line)
+	}
+	return s.history[n-1], nil
+}
+
+func (s *Session) loadHistory() {
+	if s.opts.History == "" {
+		return
+	}
+	data, err := ioutil.ReadFile(s.opts.History)
+	if err != nil {
+		return
+	}
+	for _, l := range strings.Split(string(data), "\n") {
+		if l != "" {
+			s.history = append(s.history, l)
+		}
+	}
+}
+
+func (s *Session) addHistory(line string) {
+	s.history = append(s.history, line)
+	if s.opts.History == "" {
+		return
+	}
+	f, err := os.OpenFile(s.opts.History, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	fmt.Fprintln(f, line)
+}
@@ -0,0 +1,159 @@
+package repl
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Session", func() {
+	var dir string
+
+	write := func(name, content string) string {
+		file := filepath.Join(dir, name)
+		Expect(ioutil.WriteFile(file, []byte(content), 0644)).To(Succeed())
+		return file
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "repl")
+		Expect(err).To(Succeed())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	newSession := func(opts Options) *Session {
+		template := write("template.yml", `
+name: (( "alice" ))
+size: 2
+nested:
+  <<: (( &tag:nested ))
+  value: (( size * 2 ))
+  list:
+    - a
+    - b
+`)
+		s, err := NewSession(template, opts)
+		Expect(err).To(Succeed())
+		return s
+	}
+
+	It("evaluates expressions against the merged document", func() {
+		s := newSession(Options{Stubs: []string{write("stub.yml", "size: 3\n")}})
+		defer s.Close()
+
+		r, err := s.Evaluate("nested.value + 1")
+		Expect(err).To(Succeed())
+		Expect(r.Value()).To(Equal(int64(7)))
+
+		r, err = s.Evaluate(`(( name "-" size ))`)
+		Expect(err).To(Succeed())
+		Expect(r.Value()).To(Equal("alice-3"))
+
+		_, err = s.Evaluate("unknown")
+		Expect(err).To(HaveOccurred())
+		_, err = s.Evaluate("1 +")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("navigates paths", func() {
+		s := newSession(Options{})
+		defer s.Close()
+
+		Expect(s.SetPath("nested")).To(Succeed())
+		Expect(s.Path()).To(Equal([]string{"nested"}))
+		Expect(s.Prompt()).To(Equal("spiff nested> "))
+
+		r, err := s.Evaluate("list.[1]")
+		Expect(err).To(Succeed())
+		Expect(r.Value()).To(Equal("b"))
+		r, err = s.Evaluate("name")
+		Expect(err).To(Succeed())
+		Expect(r.Value()).To(Equal("alice"))
+
+		Expect(s.SetPath("list.[0]")).To(Succeed())
+		Expect(s.Path()).To(Equal([]string{"nested", "list", "[0]"}))
+		Expect(s.SetPath("../..")).To(Succeed())
+		Expect(s.Path()).To(Equal([]string{"nested"}))
+		Expect(s.SetPath("/size")).To(Succeed())
+		Expect(s.Path()).To(Equal([]string{"size"}))
+		Expect(s.SetPath("/missing").Error()).To(Equal(`path "missing" not found`))
+		Expect(s.Path()).To(Equal([]string{"size"}))
+		Expect(s.SetPath("/")).To(Succeed())
+		Expect(s.Path()).To(BeEmpty())
+	})
+
+	It("loads additional stubs", func() {
+		s := newSession(Options{Stubs: []string{write("stub.yml", "size: 3\nname: bob\n")}})
+		defer s.Close()
+
+		Expect(s.Load(write("other.yml", "size: 5\n"))).To(Succeed())
+		r, err := s.Evaluate("nested.value")
+		Expect(err).To(Succeed())
+		Expect(r.Value()).To(Equal(int64(10)))
+		r, err = s.Evaluate("name")
+		Expect(err).To(Succeed())
+		Expect(r.Value()).To(Equal("bob"))
+
+		Expect(s.Load(filepath.Join(dir, "missing.yml"))).To(HaveOccurred())
+	})
+
+	It("runs an interactive session", func() {
+		history := filepath.Join(dir, "history")
+		s := newSession(Options{History: history})
+		defer s.Close()
+
+		in := strings.NewReader(strings.Join([]string{
+			"nested.list",
+			":path nested",
+			"value",
+			"!3",
+			":tags",
+			":load " + write("stub.yml", "size: 4\n"),
+			"!4",
+			":history",
+			":unknown",
+			":quit",
+			"size",
+		}, "\n"))
+		out := &bytes.Buffer{}
+		Expect(s.Run(in, out)).To(Succeed())
+		Expect(out.String()).To(Equal(fmt.Sprintf(`spiff> - a
+- b
+spiff> /nested
+spiff nested> 4
+spiff nested> value
+4
+spiff nested> doc.1
+nested: nested
+spiff nested> spiff nested> value
+8
+spiff nested>    1  nested.list
+   2  :path nested
+   3  value
+   4  value
+   5  :tags
+   6  :load %s
+   7  value
+   8  :history
+spiff nested> error: unknown command ":unknown" (see :help)
+spiff nested> `, filepath.Join(dir, "stub.yml"))))
+
+		data, err := ioutil.ReadFile(history)
+		Expect(err).To(Succeed())
+		Expect(strings.Split(string(data), "\n")).To(HaveLen(11))
+
+		s2 := newSession(Options{History: history})
+		defer s2.Close()
+		Expect(s2.History()).To(HaveLen(10))
+	})
+})
@@ -4,6 +4,8 @@ import (
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -144,5 +146,312 @@ foo: (( values ))
 			})
 		})
 
+		Context("when reporting warnings", func() {
+			var dir string
+
+			BeforeEach(func() {
+				var err error
+				dir, err = ioutil.TempDir(os.TempDir(), "warnings")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ioutil.WriteFile(filepath.Join(dir, "template.yml"), []byte("value: (( warn(\"value is outdated\", 1) ))\n"), 0644)).To(Succeed())
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(dir)
+			})
+
+			It("prints the warnings to stderr", func() {
+				var err error
+				cmd := exec.Command(spiff, "merge", "template.yml")
+				cmd.Dir = dir
+				merge, err = Start(cmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(merge.Out).To(Say("value: 1\n"))
+				Expect(merge.Err).To(Say("warning: value: value is outdated\n"))
+			})
+
+			It("fails for warnings as errors", func() {
+				var err error
+				cmd := exec.Command(spiff, "merge", "--warnings-as-errors", "template.yml")
+				cmd.Dir = dir
+				merge, err = Start(cmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(merge.Wait()).To(Exit(1))
+				Expect(merge.Out).NotTo(Say("value"))
+				Expect(merge.Err).To(Say("warning: value: value is outdated\n"))
+				Expect(merge.Err).To(Say("1 warning\\(s\\) found, treated as errors"))
+			})
+		})
+
+		Context("when using a policy", func() {
+			var dir string
+
+			BeforeEach(func() {
+				var err error
+				dir, err = ioutil.TempDir(os.TempDir(), "policy")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ioutil.WriteFile(filepath.Join(dir, "template.yml"), []byte("value: (( merge ))\n"), 0644)).To(Succeed())
+				Expect(ioutil.WriteFile(filepath.Join(dir, "policy.yml"), []byte("hosts: []\n"), 0644)).To(Succeed())
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(dir)
+			})
+
+			It("applies the policy to input files", func() {
+				var err error
+				cmd := exec.Command(spiff, "merge", "--policy", "policy.yml", "template.yml", "http://localhost:1/stub.yml")
+				cmd.Dir = dir
+				merge, err = Start(cmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(merge.Wait()).To(Exit(1))
+				Expect(merge.Err).To(Say(`error reading stub \[http:/localhost:1/stub.yml\]: .*policy violation: access to URL "http://localhost:1/stub.yml" not allowed`))
+			})
+		})
+
+		Context("when violating template parameters", func() {
+			var dir string
+
+			run := func(args ...string) *Session {
+				cmd := exec.Command(spiff, append([]string{"merge"}, args...)...)
+				cmd.Dir = dir
+				session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				return session.Wait()
+			}
+
+			BeforeEach(func() {
+				var err error
+				dir, err = ioutil.TempDir(os.TempDir(), "params")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ioutil.WriteFile(filepath.Join(dir, "template.yml"), []byte(`
+__params:
+  host:
+    type: string
+  port:
+    type: int
+    default: 8080
+    validate:
+      - [ "gt", 0 ]
+host: (( merge ))
+port: (( merge ))
+`), 0644)).To(Succeed())
+				Expect(ioutil.WriteFile(filepath.Join(dir, "stub.yml"), []byte("host: example.org\nport: 0\n"), 0644)).To(Succeed())
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(dir)
+			})
+
+			It("reports violations without the error classification", func() {
+				merge = run("template.yml", "stub.yml")
+				Expect(merge).To(Exit(1))
+				Expect(merge.Err).To(Say("invalid template parameters:\n\tport: condition 1 failed"))
+				Expect(merge.Err).NotTo(Say("error classification"))
+			})
+
+			It("reports violations and processing errors", func() {
+				merge = run("template.yml")
+				Expect(merge).To(Exit(1))
+				Expect(merge.Err).To(Say("invalid template parameters:\n\thost: parameter must be set by a stub\n"))
+				Expect(merge.Err).To(Say("'host' not found in any stub"))
+				Expect(merge.Err).To(Say("error classification"))
+			})
+
+			It("reports violations as json", func() {
+				merge = run("--error-format", "json", "template.yml")
+				Expect(merge).To(Exit(1))
+				Expect(merge.Err).To(Say(`"parameters": \[\s*{\s*"name": "host",\s*"message": "parameter must be set by a stub"`))
+				Expect(merge.Err).To(Say(`"unresolved": \[`))
+			})
+
+			It("reports other errors as json", func() {
+				merge = run("--error-format", "json", "template.yml", "missing.yml")
+				Expect(merge).To(Exit(1))
+				Expect(merge.Err).To(Say(`"message": "error reading stub \[missing.yml\]",\s*"error": ".*no such file or directory"`))
+			})
+		})
+
+		Context("when reading stdin", func() {
+			It("reads stdin: locations", func() {
+				template, err := ioutil.TempFile(os.TempDir(), "stdin.yml")
+				Expect(err).NotTo(HaveOccurred())
+				defer os.Remove(template.Name())
+				template.Write([]byte("data: (( read(\"stdin:\", \"yaml\") ))\n"))
+				cmd := exec.Command(spiff, "merge", template.Name())
+				cmd.Stdin = strings.NewReader("alice: 25\n")
+				merge, err = Start(cmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(merge.Wait()).To(Exit(0))
+				Expect(merge.Out).To(Say("data:\n  alice: 25\n"))
+			})
+
+			It("refuses stdin: locations if stdin is used for the template", func() {
+				var err error
+				cmd := exec.Command(spiff, "merge", "-")
+				cmd.Stdin = strings.NewReader("data: (( read(\"stdin:\", \"yaml\") ))\n")
+				merge, err = Start(cmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(merge.Wait()).To(Exit(1))
+				Expect(merge.Err).To(Say("standard input not available: already used as input document"))
+			})
+		})
+
+		Context("when watching", func() {
+			var dir string
+
+			BeforeEach(func() {
+				var err error
+				dir, err = ioutil.TempDir(os.TempDir(), "watch")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ioutil.WriteFile(filepath.Join(dir, "template.yml"), []byte("data: (( read(\"data.yml\") ))\nvalue: (( merge ))\n"), 0644)).To(Succeed())
+				Expect(ioutil.WriteFile(filepath.Join(dir, "data.yml"), []byte("x: 1\n"), 0644)).To(Succeed())
+				Expect(ioutil.WriteFile(filepath.Join(dir, "stub.yml"), []byte("value: 1\n"), 0644)).To(Succeed())
+			})
+
+			AfterEach(func() {
+				if merge != nil {
+					merge.Kill().Wait()
+				}
+				os.RemoveAll(dir)
+			})
+
+			It("renders again on changes of read files and stubs", func() {
+				var err error
+				cmd := exec.Command(spiff, "merge", "--watch-diff", "--watch-interval", "50ms", "template.yml", "stub.yml")
+				cmd.Dir = dir
+				merge, err = Start(cmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(merge.Out).Should(Say("data:\n  x: 1\nvalue: 1\n"))
+				Eventually(merge.Err).Should(Say("watching 3 files for changes"))
+
+				Expect(ioutil.WriteFile(filepath.Join(dir, "data.yml"), []byte("x: 2\n"), 0644)).To(Succeed())
+				Eventually(merge.Err).Should(Say("data.yml changed, rendering again"))
+				Eventually(merge.Out).Should(Say("Difference in data.x\n  previous:\n    1\n  current:\n    2\n"))
+
+				Expect(ioutil.WriteFile(filepath.Join(dir, "stub.yml"), []byte("value: (( unknown ))\n"), 0644)).To(Succeed())
+				Eventually(merge.Err).Should(Say("'unknown' not found"))
+
+				Expect(ioutil.WriteFile(filepath.Join(dir, "stub.yml"), []byte("value: 22\n"), 0644)).To(Succeed())
+				Eventually(merge.Out).Should(Say("Difference in value\n  previous:\n    1\n  current:\n    22\n"))
+				Expect(merge.ExitCode()).To(Equal(-1))
+			})
+
+			It("renders again if looked up files appear", func() {
+				var err error
+				Expect(ioutil.WriteFile(filepath.Join(dir, "template.yml"), []byte("found: (( length(lookup_file(\"extra.yml\", [\"conf\"])) ))\n"), 0644)).To(Succeed())
+				Expect(os.Mkdir(filepath.Join(dir, "conf"), 0755)).To(Succeed())
+				cmd := exec.Command(spiff, "merge", "--watch", "--watch-interval", "50ms", "template.yml")
+				cmd.Dir = dir
+				merge, err = Start(cmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(merge.Out).Should(Say("found: 0\n"))
+				Eventually(merge.Err).Should(Say("watching 2 files for changes"))
+
+				Expect(ioutil.WriteFile(filepath.Join(dir, "conf", "extra.yml"), []byte("x: 1\n"), 0644)).To(Succeed())
+				Eventually(merge.Err).Should(Say("conf/extra.yml changed, rendering again"))
+				Eventually(merge.Out).Should(Say("found: 1\n"))
+			})
+		})
+
+	})
+
+	Context("diff", func() {
+		var dir string
+		var diff *Session
+
+		run := func(args ...string) *Session {
+			cmd := exec.Command(spiff, append([]string{"diff"}, args...)...)
+			cmd.Dir = dir
+			session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			return session.Wait()
+		}
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir(os.TempDir(), "diff")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ioutil.WriteFile(filepath.Join(dir, "a.yml"), []byte("value: 1\n"), 0644)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(dir, "b.yml"), []byte("value: 2\n"), 0644)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(dir, "multi.yml"), []byte("value: 1\n---\nvalue: 2\n"), 0644)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("exits with 1 for differences", func() {
+			diff = run("a.yml", "b.yml")
+			Expect(diff).To(Exit(1))
+			diff = run("a.yml", "a.yml")
+			Expect(diff).To(Exit(0))
+		})
+
+		It("exits with 2 for errors", func() {
+			diff = run("a.yml", "missing.yml")
+			Expect(diff).To(Exit(2))
+			diff = run("--format", "jsonpatch", "a.yml", "multi.yml")
+			Expect(diff).To(Exit(2))
+			Expect(diff.Err).To(Say("Different number of documents"))
+		})
+
+		It("rejects compare options for the unified format", func() {
+			diff = run("--format", "unified", "--ignore", "value", "a.yml", "b.yml")
+			Expect(diff).To(Exit(2))
+			Expect(diff.Err).To(Say("cannot be combined"))
+		})
+	})
+
+	Context("doc", func() {
+		var dir string
+		var doc *Session
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir(os.TempDir(), "doc")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ioutil.WriteFile(filepath.Join(dir, "template.yml"), []byte(`
+__params:
+  host:
+    description: host name
+    type: string
+  port:
+    type: int
+    default: 8080
+    validate:
+      - [ "gt", 0 ]
+host: (( merge ))
+port: (( merge ))
+`), 0644)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("renders the parameters as markdown", func() {
+			var err error
+			doc, err = Start(exec.Command(spiff, "doc", filepath.Join(dir, "template.yml")), GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(doc.Wait()).To(Exit(0))
+			Expect(doc.Out).To(Say("# Parameters of template.yml\n"))
+			Expect(doc.Out).To(Say("\\| `host` \\| string \\| yes \\|  \\| host name \\|  \\|\n"))
+			Expect(doc.Out).To(Say("\\| `port` \\| int \\| no \\| `8080` \\|  \\| `\\[\"gt\",0\\]` \\|\n"))
+		})
+
+		It("renders the parameters as json", func() {
+			var err error
+			doc, err = Start(exec.Command(spiff, "doc", "--format", "json", filepath.Join(dir, "template.yml")), GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(doc.Wait()).To(Exit(0))
+			Expect(doc.Out.Contents()).To(MatchJSON(`[
+  {"name": "host", "description": "host name", "type": "string", "required": true},
+  {"name": "port", "type": "int", "required": false, "default": 8080, "validate": [["gt", 0]]}
+]`))
+		})
 	})
 })
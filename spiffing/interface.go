@@ -26,6 +26,45 @@ type Node = yaml.Node
 // Options described the processing options
 type Options = flow.Options
 
+// UnresolvedNodeInfo is the structured description of an unresolved
+// node reported by a processing error
+type UnresolvedNodeInfo = dynaml.UnresolvedNodeInfo
+
+// Policy restricts the access to external resources granted by the mode
+type Policy = dynaml.Policy
+
+// Fetcher reads remote content, optionally using a persistent cache
+// and a lock file
+type Fetcher = flow.Fetcher
+
+// Journal records the side effects of a processing in dry-run mode
+type Journal = dynaml.Journal
+
+// JournalEntry describes a side effect recorded in dry-run mode
+type JournalEntry = dynaml.JournalEntry
+
+// Profiler records the effort spent for a processing
+type Profiler = dynaml.Profiler
+
+// ProfileReport is the result of a profiled processing
+type ProfileReport = dynaml.ProfileReport
+
+// Warning describes a suspicious, but valid situation found during a processing
+type Warning = dynaml.Warning
+
+// Warnings collects the warnings of a processing
+type Warnings = dynaml.Warnings
+
+// Resolver provides the content for locations of a URL scheme
+type Resolver = dynaml.Resolver
+
+// ResolverFunc is a function used as Resolver
+type ResolverFunc = dynaml.ResolverFunc
+
+// Resolvers provides access to a set of resolvers used to extend
+// the standard URL schemes supported for reading content
+type Resolvers = dynaml.Resolvers
+
 // Functions provides access to a set of spiff functions used to extend
 // the standard function set
 type Functions = dynaml.Functions
@@ -51,12 +90,40 @@ type Spiff interface {
 	// prcessing. Setting a filesystem disables the command
 	// execution functions.
 	WithFileSystem(fs vfs.FileSystem) Spiff
+	// WithPolicy creates a new context with the given policy
+	// restricting the access to executables, files, URLs and
+	// environment variables granted by the mode.
+	WithPolicy(policy *Policy) Spiff
+	// WithFetcher creates a new context with the given fetcher
+	// used to read content from http(s) URLs.
+	WithFetcher(fetcher *Fetcher) Spiff
+	// WithDeterministic creates a new context using the deterministic
+	// mode with the given seed. Random values, generated keys, salts
+	// and temp file names are derived from the seed and the node path,
+	// and uncached command executions are rejected.
+	WithDeterministic(seed string) Spiff
+	// WithDryRun creates a new context using the dry-run mode.
+	// File modifications are kept in memory and commands are not
+	// executed. Instead, those side effects are recorded in the
+	// given journal.
+	WithDryRun(journal *Journal) Spiff
+	// WithProfiler creates a new context recording flow iterations,
+	// node evaluations and the time spent for function calls and
+	// documents with the given profiler.
+	WithProfiler(profiler *Profiler) Spiff
+	// WithWarnings creates a new context collecting the warnings
+	// of the processing (deprecated nodes, warn function calls and
+	// failed validations in lenient mode) in the given object.
+	WithWarnings(warnings *Warnings) Spiff
 	// WithFunctions creates a new context with the given
 	// additional function definitions
 	WithFunctions(functions Functions) Spiff
 	// WithFunctions creates a new context with the given
 	// additional function definitions
 	WithControls(controls Controls) Spiff
+	// WithResolvers creates a new context with the given
+	// additional URL scheme resolvers used to read content
+	WithResolvers(resolvers Resolvers) Spiff
 
 	// WithFeatures creates a new context with the given
 	// additional features enabled
@@ -63,6 +63,12 @@ func (s *sourceData) Data() ([]byte, error) {
 type spiff struct {
 	key      string
 	mode     int
+	policy   *Policy
+	fetcher  *Fetcher
+	seed     *string
+	journal  *Journal
+	profiler *Profiler
+	warnings *Warnings
 	fs       vfs.FileSystem
 	opts     flow.Options
 	values   map[string]yaml.Node
@@ -78,6 +84,17 @@ func NewFunctions() Functions {
 	return dynaml.NewFunctions()
 }
 
+// NewResolvers provides a new registry for additional URL scheme resolvers
+func NewResolvers() Resolvers {
+	return dynaml.NewResolvers()
+}
+
+// NewFetcher provides a new fetcher for remote content using an optional
+// cache directory. In offline mode content is served from the cache, only.
+func NewFetcher(cacheDir string, offline bool) *Fetcher {
+	return flow.NewFetcher(cacheDir, offline)
+}
+
 // NewControls provides a new registry for additional spiff controls
 func NewControls() Controls {
 	return dynaml.NewControls()
@@ -121,7 +138,19 @@ func (s *spiff) assureBinding() {
 	if s.binding == nil {
 		state := flow.NewState(s.key, s.mode, s.fs).
 			SetRegistry(s.registry).
-			SetFeatures(s.features)
+			SetFeatures(s.features).
+			SetPolicy(s.policy).
+			SetFetcher(s.fetcher).
+			SetProfiler(s.profiler)
+		if s.seed != nil {
+			state.SetDeterministic(*s.seed)
+		}
+		if s.journal != nil {
+			state.SetDryRun(s.journal)
+		}
+		if s.warnings != nil {
+			state.SetWarnings(s.warnings)
+		}
 		if len(s.tags) > 0 {
 			var tags []*dynaml.Tag
 			for _, t := range s.tags {
@@ -189,6 +218,48 @@ func (s spiff) WithFileSystem(fs vfs.FileSystem) Spiff {
 	return s.Reset()
 }
 
+// WithPolicy creates a new context with the given policy
+// restricting the access to external resources.
+func (s spiff) WithPolicy(policy *Policy) Spiff {
+	s.policy = policy
+	return s.Reset()
+}
+
+// WithFetcher creates a new context with the given fetcher
+// used to read remote content.
+func (s spiff) WithFetcher(fetcher *Fetcher) Spiff {
+	s.fetcher = fetcher
+	return s.Reset()
+}
+
+// WithDeterministic creates a new context using the
+// deterministic mode with the given seed.
+func (s spiff) WithDeterministic(seed string) Spiff {
+	s.seed = &seed
+	return s.Reset()
+}
+
+// WithDryRun creates a new context using the dry-run mode
+// recording side effects in the given journal.
+func (s spiff) WithDryRun(journal *Journal) Spiff {
+	s.journal = journal
+	return s.Reset()
+}
+
+// WithProfiler creates a new context recording the
+// effort of the processing with the given profiler.
+func (s spiff) WithProfiler(profiler *Profiler) Spiff {
+	s.profiler = profiler
+	return s.Reset()
+}
+
+// WithWarnings creates a new context collecting the
+// warnings of the processing in the given object.
+func (s spiff) WithWarnings(warnings *Warnings) Spiff {
+	s.warnings = warnings
+	return s.Reset()
+}
+
 // WithFunctions creates a new context with the given
 // additional function definitions
 func (s spiff) WithFunctions(functions Functions) Spiff {
@@ -196,6 +267,13 @@ func (s spiff) WithFunctions(functions Functions) Spiff {
 	return s.Reset()
 }
 
+// WithResolvers creates a new context with the given
+// URL scheme resolvers
+func (s spiff) WithResolvers(resolvers Resolvers) Spiff {
+	s.registry = s.registry.WithResolvers(resolvers)
+	return s.Reset()
+}
+
 // WithControls creates a new context with the given
 // control definitions
 func (s spiff) WithControls(controls Controls) Spiff {
@@ -224,7 +302,12 @@ func (s spiff) WithValues(values map[string]interface{}) (Spiff, error) {
 
 // SetTag sets/resets a global tag for subsequent processings.
 func (s spiff) SetTag(tag string, node yaml.Node) Spiff {
-	s.tags[tag] = dynaml.NewTag(tag, node, nil, dynaml.TAG_SCOPE_GLOBAL)
+	tags := map[string]*dynaml.Tag{}
+	for k, v := range s.tags {
+		tags[k] = v
+	}
+	tags[tag] = dynaml.NewTag(tag, node, nil, dynaml.TAG_SCOPE_GLOBAL)
+	s.tags = tags
 	return s.Reset()
 }
 
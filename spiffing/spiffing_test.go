@@ -1,6 +1,15 @@
 package spiffing
 
 import (
+	"archive/tar"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -37,6 +46,352 @@ var _ = Describe("Spiffing", func() {
 		})
 	})
 
+	Context("with policy", func() {
+		process := func(ctx Spiff, src string) string {
+			templ, err := ctx.Unmarshal("test", []byte(src))
+			Expect(err).To(Succeed())
+			result, err := ctx.Cascade(templ, nil)
+			Expect(err).To(Succeed())
+			data, err := ctx.Marshal(result)
+			Expect(err).To(Succeed())
+			return string(data)
+		}
+
+		ctx := New().WithPolicy(&Policy{
+			Executables: []string{"echo"},
+			Read:        []string{"testdata"},
+			Write:       []string{},
+			Env:         []string{"HOME"},
+		})
+
+		It("allows listed executables", func() {
+			Expect(process(ctx, `(( exec("echo", "alice") ))`)).To(Equal("alice\n"))
+		})
+		It("denies other executables", func() {
+			Expect(process(ctx, `(( catch(exec("ls")).error ))`)).To(Equal("'policy violation: executable \"ls\" not allowed'\n"))
+		})
+		It("denies reading outside of read roots", func() {
+			Expect(process(ctx, `(( catch(read("spiff.go")).error ))`)).To(Equal("'read: policy violation: read access to \"spiff.go\" not allowed'\n"))
+		})
+		It("denies writing", func() {
+			Expect(process(ctx, `(( catch(write("out.yml", "data")).error ))`)).To(Equal("'policy violation: write access to \"out.yml\" not allowed'\n"))
+		})
+		It("denies other environment variables", func() {
+			Expect(process(ctx, `(( catch(env("PATH")).error ))`)).To(Equal("'policy violation: environment variable \"PATH\" not allowed'\n"))
+		})
+		It("denies escaping roots by symbolic links", func() {
+			dir, err := ioutil.TempDir("", "spiff-policy")
+			Expect(err).To(Succeed())
+			defer os.RemoveAll(dir)
+			root := filepath.Join(dir, "root")
+			outside := filepath.Join(dir, "outside")
+			Expect(os.Mkdir(root, 0755)).To(Succeed())
+			Expect(os.Mkdir(outside, 0755)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(outside, "secret"), []byte("secret"), 0644)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(root, "file"), []byte("data"), 0644)).To(Succeed())
+			Expect(os.Symlink(outside, filepath.Join(root, "escape"))).To(Succeed())
+			Expect(os.Symlink(filepath.Join(outside, "missing"), filepath.Join(root, "dangling"))).To(Succeed())
+
+			ctx := New().WithPolicy(&Policy{Read: []string{root}, Write: []string{root}})
+			Expect(process(ctx, `(( read("`+root+`/file", "text") ))`)).To(Equal("data\n"))
+			Expect(process(ctx, `(( catch(read("`+root+`/escape/secret", "text")).valid ))`)).To(Equal("false\n"))
+			Expect(process(ctx, `(( catch(write("`+root+`/escape/out", "data")).valid ))`)).To(Equal("false\n"))
+			Expect(process(ctx, `(( catch(write("`+root+`/dangling", "data")).valid ))`)).To(Equal("false\n"))
+			Expect(process(ctx, `(( catch(write("`+root+`/new", "data")).valid ))`)).To(Equal("true\n"))
+			_, err = os.Stat(filepath.Join(outside, "missing"))
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+		It("parses policies", func() {
+			p, err := dynaml.ParsePolicy("policy", []byte("executables: [ echo ]\nwrite: []\n"))
+			Expect(err).To(Succeed())
+			Expect(p).To(Equal(&Policy{Executables: []string{"echo"}, Write: []string{}}))
+			_, err = dynaml.ParsePolicy("policy", []byte("unknown: []\n"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("with resolvers", func() {
+		process := func(ctx Spiff, src string) string {
+			templ, err := ctx.Unmarshal("test", []byte(src))
+			Expect(err).To(Succeed())
+			result, err := ctx.Cascade(templ, nil)
+			Expect(err).To(Succeed())
+			data, err := ctx.Marshal(result)
+			Expect(err).To(Succeed())
+			return string(data)
+		}
+
+		It("reads data URLs", func() {
+			Expect(process(New(), `(( read("data:,alice%3A%2025", "yaml") ))`)).To(Equal("alice: 25\n"))
+			Expect(process(New(), `(( read("data:;base64,Ym9iOiAyNgo=", "yaml") ))`)).To(Equal("bob: 26\n"))
+		})
+
+		It("reads files from archives", func() {
+			buf := &bytes.Buffer{}
+			tw := tar.NewWriter(buf)
+			content := []byte("alice: 25\n")
+			Expect(tw.WriteHeader(&tar.Header{Name: "./dir/file.yml", Mode: 0644, Size: int64(len(content))})).To(Succeed())
+			_, err := tw.Write(content)
+			Expect(err).To(Succeed())
+			Expect(tw.Close()).To(Succeed())
+			location := "archive:data:;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()) + "#dir/file.yml"
+			Expect(process(New(), `(( read("`+location+`", "yaml") ))`)).To(Equal("alice: 25\n"))
+		})
+
+		It("uses custom resolvers", func() {
+			resolvers := NewResolvers()
+			resolvers.RegisterResolver("vault", ResolverFunc(func(state dynaml.State, location string) ([]byte, error) {
+				return []byte("secret: " + location[len("vault:"):] + "\n"), nil
+			}))
+			ctx := New().WithResolvers(resolvers)
+			Expect(process(ctx, `(( read("vault:alice", "yaml") ))`)).To(Equal("secret: alice\n"))
+			Expect(process(ctx, `(( lookup_file("vault:bob", []) ))`)).To(Equal("- vault:bob\n"))
+			Expect(process(ctx, `(( read("data:,text", "text") ))`)).To(Equal("text\n"))
+		})
+	})
+
+	Context("in deterministic mode", func() {
+		process := func(ctx Spiff, src string) (string, error) {
+			templ, err := ctx.Unmarshal("test", []byte(src))
+			Expect(err).To(Succeed())
+			result, err := ctx.Cascade(templ, nil)
+			if err != nil {
+				return "", err
+			}
+			data, err := ctx.Marshal(result)
+			Expect(err).To(Succeed())
+			return string(data), nil
+		}
+
+		template := `
+---
+number: (( rand(1000000) ))
+string: (( rand("a-z", 20) ))
+list:
+  - (( rand(1000000) ))
+  - (( rand(1000000) ))
+wg: (( wggenkey() ))
+rsa: (( x509genkey(1024) ))
+ec: (( x509genkey("P256") ))
+md5: (( md5crypt("secret") ))
+bcrypt: (( bcrypt("secret", 4) ))
+check: (( bcrypt_check("secret", bcrypt) ))
+temp: (( tempfile("data") ))
+`
+
+		It("renders reproducibly", func() {
+			first, err := process(New().WithDeterministic("seed"), template)
+			Expect(err).To(Succeed())
+			second, err := process(New().WithDeterministic("seed"), template)
+			Expect(err).To(Succeed())
+			Expect(second).To(Equal(first))
+			Expect(first).To(ContainSubstring("check: true\n"))
+
+			other, err := process(New().WithDeterministic("other"), template)
+			Expect(err).To(Succeed())
+			Expect(other).NotTo(Equal(first))
+		})
+
+		It("creates temporary files in a private directory", func() {
+			first, err := process(New().WithDeterministic("seed"), `(( tempfile("data") ))`)
+			Expect(err).To(Succeed())
+			second, err := process(New().WithDeterministic("seed"), `(( tempfile("data") ))`)
+			Expect(err).To(Succeed())
+			Expect(second).To(Equal(first))
+			dir := filepath.Dir(strings.TrimSpace(first))
+			Expect(filepath.Dir(dir)).To(Equal(filepath.Clean(os.TempDir())))
+			info, err := os.Lstat(dir)
+			Expect(err).To(Succeed())
+			Expect(info.IsDir()).To(BeTrue())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0700)))
+		})
+
+		It("rejects a temp directory accessible by others", func() {
+			first, err := process(New().WithDeterministic("shared"), `(( tempfile("data") ))`)
+			Expect(err).To(Succeed())
+			dir := filepath.Dir(strings.TrimSpace(first))
+			defer os.RemoveAll(dir)
+			Expect(os.Chmod(dir, 0777)).To(Succeed())
+			_, err = process(New().WithDeterministic("shared"), `(( tempfile("data") ))`)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("is not private to the current user"))
+		})
+
+		It("derives values from the node path", func() {
+			result, err := process(New().WithDeterministic("seed"), `
+---
+a: (( rand(1000000) ))
+b: (( rand(1000000) ))
+`)
+			Expect(err).To(Succeed())
+			var a, b int
+			_, err = fmt.Sscanf(result, "a: %d\nb: %d\n", &a, &b)
+			Expect(err).To(Succeed())
+			Expect(a).NotTo(Equal(b))
+		})
+
+		It("derives different values for repeated calls of an expression", func() {
+			template := `
+---
+numbers: (( map[[1,2,3]|x|->rand(1000000)] ))
+pair: (( [rand(1000000), rand(1000000)] ))
+keys: (( map[[1,2]|x|->wggenkey()] ))
+hashes: (( map[[1,2]|x|->bcrypt("secret", 4)] ))
+`
+			result, err := process(New().WithDeterministic("seed"), template)
+			Expect(err).To(Succeed())
+			again, err := process(New().WithDeterministic("seed"), template)
+			Expect(err).To(Succeed())
+			Expect(again).To(Equal(result))
+
+			var n1, n2, n3, p1, p2 int
+			var k1, k2, h1, h2 string
+			_, err = fmt.Sscanf(result, "numbers:\n- %d\n- %d\n- %d\npair:\n- %d\n- %d\nkeys:\n- %s\n- %s\nhashes:\n- %s\n- %s\n",
+				&n1, &n2, &n3, &p1, &p2, &k1, &k2, &h1, &h2)
+			Expect(err).To(Succeed())
+			Expect([]int{n2, n3}).NotTo(ContainElement(n1))
+			Expect(n2).NotTo(Equal(n3))
+			Expect(p1).NotTo(Equal(p2))
+			Expect(k1).NotTo(Equal(k2))
+			Expect(h1).NotTo(Equal(h2))
+		})
+
+		It("rejects uncached executions", func() {
+			_, err := process(New().WithDeterministic("seed"), `(( exec_uncached("echo", "alice") ))`)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("exec_uncached not possible in deterministic mode"))
+			result, err := process(New().WithDeterministic("seed"), `(( exec("echo", "alice") ))`)
+			Expect(err).To(Succeed())
+			Expect(result).To(Equal("alice\n"))
+		})
+	})
+
+	Context("with warnings", func() {
+		It("collects warnings of the cascade", func() {
+			warnings := &Warnings{}
+			ctx := New().WithWarnings(warnings).WithFeatures("lenient-validation")
+			templ, err := ctx.Unmarshal("template", []byte(`
+---
+a: (( warn("a is outdated", 1) ))
+b: (( &deprecated("use a") (2) ))
+c: (( validate(3, ["gt", 5]) ))
+`))
+			Expect(err).To(Succeed())
+			stub, err := ctx.Unmarshal("stub", []byte(`
+---
+b: 3
+`))
+			Expect(err).To(Succeed())
+			result, err := ctx.Cascade(templ, []Node{stub})
+			Expect(err).To(Succeed())
+			data, err := ctx.Marshal(result)
+			Expect(err).To(Succeed())
+			Expect(string(data)).To(Equal("a: 1\nb: 3\nc: 3\n"))
+
+			list := []string{}
+			for _, w := range warnings.List() {
+				list = append(list, w.String())
+			}
+			Expect(list).To(ConsistOf(
+				"a: a is outdated",
+				"b: deprecated node overridden by stub:3:4: use a",
+				"c: condition 1 failed: less or equal to 5",
+			))
+		})
+	})
+
+	Context("with profiler", func() {
+		It("records the effort of the processing", func() {
+			profiler := &Profiler{}
+			ctx := New().WithProfiler(profiler)
+			templ, err := ctx.Unmarshal("template", []byte(`
+---
+utils:
+  double: (( |x|->x * 2 ))
+a: (( utils.double(b) ))
+b: (( length("abc") + c ))
+c: (( merge ))
+`))
+			Expect(err).To(Succeed())
+			stub, err := ctx.Unmarshal("stub", []byte(`
+---
+c: 1
+`))
+			Expect(err).To(Succeed())
+			result, err := ctx.Cascade(templ, []Node{stub})
+			Expect(err).To(Succeed())
+			data, err := ctx.Marshal(result)
+			Expect(err).To(Succeed())
+			Expect(string(data)).To(Equal("utils:\n  double: (( lambda|x|->x * 2 ))\na: 8\nb: 4\nc: 1\n"))
+
+			report := profiler.Report()
+			Expect(report.Iterations).To(BeNumerically(">", 2))
+			names := func(entries []dynaml.ProfileEntry) map[string]int {
+				m := map[string]int{}
+				for _, e := range entries {
+					m[e.Name] = e.Count
+				}
+				return m
+			}
+			Expect(names(report.Documents)).To(HaveKey("template"))
+			Expect(names(report.Documents)).To(HaveKey("stub"))
+			Expect(names(report.Functions)).To(HaveKeyWithValue("lambda template:utils.double", 1))
+			Expect(names(report.Functions)).To(HaveKey("length"))
+			Expect(names(report.Nodes)).To(HaveKey("template:b"))
+			Expect(names(report.Nodes)["template:a"]).To(BeNumerically(">", names(report.Nodes)["template:utils.double"]))
+
+			buf := &bytes.Buffer{}
+			report.Write(buf, 1)
+			Expect(buf.String()).To(ContainSubstring("calls       lambda template:utils.double\n"))
+			Expect(buf.String()).To(MatchRegexp("node evaluations:\n +[0-9]+  template:a\n  \\.\\.\\. [0-9]+ more\n$"))
+		})
+	})
+
+	Context("in dry-run mode", func() {
+		It("records side effects instead of performing them", func() {
+			dir, err := ioutil.TempDir("", "spiff-dry-run-")
+			Expect(err).To(Succeed())
+			defer os.RemoveAll(dir)
+			marker := filepath.Join(dir, "marker")
+
+			journal := &Journal{}
+			ctx := New().WithDryRun(journal)
+			templ, err := ctx.Unmarshal("test", []byte(`
+---
+dir: (( mkdir("`+dir+`/sub") ))
+file: (( write(dir "/out.yml", { $alice = 25 }) ))
+back: (( read(dir "/out.yml") ))
+exec: (( exec("touch", "`+marker+`") ))
+`))
+			Expect(err).To(Succeed())
+			result, err := ctx.Cascade(templ, nil)
+			Expect(err).To(Succeed())
+			data, err := ctx.Marshal(result)
+			Expect(err).To(Succeed())
+			Expect(string(data)).To(Equal(`dir: ` + dir + `/sub
+file: |+
+  ---
+  alice: 25
+back:
+  alice: 25
+exec: ""
+`))
+
+			Expect(journal.Entries()).To(ConsistOf(
+				JournalEntry{Operation: dynaml.JOURNAL_MKDIR, Path: dir + "/sub", Permissions: 0755},
+				JournalEntry{Operation: dynaml.JOURNAL_WRITE, Path: dir + "/sub/out.yml", Permissions: 0644},
+				JournalEntry{Operation: dynaml.JOURNAL_EXEC, Command: []string{"touch", marker}},
+			))
+			Expect(filepath.Join(dir, "sub")).NotTo(BeADirectory())
+			Expect(marker).NotTo(BeAnExistingFile())
+		})
+
+		It("describes entries", func() {
+			Expect(JournalEntry{Operation: dynaml.JOURNAL_WRITE, Path: "out.yml", Permissions: 0644}.String()).To(Equal("write    out.yml (0644)"))
+			Expect(JournalEntry{Operation: dynaml.JOURNAL_EXEC, Command: []string{"echo", "a b"}, Dir: "sub"}.String()).To(Equal(`exec     echo "a b" (in sub)`))
+		})
+	})
+
 	Context("Simple processing", func() {
 		ctx, err := New().WithValues(map[string]interface{}{
 			"values": map[string]interface{}{
@@ -103,10 +458,59 @@ orig: (( values ))
 			data, err := ctx.Marshal(result)
 			Expect(err).To(Succeed())
 			Expect(string(data)).To(Equal(
-				`data: 25
+				`values: other
+data: 25
 orig: other
-values: other
 `))
 		})
 	})
+
+	Context("error reporting", func() {
+		It("describes unresolved nodes", func() {
+			ctx := New()
+			templ, err := ctx.Unmarshal("test", []byte(`
+f: (( |x|->x.foo ))
+value: (( .f(1) ))
+other: (( value ))
+`))
+			Expect(err).To(Succeed())
+			_, err = ctx.Cascade(templ, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(UnresolvedNodes(err)).To(Equal([]UnresolvedNodeInfo{
+				{
+					Expression:     ".f(1)",
+					Source:         "test",
+					Line:           3,
+					Column:         8,
+					Context:        []string{"value"},
+					Path:           []string{},
+					Tag:            "*",
+					Classification: dynaml.CLASS_ERROR,
+					Issue:          "evaluation of lambda expression failed: lambda|x|->x.foo: {x: 1}",
+					Nested: []dynaml.IssueInfo{
+						{Issue: "'x.foo' not found"},
+					},
+					CallStack: []string{
+						"evaluation of lambda expression failed: lambda|x|->x.foo: {x: 1}",
+						"'x.foo' not found",
+					},
+				},
+				{
+					Expression:     "value",
+					Source:         "test",
+					Line:           4,
+					Column:         8,
+					Context:        []string{"other"},
+					Path:           []string{},
+					Tag:            "-",
+					Classification: dynaml.CLASS_FAILED,
+					Issue:          "'value' unresolved",
+				},
+			}))
+		})
+
+		It("ignores other errors", func() {
+			Expect(UnresolvedNodes(fmt.Errorf("other"))).To(BeNil())
+		})
+	})
 })
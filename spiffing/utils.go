@@ -1,9 +1,11 @@
 package spiffing
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/mandelsoft/spiff/dynaml"
 	"github.com/mandelsoft/spiff/yaml"
 )
 
@@ -92,3 +94,13 @@ func ToNode(name string, data interface{}) (Node, error) {
 func Normalize(n Node) (interface{}, error) {
 	return yaml.Normalize(n)
 }
+
+// UnresolvedNodes provides the structured description of the unresolved
+// nodes reported by a processing error. It returns nil for other errors.
+func UnresolvedNodes(err error) []UnresolvedNodeInfo {
+	var unresolved dynaml.UnresolvedNodes
+	if errors.As(err, &unresolved) {
+		return unresolved.Info()
+	}
+	return nil
+}
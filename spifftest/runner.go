@@ -0,0 +1,218 @@
+package spifftest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mandelsoft/spiff/compare"
+	"github.com/mandelsoft/spiff/dynaml"
+	"github.com/mandelsoft/spiff/spiffing"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+// Options controls the execution of tests.
+type Options struct {
+	// Update rewrites the expected files with the actual output
+	// instead of comparing it.
+	Update bool
+	// Context is the spiff context used as base for the processing.
+	// It defaults to spiffing.New().
+	Context spiffing.Spiff
+}
+
+// Status is the outcome of a test.
+type Status string
+
+const (
+	PASSED  Status = "PASS"
+	FAILED  Status = "FAIL"
+	UPDATED Status = "UPDATED"
+)
+
+// Result describes the outcome of a test.
+type Result struct {
+	Spec   *Spec
+	Status Status
+	// Message describes the reason for a failure.
+	Message string
+	// Diffs are the differences between the expected (A) and the
+	// actual (B) output.
+	Diffs []compare.Diff
+}
+
+func (r *Result) fail(msg string, args ...interface{}) *Result {
+	r.Status = FAILED
+	r.Message = fmt.Sprintf(msg, args...)
+	return r
+}
+
+// Run executes a test.
+func Run(spec *Spec, opts Options) *Result {
+	result := &Result{Spec: spec, Status: PASSED}
+
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = spiffing.New()
+	}
+	if len(spec.Features) > 0 {
+		ctx = ctx.WithFeatures(spec.Features...)
+	}
+	if spec.Values != nil {
+		var err error
+		ctx, err = ctx.WithValues(spec.Values)
+		if err != nil {
+			return result.fail("invalid values: %s", err)
+		}
+	}
+	for _, name := range sortedKeys(spec.Tags) {
+		node, err := read(spec.Path(spec.Tags[name]))
+		if err != nil {
+			return result.fail("tag %s: %s", name, err)
+		}
+		ctx = ctx.SetTag(name, node)
+	}
+
+	template, err := read(spec.Path(spec.Template))
+	if err != nil {
+		return result.fail("template: %s", err)
+	}
+	var stubs []yaml.Node
+	for _, s := range spec.Stubs {
+		stub, err := read(spec.Path(s))
+		if err != nil {
+			return result.fail("stub: %s", err)
+		}
+		stubs = append(stubs, stub)
+	}
+
+	output, err := ctx.Cascade(template, stubs)
+	if spec.Error != "" {
+		if err == nil {
+			return result.fail("expected error matching %q, but processing succeeded", spec.Error)
+		}
+		if !regexp.MustCompile(spec.Error).MatchString(err.Error()) {
+			return result.fail("expected error matching %q, but got: %s", spec.Error, err)
+		}
+		return result
+	}
+	if err != nil {
+		return result.fail("processing failed: %s", err)
+	}
+
+	if len(spec.Select) > 0 {
+		selected := &selection{}
+		for _, p := range spec.Select {
+			comps := dynaml.PathComponents(p, false)
+			node, ok := yaml.FindR(true, output, nil, comps...)
+			if !ok {
+				return result.fail("path %q not found", p)
+			}
+			selected.add(comps, node)
+		}
+		output = selected.Node()
+	}
+
+	var expected yaml.Node
+	switch {
+	case spec.Output != nil:
+		expected, err = yaml.Parse("output", spec.Output)
+		if err != nil {
+			return result.fail("invalid output: %s", err)
+		}
+	case spec.Expected != "":
+		file := spec.Path(spec.Expected)
+		if opts.Update {
+			data, err := ctx.Marshal(output)
+			if err != nil {
+				return result.fail("cannot marshal output: %s", err)
+			}
+			if old, err := ioutil.ReadFile(file); err == nil && string(old) == string(data) {
+				return result
+			}
+			if err := ioutil.WriteFile(file, data, 0644); err != nil {
+				return result.fail("cannot update expected file: %s", err)
+			}
+			result.Status = UPDATED
+			return result
+		}
+		expected, err = read(file)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return result.fail("expected file %s not found", file)
+			}
+			return result.fail("expected: %s", err)
+		}
+	default:
+		return result
+	}
+
+	copts, _ := spec.compareOptions()
+	result.Diffs = compare.CompareWithOptions(expected, output, copts)
+	if len(result.Diffs) > 0 {
+		sort.SliceStable(result.Diffs, func(i, j int) bool {
+			return strings.Join(result.Diffs[i].Path, ".") < strings.Join(result.Diffs[j].Path, ".")
+		})
+		return result.fail("output differs from expected output")
+	}
+	return result
+}
+
+// selection collects selected nodes of a document keeping the
+// nesting of their paths.
+type selection struct {
+	node   yaml.Node
+	nested map[string]*selection
+}
+
+func (s *selection) add(path []string, node yaml.Node) {
+	if s.node != nil {
+		// already selected by an enclosing path
+		return
+	}
+	if len(path) == 0 {
+		s.node = node
+		s.nested = nil
+		return
+	}
+	if s.nested == nil {
+		s.nested = map[string]*selection{}
+	}
+	n := s.nested[path[0]]
+	if n == nil {
+		n = &selection{}
+		s.nested[path[0]] = n
+	}
+	n.add(path[1:], node)
+}
+
+func (s *selection) Node() yaml.Node {
+	if s.node != nil {
+		return s.node
+	}
+	m := map[string]yaml.Node{}
+	for k, n := range s.nested {
+		m[k] = n.Node()
+	}
+	return yaml.NewNode(m, "")
+}
+
+func read(file string) (yaml.Node, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Parse(file, data)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
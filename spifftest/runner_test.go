@@ -0,0 +1,207 @@
+package spifftest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Template tests", func() {
+	var dir string
+
+	write := func(name, content string) {
+		Expect(ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)).To(Succeed())
+	}
+
+	load := func(content string) []*Spec {
+		write("spec.test.yml", content)
+		specs, err := LoadSpecs(filepath.Join(dir, "spec.test.yml"))
+		Expect(err).To(Succeed())
+		return specs
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "spifftest-")
+		Expect(err).To(Succeed())
+		write("template.yml", `
+values: (( merge ))
+name: (( values.name ))
+size: (( values.size * 2 ))
+list:
+  - name: a
+    v: (( size ))
+`)
+		write("stub.yml", `
+values:
+  name: alice
+  size: 2
+`)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Context("parsing", func() {
+		It("parses single tests", func() {
+			specs := load(`
+template: template.yml
+stubs: [ stub.yml ]
+values:
+  size: 2
+`)
+			Expect(specs).To(HaveLen(1))
+			Expect(specs[0].Name).To(Equal("spec.test.yml"))
+			Expect(specs[0].Values).To(Equal(map[string]interface{}{"size": int64(2)}))
+			Expect(specs[0].Path(specs[0].Template)).To(Equal(filepath.Join(dir, "template.yml")))
+		})
+
+		It("parses test lists", func() {
+			specs := load(`
+tests:
+  - name: first
+    template: template.yml
+  - template: template.yml
+`)
+			Expect(specs).To(HaveLen(2))
+			Expect(specs[0].Name).To(Equal("first"))
+			Expect(specs[1].Name).To(Equal("spec.test.yml[2]"))
+		})
+
+		It("rejects invalid specs", func() {
+			_, err := ParseSpecs("spec", []byte("template: t.yml\nunknown: x\n"))
+			Expect(err).To(MatchError(ContainSubstring("unknown field \"unknown\"")))
+			_, err = ParseSpecs("spec", []byte("stubs: [ s.yml ]\n"))
+			Expect(err).To(MatchError("spec: test spec: template required"))
+			_, err = ParseSpecs("spec", []byte("template: t.yml\nerror: x\noutput: {}\n"))
+			Expect(err).To(MatchError("spec: test spec: error cannot be combined with expected output"))
+		})
+	})
+
+	Context("running", func() {
+		It("compares inline output", func() {
+			specs := load(`
+template: template.yml
+stubs: [ stub.yml ]
+select: [ name, size ]
+output:
+  name: alice
+  size: 4
+`)
+			result := Run(specs[0], Options{})
+			Expect(result.Message).To(Equal(""))
+			Expect(result.Status).To(Equal(PASSED))
+		})
+
+		It("keeps the nesting of selected paths", func() {
+			specs := load(`
+template: template.yml
+stubs: [ stub.yml ]
+select: [ values.name, name, values.size, list ]
+output:
+  values:
+    name: alice
+    size: 2
+  name: alice
+  list:
+    - name: a
+      v: 4
+`)
+			result := Run(specs[0], Options{})
+			Expect(result.Message).To(Equal(""))
+			Expect(result.Status).To(Equal(PASSED))
+		})
+
+		It("reports differences", func() {
+			specs := load(`
+template: template.yml
+stubs: [ stub.yml ]
+select: [ list ]
+output:
+  list:
+    - name: a
+      v: 5
+`)
+			result := Run(specs[0], Options{})
+			Expect(result.Status).To(Equal(FAILED))
+			Expect(result.Diffs).To(HaveLen(1))
+			Expect(result.Diffs[0].Path).To(Equal([]string{"list", "a", "v"}))
+			Expect(result.Diffs[0].A.Value()).To(Equal(int64(5)))
+			Expect(result.Diffs[0].B.Value()).To(Equal(int64(4)))
+		})
+
+		It("checks expected errors", func() {
+			specs := load(`
+tests:
+  - template: template.yml
+    error: "'values' not found in any stub"
+  - template: template.yml
+    error: "other"
+  - template: template.yml
+    stubs: [ stub.yml ]
+    error: "values"
+`)
+			Expect(Run(specs[0], Options{}).Status).To(Equal(PASSED))
+			result := Run(specs[1], Options{})
+			Expect(result.Status).To(Equal(FAILED))
+			Expect(result.Message).To(HavePrefix("expected error matching \"other\", but got: "))
+			Expect(Run(specs[2], Options{}).Message).To(Equal("expected error matching \"values\", but processing succeeded"))
+		})
+
+		It("updates and uses golden files", func() {
+			specs := load(`
+template: template.yml
+stubs: [ stub.yml ]
+expected: expected.yml
+`)
+			result := Run(specs[0], Options{})
+			Expect(result.Status).To(Equal(FAILED))
+			Expect(result.Message).To(Equal("expected file " + filepath.Join(dir, "expected.yml") + " not found"))
+
+			Expect(Run(specs[0], Options{Update: true}).Status).To(Equal(UPDATED))
+			data, err := ioutil.ReadFile(filepath.Join(dir, "expected.yml"))
+			Expect(err).To(Succeed())
+			Expect(string(data)).To(Equal(`values:
+  name: alice
+  size: 2
+name: alice
+size: 4
+list:
+- name: a
+  v: 4
+`))
+			Expect(Run(specs[0], Options{Update: true}).Status).To(Equal(PASSED))
+			Expect(Run(specs[0], Options{}).Status).To(Equal(PASSED))
+
+			write("stub.yml", "values:\n  name: bob\n  size: 2\n")
+			result = Run(specs[0], Options{})
+			Expect(result.Status).To(Equal(FAILED))
+			Expect(result.Diffs).To(HaveLen(2))
+		})
+
+		It("uses values, tags and features", func() {
+			write("lib.yml", "greeting: hello\n")
+			write("tagged.yml", `
+text: (( values.name )) says (( lib::greeting ))!
+`)
+			specs := load(`
+template: tagged.yml
+values:
+  values:
+    name: alice
+tags:
+  lib: lib.yml
+features: [ interpolation ]
+output:
+  text: alice says hello!
+`)
+			result := Run(specs[0], Options{})
+			Expect(result.Message).To(Equal(""))
+			Expect(result.Status).To(Equal(PASSED))
+		})
+	})
+})
@@ -0,0 +1,185 @@
+// Package spifftest provides a declarative test runner for spiff templates.
+//
+// A test spec file is a yaml document describing a single test or a list
+// of tests (field tests). Every test processes a template with optional
+// stubs, values, tags and features and checks the result against expected
+// output (inline or a golden file) or an expected processing error.
+package spifftest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+
+	"github.com/mandelsoft/spiff/compare"
+	"github.com/mandelsoft/spiff/yaml"
+)
+
+// Spec describes a template test. File names are relative to
+// the directory of the spec file.
+type Spec struct {
+	// Name is the name of the test. It defaults to the name of the
+	// spec file (and the index for multiple tests).
+	Name string `json:"name,omitempty"`
+	// Template is the template file to process.
+	Template string `json:"template"`
+	// Stubs are the stub files used for the processing.
+	Stubs []string `json:"stubs,omitempty"`
+	// Values are additional bindings for the processing.
+	Values map[string]interface{} `json:"values,omitempty"`
+	// Tags maps global tag names to yaml files.
+	Tags map[string]string `json:"tags,omitempty"`
+	// Features are the feature flags to enable.
+	Features []string `json:"features,omitempty"`
+	// Select restricts the checked output to the given paths, keeping
+	// their nesting.
+	Select []string `json:"select,omitempty"`
+	// Expected is the golden file containing the expected output.
+	Expected string `json:"expected,omitempty"`
+	// Output is the expected output given inline.
+	Output json.RawMessage `json:"output,omitempty"`
+	// Error is a regular expression the processing error must match.
+	Error string `json:"error,omitempty"`
+	// Keys are the key selectors used to compare lists
+	// (<pattern>=<field>{,<field>}).
+	Keys []string `json:"keys,omitempty"`
+	// Ignore are path patterns excluded from the comparison.
+	Ignore []string `json:"ignore,omitempty"`
+
+	// File is the spec file the test is read from.
+	File string `json:"-"`
+}
+
+type specFile struct {
+	Tests []*Spec `json:"tests"`
+}
+
+// Path provides the path of a file given relative to the spec file.
+func (s *Spec) Path(file string) string {
+	if file == "" || filepath.IsAbs(file) || s.File == "" {
+		return file
+	}
+	return filepath.Join(filepath.Dir(s.File), file)
+}
+
+// Validate checks the consistency of the spec.
+func (s *Spec) Validate() error {
+	if s.Template == "" {
+		return fmt.Errorf("template required")
+	}
+	if s.Expected != "" && s.Output != nil {
+		return fmt.Errorf("expected and output are exclusive")
+	}
+	if s.Error != "" {
+		if s.Expected != "" || s.Output != nil || len(s.Select) > 0 {
+			return fmt.Errorf("error cannot be combined with expected output")
+		}
+		if _, err := regexp.Compile(s.Error); err != nil {
+			return fmt.Errorf("invalid error pattern: %s", err)
+		}
+	}
+	_, err := s.compareOptions()
+	return err
+}
+
+func (s *Spec) compareOptions() (*compare.Options, error) {
+	opts := &compare.Options{Ignore: s.Ignore}
+	for _, k := range s.Keys {
+		sel, err := compare.ParseKeySelector(k)
+		if err != nil {
+			return nil, err
+		}
+		opts.Keys = append(opts.Keys, sel)
+	}
+	return opts, nil
+}
+
+// ParseSpecs parses the tests described by a spec document.
+func ParseSpecs(name string, data []byte) ([]*Spec, error) {
+	node, err := yaml.Parse(name, data)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := node.Value().(map[string]yaml.Node)
+	if !ok {
+		return nil, fmt.Errorf("%s: test spec must be a map", name)
+	}
+	data, err = yaml.ToJSON(node)
+	if err != nil {
+		return nil, err
+	}
+	var specs []*Spec
+	if _, ok := m["tests"]; ok {
+		file := &specFile{}
+		if err := decode(data, file); err != nil {
+			return nil, fmt.Errorf("%s: invalid test spec: %s", name, err)
+		}
+		specs = file.Tests
+	} else {
+		spec := &Spec{}
+		if err := decode(data, spec); err != nil {
+			return nil, fmt.Errorf("%s: invalid test spec: %s", name, err)
+		}
+		specs = []*Spec{spec}
+	}
+	for i, s := range specs {
+		if s == nil {
+			return nil, fmt.Errorf("%s: test %d: empty test spec", name, i+1)
+		}
+		if s.Values != nil {
+			s.Values = numbers(s.Values).(map[string]interface{})
+		}
+		s.File = name
+		if s.Name == "" {
+			s.Name = filepath.Base(name)
+			if len(specs) > 1 {
+				s.Name = fmt.Sprintf("%s[%d]", s.Name, i+1)
+			}
+		}
+		if err := s.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: test %s: %s", name, s.Name, err)
+		}
+	}
+	return specs, nil
+}
+
+// LoadSpecs reads the tests described by a spec file.
+func LoadSpecs(file string) ([]*Spec, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSpecs(file, data)
+}
+
+func decode(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// numbers converts json numbers to the integer and float values
+// used by spiff.
+func numbers(v interface{}) interface{} {
+	switch t := v.(type) {
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i
+		}
+		f, _ := t.Float64()
+		return f
+	case map[string]interface{}:
+		for k, e := range t {
+			t[k] = numbers(e)
+		}
+	case []interface{}:
+		for i, e := range t {
+			t[i] = numbers(e)
+		}
+	}
+	return v
+}
@@ -0,0 +1,147 @@
+package yaml
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/mandelsoft/spiff/legacy/candiedyaml"
+)
+
+// CommentFunction provides the comment for a leaf node of a document.
+// Paths address list entries by their index in the form [<index>].
+type CommentFunction func(path []string, node Node) string
+
+// MarshalWithComments marshals a document in block style and adds
+// the comment provided for every leaf node at the end of its line.
+func MarshalWithComments(node Node, comment CommentFunction) ([]byte, error) {
+	w := &commentWriter{comment: comment}
+	err := w.write(node, nil, 0, "")
+	if err != nil {
+		return nil, err
+	}
+	return w.buf.Bytes(), nil
+}
+
+type commentWriter struct {
+	buf     bytes.Buffer
+	comment CommentFunction
+}
+
+// write emits a node with the given indentation. The first line
+// starts with the given prefix instead of the indentation.
+func (w *commentWriter) write(node Node, path []string, indent int, prefix string) error {
+	switch v := nodeValue(node).(type) {
+	case map[string]Node:
+		if len(v) > 0 {
+			for i, k := range GetOrderedKeys(node) {
+				if i > 0 {
+					prefix = strings.Repeat(" ", indent)
+				}
+				key, err := marshalScalar(k)
+				if err != nil {
+					return err
+				}
+				sub := append(path[:len(path):len(path)], k)
+				switch nested := nodeValue(v[k]).(type) {
+				case map[string]Node:
+					if len(nested) > 0 {
+						w.buf.WriteString(prefix + key + ":\n")
+						err = w.write(v[k], sub, indent+2, strings.Repeat(" ", indent+2))
+						break
+					}
+					err = w.leaf(v[k], sub, indent, prefix+key+": ")
+				case []Node:
+					if len(nested) > 0 {
+						w.buf.WriteString(prefix + key + ":\n")
+						err = w.write(v[k], sub, indent, strings.Repeat(" ", indent))
+						break
+					}
+					err = w.leaf(v[k], sub, indent, prefix+key+": ")
+				default:
+					err = w.leaf(v[k], sub, indent, prefix+key+": ")
+				}
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	case []Node:
+		if len(v) > 0 {
+			for i, e := range v {
+				if i > 0 {
+					prefix = strings.Repeat(" ", indent)
+				}
+				sub := append(path[:len(path):len(path)], fmt.Sprintf("[%d]", i))
+				var err error
+				switch nested := nodeValue(e).(type) {
+				case map[string]Node:
+					if len(nested) > 0 {
+						err = w.write(e, sub, indent+2, prefix+"- ")
+						break
+					}
+					err = w.leaf(e, sub, indent, prefix+"- ")
+				case []Node:
+					if len(nested) > 0 {
+						err = w.write(e, sub, indent+2, prefix+"- ")
+						break
+					}
+					err = w.leaf(e, sub, indent, prefix+"- ")
+				default:
+					err = w.leaf(e, sub, indent, prefix+"- ")
+				}
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	return w.leaf(node, path, indent, prefix)
+}
+
+// leaf emits a leaf node. Continuation lines of multi line values are
+// indented relative to the given indentation.
+func (w *commentWriter) leaf(node Node, path []string, indent int, prefix string) error {
+	value, err := marshalScalar(node)
+	if err != nil {
+		return err
+	}
+	comment := ""
+	if w.comment != nil {
+		comment = w.comment(path, node)
+	}
+	lines := strings.Split(value, "\n")
+	for i := 1; i < len(lines); i++ {
+		lines[i] = strings.Repeat(" ", indent) + lines[i]
+	}
+	if comment != "" {
+		if len(lines) == 1 || strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">") {
+			lines[0] += "  # " + comment
+		} else {
+			// a comment would end a multi line plain or quoted value
+			w.buf.WriteString(strings.Repeat(" ", indent) + "# " + comment + "\n")
+		}
+	}
+	w.buf.WriteString(prefix + strings.Join(lines, "\n") + "\n")
+	return nil
+}
+
+func nodeValue(node Node) interface{} {
+	if node == nil {
+		return nil
+	}
+	return node.Value()
+}
+
+func marshalScalar(value interface{}) (string, error) {
+	if n, ok := value.(Node); value == nil || ok && n.Value() == nil {
+		return "null", nil
+	}
+	data, err := candiedyaml.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(data), "\n"), nil
+}
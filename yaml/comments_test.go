@@ -0,0 +1,48 @@
+package yaml
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Marshalling with comments", func() {
+	pathComment := func(path []string, node Node) string {
+		return strings.Join(path, ".")
+	}
+
+	It("marshals like the standard marshaller without comments", func() {
+		source := "z: 1\na:\n  k:\n  - x: 1\n    \"y\": 2\n  - - 3\n  - {}\n  b: []\n  c: |-\n    x\n    y\n"
+		data, err := MarshalWithComments(parseYAML(source), nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(source))
+	})
+
+	It("adds comments for all leaf nodes", func() {
+		data, err := MarshalWithComments(parseYAML("z: 1\na:\n  k:\n  - x: 1\n  - - 3\n  b: {}\n  c: ~\n"), pathComment)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`z: 1  # z
+a:
+  k:
+  - x: 1  # a.k.[0].x
+  - - 3  # a.k.[1].[0]
+  b: {}  # a.b
+  c: null  # a.c
+`))
+	})
+
+	It("keeps multi line values intact", func() {
+		data, err := MarshalWithComments(parseYAML("a:\n  b: |-\n    x\n    y\n"), pathComment)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("a:\n  b: |-  # a.b\n    x\n    y\n"))
+
+		long := strings.Repeat("word ", 30)
+		data, err = MarshalWithComments(NewNode(map[string]Node{"a": node(long)}, "test"), pathComment)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(HavePrefix("# a\na: 'word"))
+		parsed, err := Parse("test", data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed.Value().(map[string]Node)["a"].Value()).To(Equal(long))
+	})
+})
@@ -1,6 +1,7 @@
 package yaml
 
 import (
+	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
@@ -84,6 +85,14 @@ func nextStep(raw bool, step string, here Node, features features.FeatureFlags)
 }
 
 func stepThroughList(raw bool, here []Node, step string, key string, features features.FeatureFlags) (Node, bool) {
+	index, found := listStep(raw, here, step, key, features)
+	if !found {
+		return nil, false
+	}
+	return here[index], true
+}
+
+func listStep(raw bool, here []Node, step string, key string, features features.FeatureFlags) (int, bool) {
 	match := listIndex.FindStringSubmatch(step)
 	if match != nil {
 		index, err := strconv.Atoi(match[1])
@@ -94,11 +103,11 @@ func stepThroughList(raw bool, here []Node, step string, key string, features fe
 		if index < 0 {
 			index = len(here) + index
 		}
-		if len(here) <= index {
-			return nil, false
+		if index < 0 || len(here) <= index {
+			return 0, false
 		}
 
-		return here[index], true
+		return index, true
 	}
 
 	if key == "" {
@@ -110,23 +119,113 @@ func stepThroughList(raw bool, here []Node, step string, key string, features fe
 		step = step[split+1:]
 	}
 
-	for _, sub := range here {
+	for i, sub := range here {
 		_, ok := sub.Value().(map[string]Node)
 		if !ok {
 			continue
 		}
 
-		name, ok := FindStringR(raw, sub, features, key)
+		name, ok := FindKeyValueR(raw, sub, features, key)
 		if !ok {
 			continue
 		}
 
 		if name == step {
-			return sub, true
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// KeyPaths splits a list key into the paths of its key fields. A list
+// key is a comma separated list of (possibly dotted) field paths, for
+// example kind,metadata.name.
+func KeyPaths(key string) [][]string {
+	var paths [][]string
+	for _, k := range strings.Split(key, ",") {
+		paths = append(paths, strings.Split(strings.TrimSpace(k), "."))
+	}
+	return paths
+}
+
+// NormalizeKey removes the white space from a list key.
+func NormalizeKey(key string) string {
+	keys := strings.Split(key, ",")
+	for i, k := range keys {
+		keys[i] = strings.TrimSpace(k)
+	}
+	return strings.Join(keys, ",")
+}
+
+// KeyField provides the name of the field of a list entry
+// holding (the first component of) a list key.
+func KeyField(key string) string {
+	return KeyPaths(key)[0][0]
+}
+
+// FindKeyValue determines the key value of a list entry for a list key.
+func FindKeyValue(root Node, features features.FeatureFlags, key string) (string, bool) {
+	return FindKeyValueR(false, root, features, key)
+}
+
+// FindKeyValueR determines the key value of a list entry for a list key.
+// Single key fields must have string values. The fields of composite keys
+// may have any scalar value, their values are joined by commas (see
+// JoinKeyValues).
+func FindKeyValueR(raw bool, root Node, features features.FeatureFlags, key string) (string, bool) {
+	paths := KeyPaths(key)
+	var values []string
+	for _, path := range paths {
+		node, ok := FindR(raw, root, features, path...)
+		if !ok {
+			return "", false
+		}
+		value, ok := KeyValue(node, len(paths) > 1)
+		if !ok {
+			return "", false
+		}
+		values = append(values, value)
+	}
+	return JoinKeyValues(values), true
+}
+
+// KeyValue provides the string representation of a value used as list key.
+// Only strings are accepted for single keys, the components of composite
+// keys may be any scalar value.
+func KeyValue(node Node, composite bool) (string, bool) {
+	switch v := node.Value().(type) {
+	case string:
+		return v, true
+	case int64, bool, float64:
+		if composite {
+			return fmt.Sprintf("%v", v), true
 		}
 	}
+	return "", false
+}
+
+// JoinKeyValues provides the key value for the values of the key fields
+// of a list key. The values of composite keys are joined by commas, commas
+// and backslashes in the values are escaped by a backslash to keep the
+// key value unambiguous.
+func JoinKeyValues(values []string) string {
+	if len(values) == 1 {
+		return values[0]
+	}
+	escaped := make([]string, len(values))
+	for i, v := range values {
+		escaped[i] = keyEscaper.Replace(v)
+	}
+	return strings.Join(escaped, ",")
+}
+
+var keyEscaper = strings.NewReplacer(`\`, `\\`, ",", `\,`)
 
-	return nil, false
+// ListIndex determines the index of the list entry addressed
+// by a path step.
+func ListIndex(list []Node, step string, key string) (int, bool) {
+	return listStep(true, list, step, key, nil)
 }
 
 func PathComponent(step string) string {
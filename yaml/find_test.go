@@ -33,7 +33,7 @@ foo:
 			It("accepts string keys to index maps", func() {
 				val, found := Find(tree, nil, "foo", "bar", "baz")
 				Expect(found).To(BeTrue())
-				Expect(val).To(Equal(node("found")))
+				Expect(val).To(Equal(pnode("found", 5, 10)))
 			})
 		})
 
@@ -49,7 +49,7 @@ foo:
 			It("accepts [x] to index lists", func() {
 				val, found := Find(tree, nil, "foo", "bar", "[1]", "fizz")
 				Expect(found).To(BeTrue())
-				Expect(val).To(Equal(node("right")))
+				Expect(val).To(Equal(pnode("right", 6, 13)))
 			})
 		})
 
@@ -85,6 +85,74 @@ bar: 42
 		})
 	})
 
+	Describe("FindKeyValue", func() {
+		tree := parseYAML(`
+---
+kind: Service
+port: 53
+metadata:
+  name: foo
+`)
+
+		It("returns the value of a single key field", func() {
+			found, ok := FindKeyValue(tree, nil, "kind")
+			Expect(ok).To(BeTrue())
+			Expect(found).To(Equal("Service"))
+		})
+
+		It("joins the values of composite and nested keys", func() {
+			found, ok := FindKeyValue(tree, nil, "kind,port,metadata.name")
+			Expect(ok).To(BeTrue())
+			Expect(found).To(Equal("Service,53,foo"))
+		})
+
+		It("escapes commas in the values of composite keys", func() {
+			list := parseYAML(`
+---
+- a: x,y
+  b: z
+- a: x
+  b: y,z
+`)
+			first, ok := FindKeyValue(list.Value().([]Node)[0], nil, "a,b")
+			Expect(ok).To(BeTrue())
+			second, ok := FindKeyValue(list.Value().([]Node)[1], nil, "a,b")
+			Expect(ok).To(BeTrue())
+			Expect(first).To(Equal(`x\,y,z`))
+			Expect(second).To(Equal(`x,y\,z`))
+			found, ok := Find(list, nil, `a,b:x,y\,z`, "b")
+			Expect(ok).To(BeTrue())
+			Expect(found.Value()).To(Equal("y,z"))
+		})
+
+		It("accepts only strings for single keys", func() {
+			_, ok := FindKeyValue(tree, nil, "port")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("returns false for missing or non-scalar key fields", func() {
+			_, ok := FindKeyValue(tree, nil, "kind,metadata.other")
+			Expect(ok).To(BeFalse())
+			_, ok = FindKeyValue(tree, nil, "metadata")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("finds list entries by composite keys", func() {
+			list := parseYAML(`
+---
+- kind: Service
+  metadata:
+    name: foo
+- kind: Deployment
+  metadata:
+    name: foo
+`)
+			found, ok := Find(list, nil, "kind,metadata.name:Deployment,foo", "kind")
+			Expect(ok).To(BeTrue())
+			Expect(found.Value()).To(Equal("Deployment"))
+		})
+	})
+
 	Describe("FindInt", func() {
 
 		Context("when the found node is an int", func() {
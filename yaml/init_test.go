@@ -24,3 +24,7 @@ func parseYAML(source string) Node {
 func node(val interface{}) Node {
 	return NewNode(val, "test")
 }
+
+func pnode(val interface{}, line, column int) Node {
+	return PositionedNode(node(val), Position{line, column})
+}
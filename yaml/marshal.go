@@ -1,6 +1,7 @@
 package yaml
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -17,11 +18,15 @@ func ToJSON(root Node) ([]byte, error) {
 	if root == nil {
 		return ValueToJSON(nil)
 	}
-	return ValueToJSON(root.Value())
+	n, err := normalizeNode(root, true)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(n)
 }
 
 func ValueToJSON(root interface{}) ([]byte, error) {
-	n, err := normalizeValue(root)
+	n, err := normalizeValue(root, false)
 	if err != nil {
 		return nil, err
 	}
@@ -29,26 +34,91 @@ func ValueToJSON(root interface{}) ([]byte, error) {
 }
 
 func Normalize(root Node) (interface{}, error) {
-	if root == nil || root.Value() == nil {
+	return normalizeNode(root, false)
+}
+
+// orderedMap is a normalized map keeping the
+// key order for the JSON serialization.
+type orderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func (m orderedMap) MarshalJSON() ([]byte, error) {
+	buf := bytes.Buffer{}
+	buf.WriteString("{")
+	for i, k := range m.keys {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteString(":")
+		value, err := json.Marshal(m.values[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteString("}")
+	return buf.Bytes(), nil
+}
+
+func normalizeNode(node Node, ordered bool) (interface{}, error) {
+	if node == nil || node.Value() == nil {
 		return nil, nil
 	}
-	return normalizeValue(root.Value())
+	if m, ok := node.Value().(map[string]Node); ok && ordered && len(node.KeyOrder()) > 0 {
+		normalized := orderedMap{values: map[string]interface{}{}}
+		for _, key := range GetOrderedKeys(node) {
+			sub, err := normalizeNode(m[key], ordered)
+			if err != nil {
+				return nil, err
+			}
+			normalized.keys = append(normalized.keys, key)
+			normalized.values[key] = sub
+		}
+		return normalized, nil
+	}
+	return normalizeValue(node.Value(), ordered)
 }
 
-func normalizeValue(value interface{}) (interface{}, error) {
+func normalizeValue(value interface{}, ordered bool) (interface{}, error) {
 	switch rootVal := value.(type) {
 	case candiedyaml.Marshaler:
 		_, v, err := rootVal.MarshalYAML()
 		if err != nil {
 			return nil, err
 		}
-		return normalizeValue(v)
+		return normalizeValue(v, ordered)
+
+	case candiedyaml.MapSlice:
+		normalized := orderedMap{values: map[string]interface{}{}}
+		for _, item := range rootVal {
+			key, ok := item.Key.(string)
+			if !ok {
+				return nil, NonStringKeyError{item.Key}
+			}
+			sub, err := normalizeValue(item.Value, ordered)
+			if err != nil {
+				return nil, err
+			}
+			normalized.keys = append(normalized.keys, key)
+			normalized.values[key] = sub
+		}
+		if !ordered {
+			return normalized.values, nil
+		}
+		return normalized, nil
 
 	case map[string]Node:
 		normalized := map[string]interface{}{}
 
 		for key, val := range rootVal {
-			sub, err := Normalize(val)
+			sub, err := normalizeNode(val, ordered)
 			if err != nil {
 				return nil, err
 			}
@@ -62,7 +132,7 @@ func normalizeValue(value interface{}) (interface{}, error) {
 		normalized := []interface{}{}
 
 		for _, val := range rootVal {
-			sub, err := Normalize(val)
+			sub, err := normalizeNode(val, ordered)
 			if err != nil {
 				return nil, err
 			}
@@ -23,6 +23,9 @@ type Node interface {
 	Value() interface{}
 	Template() interface{}
 	SourceName() string
+	SourcePosition() Position
+	Origin() *Origin
+	KeyOrder() []string
 	RedirectPath() []string
 	Flags() NodeFlags
 	Temporary() bool
@@ -49,9 +52,68 @@ type AnnotatedNode struct {
 	template   interface{}
 	resolver   RefResolver
 	sourceName string
+	position   Position
+	origin     *Origin
+	keys       []string
 	Annotation
 }
 
+// Position describes the location of a node in its source document.
+// Line and column start with 1, the zero value describes an unknown location.
+type Position struct {
+	Line   int
+	Column int
+}
+
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+func (p Position) String() string {
+	if !p.IsValid() {
+		return ""
+	}
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// SourceLocation describes the origin of a node in the
+// form <source name>[:<line>:<column>].
+func SourceLocation(node Node) string {
+	if node == nil {
+		return ""
+	}
+	pos := node.SourcePosition()
+	if !pos.IsValid() {
+		return node.SourceName()
+	}
+	return fmt.Sprintf("%s:%s", node.SourceName(), pos)
+}
+
+// Origin describes where the value of a node has finally been defined.
+// Values computed by an expression describe the expression and its location.
+type Origin struct {
+	Source     string
+	Position   Position
+	Expression string
+}
+
+// NodeOrigin determines the origin of the value of a node. Without
+// explicit origin, this is the location of the node itself.
+func NodeOrigin(node Node) Origin {
+	if o := node.Origin(); o != nil {
+		return *o
+	}
+	return Origin{node.SourceName(), node.SourcePosition(), ""}
+}
+
+// Location describes the origin in the form <source name>[:<line>:<column>].
+func (o Origin) Location() string {
+	if !o.Position.IsValid() {
+		return o.Source
+	}
+	return fmt.Sprintf("%s:%s", o.Source, o.Position)
+}
+
 type Issue struct {
 	Issue    string
 	OrigPath []string
@@ -79,6 +141,10 @@ const (
 	FLAG_IMPLIED  = 0x080
 
 	FLAG_NOMERGE = 0x100
+	FLAG_DELETE  = 0x200
+
+	FLAG_FINAL      = 0x400
+	FLAG_DEPRECATED = 0x800
 )
 
 type NodeFlags int
@@ -132,10 +198,12 @@ func (f NodeFlags) PropagateImplied() bool {
 }
 
 func (f *NodeFlags) Overridden() NodeFlags {
-	if f.Default() {
-		return (*f | FLAG_INJECT) &^ FLAG_DEFAULT
+	// final and deprecated describe the overridden node, only
+	flags := *f &^ (FLAG_FINAL | FLAG_DEPRECATED)
+	if flags.Default() {
+		return (flags | FLAG_INJECT) &^ FLAG_DEFAULT
 	}
-	return *f
+	return flags
 }
 
 func (f NodeFlags) State() bool {
@@ -170,6 +238,30 @@ func (f *NodeFlags) SetNoMerge() *NodeFlags {
 	return f
 }
 
+func (f NodeFlags) Final() bool {
+	return (f & FLAG_FINAL) != 0
+}
+func (f *NodeFlags) SetFinal() *NodeFlags {
+	*f |= FLAG_FINAL
+	return f
+}
+
+func (f NodeFlags) Deprecated() bool {
+	return (f & FLAG_DEPRECATED) != 0
+}
+func (f *NodeFlags) SetDeprecated() *NodeFlags {
+	*f |= FLAG_DEPRECATED
+	return f
+}
+
+func (f NodeFlags) Deleted() bool {
+	return (f & FLAG_DELETE) != 0
+}
+func (f *NodeFlags) SetDeleted() *NodeFlags {
+	*f |= FLAG_DELETE
+	return f
+}
+
 type Annotation struct {
 	redirectPath []string
 	replace      bool
@@ -181,22 +273,45 @@ type Annotation struct {
 	undefined    bool
 	issue        Issue
 	tag          string
+	insertion    string
+	deprecation  string
 	NodeFlags
 }
 
 func copyNode(node Node) AnnotatedNode {
-	return AnnotatedNode{node.Value(), node.Template(), node.Resolver(), node.SourceName(), node.GetAnnotation()}
+	return AnnotatedNode{node.Value(), node.Template(), node.Resolver(), node.SourceName(), node.SourcePosition(), node.Origin(), node.KeyOrder(), node.GetAnnotation()}
 }
 func copyNodeAnnotated(node Node, anno Annotation) AnnotatedNode {
-	return AnnotatedNode{node.Value(), node.Template(), node.Resolver(), node.SourceName(), anno}
+	return AnnotatedNode{node.Value(), node.Template(), node.Resolver(), node.SourceName(), node.SourcePosition(), node.Origin(), node.KeyOrder(), anno}
 }
 
 func NewNode(value interface{}, sourcePath string) Node {
-	return AnnotatedNode{MassageType(value), nil, nil, sourcePath, EmptyAnnotation()}
+	return AnnotatedNode{MassageType(value), nil, nil, sourcePath, Position{}, nil, nil, EmptyAnnotation()}
 }
 
 func NewDynamicNode(value, template interface{}, sourcePath string) Node {
-	return AnnotatedNode{MassageType(value), template, nil, sourcePath, EmptyAnnotation().SetInjected().SetDynamic()}
+	return AnnotatedNode{MassageType(value), template, nil, sourcePath, Position{}, nil, nil, EmptyAnnotation().SetInjected().SetDynamic()}
+}
+
+func PositionedNode(node Node, pos Position) Node {
+	n := copyNode(node)
+	n.position = pos
+	return n
+}
+
+// OriginNode sets the origin of the value of a node.
+func OriginNode(node Node, origin *Origin) Node {
+	n := copyNode(node)
+	n.origin = origin
+	return n
+}
+
+// OrderedNode sets the preferred order of the keys of a map node.
+// Keys of the map not mentioned are ordered after the given ones.
+func OrderedNode(node Node, keys []string) Node {
+	n := copyNode(node)
+	n.keys = keys
+	return n
 }
 
 func ResolverNode(node Node, resolver RefResolver) Node {
@@ -210,6 +325,20 @@ func ReplaceValue(value interface{}, node Node) Node {
 	n.value = value
 	return n
 }
+
+// EqualAnnotations checks whether two nodes are described
+// by the same meta data, regardless of their values.
+func EqualAnnotations(a, b Node) bool {
+	an, aok := a.(AnnotatedNode)
+	bn, bok := b.(AnnotatedNode)
+	if !aok || !bok {
+		return reflect.DeepEqual(ReplaceValue(nil, a), ReplaceValue(nil, b))
+	}
+	an.value = nil
+	bn.value = nil
+	return reflect.DeepEqual(an, bn)
+}
+
 func ReferencedNode(node Node) Node {
 	return copyNodeAnnotated(node, NewReferencedAnnotation(node))
 }
@@ -256,10 +385,22 @@ func AddFlags(node Node, flags NodeFlags) Node {
 	return copyNodeAnnotated(node, node.GetAnnotation().AddFlags(flags))
 }
 
+func RemoveFlags(node Node, flags NodeFlags) Node {
+	return copyNodeAnnotated(node, node.GetAnnotation().RemoveFlags(flags))
+}
+
 func SetTag(node Node, tag string) Node {
 	return copyNodeAnnotated(node, node.GetAnnotation().SetTag(tag))
 }
 
+func SetInsertion(node Node, insertion string) Node {
+	return copyNodeAnnotated(node, node.GetAnnotation().SetInsertion(insertion))
+}
+
+func DeprecatedNode(node Node, msg string) Node {
+	return copyNodeAnnotated(node, node.GetAnnotation().SetDeprecation(msg))
+}
+
 func TemporaryNode(node Node) Node {
 	return copyNodeAnnotated(node, node.GetAnnotation().SetTemporary())
 }
@@ -289,11 +430,11 @@ func MassageType(value interface{}) interface{} {
 }
 
 func EmptyAnnotation() Annotation {
-	return Annotation{nil, false, false, false, "", false, false, false, Issue{}, "", 0}
+	return Annotation{nil, false, false, false, "", false, false, false, Issue{}, "", "", "", 0}
 }
 
 func NewReferencedAnnotation(node Node) Annotation {
-	return Annotation{nil, false, false, false, node.KeyName(), node.HasError(), node.Failed(), node.Undefined(), node.Issue(), "", 0}
+	return Annotation{nil, false, false, false, node.KeyName(), node.HasError(), node.Failed(), node.Undefined(), node.Issue(), "", "", "", 0}
 }
 
 func (n Annotation) Flags() NodeFlags {
@@ -328,6 +469,17 @@ func (n Annotation) Tag() string {
 	return n.tag
 }
 
+// Insertion describes the requested position of a list entry
+// in the form before:<key> or after:<key>.
+func (n Annotation) Insertion() string {
+	return n.insertion
+}
+
+// Deprecation provides the message of a node marked as deprecated.
+func (n Annotation) Deprecation() string {
+	return n.deprecation
+}
+
 func (n Annotation) HasError() bool {
 	return n.error
 }
@@ -349,6 +501,11 @@ func (n Annotation) AddFlags(flags NodeFlags) Annotation {
 	return n
 }
 
+func (n Annotation) RemoveFlags(flags NodeFlags) Annotation {
+	n.NodeFlags &^= flags
+	return n
+}
+
 func (n Annotation) SetTemporary() Annotation {
 	n.NodeFlags.SetTemporary()
 	return n
@@ -409,6 +566,17 @@ func (n Annotation) SetTag(tag string) Annotation {
 	return n
 }
 
+func (n Annotation) SetInsertion(insertion string) Annotation {
+	n.insertion = insertion
+	return n
+}
+
+func (n Annotation) SetDeprecation(msg string) Annotation {
+	n.NodeFlags.SetDeprecated()
+	n.deprecation = msg
+	return n
+}
+
 func (n Annotation) SetUndefined() Annotation {
 	n.undefined = true
 	return n
@@ -438,6 +606,18 @@ func (n AnnotatedNode) SourceName() string {
 	return n.sourceName
 }
 
+func (n AnnotatedNode) SourcePosition() Position {
+	return n.position
+}
+
+func (n AnnotatedNode) Origin() *Origin {
+	return n.origin
+}
+
+func (n AnnotatedNode) KeyOrder() []string {
+	return n.keys
+}
+
 func (n AnnotatedNode) Template() interface{} {
 	return n.template
 }
@@ -453,6 +633,13 @@ func (n AnnotatedNode) GetAnnotation() Annotation {
 func (n AnnotatedNode) MarshalYAML() (string, interface{}, error) {
 	v := n.Value()
 
+	if m, ok := v.(map[string]Node); ok && len(n.keys) > 0 {
+		ordered := candiedyaml.MapSlice{}
+		for _, k := range GetOrderedKeys(n) {
+			ordered = append(ordered, candiedyaml.MapItem{Key: k, Value: m[k]})
+		}
+		return "", ordered, nil
+	}
 	m, ok := v.(candiedyaml.Marshaler)
 	for ok {
 		_, v, _ = m.MarshalYAML()
@@ -549,34 +736,42 @@ func UnescapeDynaml(root Node, interpol bool) Node {
 			strings.HasSuffix(value, "))") {
 			sub := value[2 : len(value)-2]
 			if strings.HasPrefix(sub, "!") {
-				return NewNode("(("+sub[1:]+"))", root.SourceName())
+				return OriginNode(PositionedNode(NewNode("(("+sub[1:]+"))", root.SourceName()), root.SourcePosition()), root.Origin())
 			}
 			return root
 		}
 		if interpol {
 			str, _ := convertToExpression(value, true)
 			if str != nil && *str != value {
-				return NewNode(*str, root.SourceName())
+				return OriginNode(PositionedNode(NewNode(*str, root.SourceName()), root.SourcePosition()), root.Origin())
 			}
 		}
 	case map[string]Node:
 		new := map[string]Node{}
 		found := false
 		for k, v := range value {
-			switch {
-			case strings.HasPrefix(k, "<<!"):
-				found = true
-				new["<<"+k[3:]] = v
-			case strings.HasPrefix(k, MERGEKEY+"!"):
-				found = true
-				new[MERGEKEY+k[len(MERGEKEY)+1:]] = v
-			default:
-				new[k] = v
-			}
+			k, esc := unescapeMergeKey(k)
+			found = found || esc
+			new[k] = v
 		}
 		if found {
-			return NewNode(new, root.SourceName())
+			var keys []string
+			for _, k := range root.KeyOrder() {
+				k, _ = unescapeMergeKey(k)
+				keys = append(keys, k)
+			}
+			return OrderedNode(OriginNode(PositionedNode(NewNode(new, root.SourceName()), root.SourcePosition()), root.Origin()), keys)
 		}
 	}
 	return root
 }
+
+func unescapeMergeKey(k string) (string, bool) {
+	switch {
+	case strings.HasPrefix(k, "<<!"):
+		return "<<" + k[3:], true
+	case strings.HasPrefix(k, MERGEKEY+"!"):
+		return MERGEKEY + k[len(MERGEKEY)+1:], true
+	}
+	return k, false
+}
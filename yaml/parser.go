@@ -47,6 +47,7 @@ func ParseMulti(sourceName string, source []byte) ([]Node, error) {
 	}
 	r := bytes.NewBuffer(source)
 	d := candiedyaml.NewDecoder(r)
+	d.UsePositions()
 
 	for d.HasNext() {
 		var parsed interface{}
@@ -68,6 +69,24 @@ var arrayType = reflect.TypeOf([]interface{}{})
 
 func Sanitize(sourceName string, root interface{}) (Node, error) {
 	switch rootVal := root.(type) {
+	case candiedyaml.Positioned:
+		n, err := Sanitize(sourceName, rootVal.Value)
+		if err != nil {
+			return nil, err
+		}
+		n = PositionedNode(n, Position{rootVal.Line, rootVal.Column})
+		if rootVal.Keys != nil {
+			keys := []string{}
+			for _, k := range rootVal.Keys {
+				str, ok := k.(string)
+				if !ok {
+					return nil, NonStringKeyError{k}
+				}
+				keys = append(keys, str)
+			}
+			n = OrderedNode(n, keys)
+		}
+		return n, nil
 	case time.Time:
 		return NewNode(rootVal.Format("2019-01-08T10:06:26Z"), sourceName), nil
 	case map[interface{}]interface{}:
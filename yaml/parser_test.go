@@ -11,12 +11,12 @@ var _ = Describe("YAML Parser", func() {
 		It("parses maps as strings mapping to Nodes", func() {
 			parsed, err := Parse("test", []byte(`foo: "fizz \"buzz\""`))
 			Expect(err).NotTo(HaveOccurred())
-			Expect(parsed).To(Equal(node(map[string]Node{"foo": node(`fizz "buzz"`)})))
+			Expect(parsed).To(Equal(OrderedNode(pnode(map[string]Node{"foo": pnode(`fizz "buzz"`, 1, 6)}, 1, 1), []string{"foo"})))
 		})
 
 		It("parses maps with block string values", func() {
-			parsesAs("foo: |\n  sup\n  :3", map[string]Node{"foo": node("sup\n:3")})
-			parsesAs("foo: >\n  sup\n  :3", map[string]Node{"foo": node("sup :3")})
+			parsesAs("foo: |\n  sup\n  :3", map[string]Node{"foo": pnode("sup\n:3", 1, 6)}, "foo")
+			parsesAs("foo: >\n  sup\n  :3", map[string]Node{"foo": pnode("sup :3", 1, 6)}, "foo")
 		})
 
 		Context("keys are not strings", func() {
@@ -29,7 +29,7 @@ var _ = Describe("YAML Parser", func() {
 
 	Context("value is a list", func() {
 		It("parses with Node contents", func() {
-			parsesAs("- 1\n- two", []Node{node(1), node("two")})
+			parsesAs("- 1\n- two", []Node{pnode(1, 1, 3), pnode("two", 2, 3)})
 		})
 	})
 
@@ -65,6 +65,35 @@ var _ = Describe("YAML Parser", func() {
 	//	})
 	//})
 
+	Context("key order", func() {
+		It("preserves the key order of maps", func() {
+			parsed, err := Parse("test", []byte("z: 1\na:\n  k: 2\n  b: 3\n"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(GetOrderedKeys(parsed)).To(Equal([]string{"z", "a"}))
+
+			data, err := Marshal(parsed)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("z: 1\na:\n  k: 2\n  b: 3\n"))
+
+			data, err = ToJSON(parsed)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal(`{"z":1,"a":{"k":2,"b":3}}`))
+		})
+
+		It("sorts keys on request", func() {
+			parsed, err := Parse("test", []byte("z: 1\na:\n  k: 2\n  b: 3\n"))
+			Expect(err).NotTo(HaveOccurred())
+
+			data, err := Marshal(SortKeys(parsed))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("a:\n  b: 3\n  k: 2\nz: 1\n"))
+
+			data, err = ToJSON(SortKeys(parsed))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal(`{"a":{"b":3,"k":2},"z":1}`))
+		})
+	})
+
 	Context("parsing multi documents", func() {
 		It("returns all documents", func() {
 			sourceName := "test"
@@ -81,8 +110,12 @@ doc2:
 	})
 })
 
-func parsesAs(source string, expr interface{}) {
+func parsesAs(source string, expr interface{}, keys ...string) {
 	parsed, err := Parse("test", []byte(source))
 	Expect(err).NotTo(HaveOccurred())
-	Expect(parsed).To(Equal(node(expr)))
+	expected := pnode(expr, 1, 1)
+	if keys != nil {
+		expected = OrderedNode(expected, keys)
+	}
+	Expect(parsed).To(Equal(expected))
 }
@@ -18,3 +18,54 @@ func GetSortedKeys(unsortedMap map[string]Node) []string {
 	sort.Strings(keys)
 	return keys
 }
+
+// GetOrderedKeys returns the keys of a map node according to its
+// key order. Keys not covered by the key order are appended sorted.
+func GetOrderedKeys(node Node) []string {
+	m, ok := node.Value().(map[string]Node)
+	if !ok {
+		return nil
+	}
+	keys := []string{}
+	found := map[string]bool{}
+	for _, k := range node.KeyOrder() {
+		if _, ok := m[k]; ok && !found[k] {
+			found[k] = true
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == len(m) {
+		return keys
+	}
+	rest := []string{}
+	for k := range m {
+		if !found[k] {
+			rest = append(rest, k)
+		}
+	}
+	sort.Strings(rest)
+	return append(keys, rest...)
+}
+
+// SortKeys removes the key order from all map nodes of a node tree,
+// resulting in the plain sorted order when marshalling.
+func SortKeys(node Node) Node {
+	if node == nil {
+		return nil
+	}
+	switch v := node.Value().(type) {
+	case map[string]Node:
+		r := map[string]Node{}
+		for k, e := range v {
+			r[k] = SortKeys(e)
+		}
+		return OrderedNode(ReplaceValue(r, node), nil)
+	case []Node:
+		r := []Node{}
+		for _, e := range v {
+			r = append(r, SortKeys(e))
+		}
+		return ReplaceValue(r, node)
+	}
+	return node
+}